@@ -27,28 +27,33 @@ const (
 )
 
 const (
-	ApiGet           = "api.get"
-	ApiPost          = "api.post"
-	ApiPut           = "api.put"
-	ApiPatch         = "api.patch"
-	ApiDelete        = "api.delete"
-	ApiOptions       = "api.options"
-	ApiHEAD          = "api.head"
-	ApiAny           = "api.any"
-	ApiQuery         = "api.query"
-	ApiForm          = "api.form"
-	ApiPath          = "api.path"
-	ApiHeader        = "api.header"
-	ApiCookie        = "api.cookie"
-	ApiBody          = "api.body"
-	ApiRawBody       = "api.raw_body"
-	ApiBaseDomain    = "api.base_domain"
-	ApiBaseURL       = "api.baseurl"
-	OpenapiOperation = "openapi.operation"
-	OpenapiProperty  = "openapi.property"
-	OpenapiSchema    = "openapi.schema"
-	OpenapiParameter = "openapi.parameter"
-	OpenapiDocument  = "openapi.document"
+	ApiGet            = "api.get"
+	ApiPost           = "api.post"
+	ApiPut            = "api.put"
+	ApiPatch          = "api.patch"
+	ApiDelete         = "api.delete"
+	ApiOptions        = "api.options"
+	ApiHEAD           = "api.head"
+	ApiAny            = "api.any"
+	ApiQuery          = "api.query"
+	ApiForm           = "api.form"
+	ApiPath           = "api.path"
+	ApiHeader         = "api.header"
+	ApiCookie         = "api.cookie"
+	ApiBody           = "api.body"
+	ApiRawBody        = "api.raw_body"
+	ApiBaseDomain     = "api.base_domain"
+	ApiBaseURL        = "api.baseurl"
+	OpenapiOperation  = "openapi.operation"
+	OpenapiProperty   = "openapi.property"
+	OpenapiSchema     = "openapi.schema"
+	OpenapiParameter  = "openapi.parameter"
+	OpenapiDocument   = "openapi.document"
+	OpenapiComponent  = "openapi.component"
+	OpenapiVisibility = "openapi.visibility"
+	ApiDeprecated     = "api.deprecated"
+	ApiSafe           = "api.safe"
+	Deprecated        = "deprecated"
 )
 
 const (
@@ -100,16 +105,60 @@ const (
 	DefaultOutputDir         = "swagger"
 	DefaultOutputYamlFile    = "openapi.yaml"
 	DefaultOutputSwaggerFile = "swagger.go"
+	DefaultOutputIdlFile     = "idl.go"
+	DefaultOutputNoopFile    = "swagger_noop.go"
+
+	// BuildConstraintSwagger excludes the swagger files from the builds with the noswagger tag, which compile
+	// the no-op swagger file instead.
+	BuildConstraintSwagger = "//go:build !noswagger"
+	BuildConstraintNoop    = "//go:build noswagger"
 
 	DefaultServerURL = "http://127.0.0.1:8888"
 	DefaultKitexAddr = "127.0.0.1:8888"
 
-	ParameterNameTTHeader = "ttheader"
-	ParameterDescription  = "metainfo for request"
+	TransportTTHeader       = "ttheader"
+	TransportTTHeaderFramed = "ttheader_framed"
+	TransportFramed         = "framed"
+	TransportBuffered       = "buffered"
+	TransportGRPC           = "grpc"
+
+	CodecJSON   = "json"
+	CodecBinary = "binary"
+
+	MetaHeaderPrefix                = "X-Meta-"
+	MetaHeaderPrefixPersistent      = "X-Meta-Persist-"
+	MetaHeaderPrefixBackward        = "X-Meta-Backward-"
+	MetaHeaderDescription           = "metainfo %s of the call"
+	MetaHeaderDescriptionPersistent = "persistent metainfo %s of the call, transmitted to the downstream calls"
+	MetaHeaderDescriptionBackward   = "backward metainfo %s returned by the server"
 
-	CommentPatternRegexp    = `//\s*(.*)|/\*([\s\S]*?)\*/`
+	CommentPatternRegexp    = `//([^\n]*)|/\*([\s\S]*?)\*/`
 	LinterRulePatternRegexp = `\(-- .* --\)`
 
+	ExtensionDeprecatedReason     = "x-deprecated-reason"
+	ExtensionDeprecatedEnumValues = "x-deprecated-enum-values"
+	ExtensionComponent            = "x-component"
+	ExtensionSafe                 = "x-safe"
+
 	ProtobufValueName = "GoogleProtobufValue"
 	ProtobufAnyName   = "GoogleProtobufAny"
 )
+
+const (
+	DefaultBaseStruct     = "Base"
+	DefaultBaseRespStruct = "BaseResp"
+	DefaultBaseFieldID    = 255
+
+	BaseModeKeep         = "keep"
+	BaseModeHide         = "hide"
+	BaseModeHeader       = "header"
+	BaseRespModeEnvelope = "envelope"
+
+	ComponentSchemaSuffixEnvelope = "Envelope"
+	BaseRespEnvelopeDesc          = "The envelope of the responses, a non-zero status code reports an error with its status message"
+)
+
+const (
+	VisibilityPublic   = "public"
+	VisibilityInternal = "internal"
+)
@@ -19,580 +19,580 @@ package tpl
 const ServerTemplateHttp = `package swagger
 
 import (
-	"context"
 	_ "embed"
+	"io/fs"
 
-	"github.com/cloudwego/hertz/pkg/app"
 	"github.com/cloudwego/hertz/pkg/app/server"
-	"github.com/hertz-contrib/cors"
-	"github.com/hertz-contrib/swagger"
-	swaggerFiles "github.com/swaggo/files"
+	swaggerruntime "github.com/hertz-contrib/swagger-generate/swagger-runtime"
 )
 
 //go:embed openapi.yaml
 var openapiYAML []byte
 
-func BindSwagger(h *server.Hertz) {
-	h.Use(cors.Default())
-
-	h.GET("/swagger/*any", swagger.WrapHandler(
-		swaggerFiles.Handler,
-		swagger.URL("/openapi.yaml"),
-	))
-
-	h.GET("/openapi.yaml", func(c context.Context, ctx *app.RequestContext) {
-		ctx.Header("Content-Type", "application/x-yaml")
-		ctx.Write(openapiYAML)
-	})
+// BindSwagger binds the swagger endpoints to a Hertz server, with the options of Swagger changed by the options,
+// unless they are disabled by Access. The middlewares of Access only apply to these endpoints.
+func BindSwagger(h *server.Hertz, opts ...SwaggerOption) {
+	if !Access.Enabled {
+		return
+	}
+	options := Swagger
+	for _, opt := range opts {
+		opt(&options)
+	}
+	g := h.Group("", swaggerruntime.AccessControl(Access)...)
+	swaggerruntime.BindDocuments(g, options, swaggerruntime.Document{YAML: openapiYAML})
 }
-`
+` + runtimeOptions + runtimeOptionFuncs
 
 const ServerTemplateRpc = `package swagger
 
 import (
 	"context"
-	_ "embed"
-	"encoding/json"
-	"errors"
-	"fmt"
-	"net"
-	"net/http"
 	"os"
-	"path/filepath"
-	"regexp"
-	"strings"
 
-	"github.com/bytedance/gopkg/cloud/metainfo"
-	"github.com/cloudwego/hertz/pkg/app"
-	"github.com/cloudwego/hertz/pkg/app/server"
-	"github.com/cloudwego/hertz/pkg/common/hlog"
 	"github.com/cloudwego/hertz/pkg/network"
-	"github.com/cloudwego/hertz/pkg/route"
-	"github.com/cloudwego/kitex/client"
-	"github.com/cloudwego/kitex/client/genericclient"
-	"github.com/cloudwego/kitex/pkg/endpoint"
-	"github.com/cloudwego/kitex/pkg/generic"
-	"github.com/cloudwego/kitex/pkg/klog"
 	"github.com/cloudwego/kitex/pkg/remote"
-	"github.com/cloudwego/kitex/pkg/remote/trans/detection"
-	"github.com/cloudwego/kitex/pkg/remote/trans/netpoll"
-	"github.com/cloudwego/kitex/pkg/remote/trans/nphttp2"
-	"github.com/cloudwego/kitex/pkg/transmeta"
-	"github.com/cloudwego/kitex/transport"
-	"github.com/hertz-contrib/cors"
-	"github.com/hertz-contrib/swagger"
-	swaggerFiles "github.com/swaggo/files"
-)
-
-var (
-	//go:embed openapi.yaml
-	openapiYAML []byte
-	hertzEngine *route.Engine
-	httpReg     = regexp.MustCompile("^(?:GET |POST|PUT|DELE|HEAD|OPTI|CONN|TRAC|PATC)$")
+	swaggerruntime "github.com/hertz-contrib/swagger-generate/swagger-runtime"
+	"github.com/hertz-contrib/swagger-generate/swagger-runtime/kitexproxy"
 )
 
 const (
-	kitexAddr = "{{.KitexAddr}}"
-	idlFile   = "{{.IdlPath}}"
+	kitexAddr             = "{{.KitexAddr}}"
+	defaultTransport      = "{{.Transport}}"
+	defaultRPCTimeout     = "{{.RPCTimeout}}"
+	defaultConnectTimeout = "{{.ConnectTimeout}}"
+	defaultMaxRetries     = {{.MaxRetries}}
+	defaultCodec          = "{{.Codec}}"
 )
 
-type MixTransHandlerFactory struct {
-	OriginFactory remote.ServerTransHandlerFactory
+// ProxyOptions are the options of the proxy: the options of the plugin, overridden by the SWAGGER_TRANSPORT,
+// SWAGGER_RPC_TIMEOUT, SWAGGER_CONNECT_TIMEOUT, SWAGGER_MAX_RETRIES, SWAGGER_CODEC, SWAGGER_MAX_BODY_SIZE,
+// SWAGGER_READ_ONLY, SWAGGER_ADDR environment variables.
+// They are changed before the Kitex server starts.
+var ProxyOptions = kitexproxy.LoadOptions(kitexproxy.Defaults{
+	Transport:      defaultTransport,
+	RPCTimeout:     defaultRPCTimeout,
+	ConnectTimeout: defaultConnectTimeout,
+	MaxRetries:     defaultMaxRetries,
+	Codec:          defaultCodec,
+})
+
+// proxy is the swagger server of the package, serving the IDLs registered by the idl.go files.
+var proxy = &kitexproxy.Server{
+	Addr:     kitexAddr,
+	Protocol: kitexproxy.Thrift,
+	Options:  &ProxyOptions,
+	Access:   &Access,
+	Swagger:  &Swagger,
+	IdlDir:   &IdlDir,
 }
+` + rpcServer + runtimeOptions
 
-type transHandler struct {
-	remote.ServerTransHandler
-}
-
-func (t *transHandler) SetInvokeHandleFunc(inkHdlFunc endpoint.Endpoint) {
-	t.ServerTransHandler.(remote.InvokeHandleFuncSetter).SetInvokeHandleFunc(inkHdlFunc)
-}
-
-func (m MixTransHandlerFactory) NewTransHandler(opt *remote.ServerOption) (remote.ServerTransHandler, error) {
+const ServerTemplateRpcPb = `package swagger
 
-	if hertzEngine == nil {
-		StartServer()
-	}
+import (
+	"context"
+	"os"
 
-	var kitexOrigin remote.ServerTransHandler
-	var err error
+	"github.com/cloudwego/hertz/pkg/network"
+	"github.com/cloudwego/kitex/pkg/remote"
+	swaggerruntime "github.com/hertz-contrib/swagger-generate/swagger-runtime"
+	"github.com/hertz-contrib/swagger-generate/swagger-runtime/kitexproxy"
+)
 
-	if m.OriginFactory != nil {
-		kitexOrigin, err = m.OriginFactory.NewTransHandler(opt)
-	} else {
-		kitexOrigin, err = detection.NewSvrTransHandlerFactory(netpoll.NewSvrTransHandlerFactory(), nphttp2.NewSvrTransHandlerFactory()).NewTransHandler(opt)
-	}
-	if err != nil {
-		return nil, err
-	}
-	return &transHandler{ServerTransHandler: kitexOrigin}, nil
-}
-
-func (t *transHandler) OnRead(ctx context.Context, conn net.Conn) error {
-	c, ok := conn.(network.Conn)
-	if ok {
-		pre, _ := c.Peek(4)
-		if httpReg.Match(pre) {
-			klog.Info("using Hertz to process request")
-			err := hertzEngine.Serve(ctx, c)
-			if err != nil {
-				err = errors.New(fmt.Sprintf("HERTZ: %s", err.Error()))
-			}
-			return err
-		}
-	}
+const (
+	kitexAddr             = "{{.KitexAddr}}"
+	defaultTransport      = "{{.Transport}}"
+	defaultRPCTimeout     = "{{.RPCTimeout}}"
+	defaultConnectTimeout = "{{.ConnectTimeout}}"
+	defaultMaxRetries     = {{.MaxRetries}}
+)
 
-	return t.ServerTransHandler.OnRead(ctx, conn)
+// ProxyOptions are the options of the proxy: the options of the plugin, overridden by the SWAGGER_TRANSPORT,
+// SWAGGER_RPC_TIMEOUT, SWAGGER_CONNECT_TIMEOUT, SWAGGER_MAX_RETRIES, SWAGGER_MAX_BODY_SIZE, SWAGGER_READ_ONLY,
+// SWAGGER_ADDR environment variables.
+// They are changed before the Kitex server starts.
+var ProxyOptions = kitexproxy.LoadOptions(kitexproxy.Defaults{
+	Transport:      defaultTransport,
+	RPCTimeout:     defaultRPCTimeout,
+	ConnectTimeout: defaultConnectTimeout,
+	MaxRetries:     defaultMaxRetries,
+})
+
+// proxy is the swagger server of the package, serving the IDLs registered by the idl.go files.
+var proxy = &kitexproxy.Server{
+	Addr:     kitexAddr,
+	Protocol: kitexproxy.Protobuf,
+	Options:  &ProxyOptions,
+	Access:   &Access,
+	Swagger:  &Swagger,
+	IdlDir:   &IdlDir,
 }
+` + rpcServer + runtimeOptions
 
-func StartServer() {
-	h := server.Default()
-	h.Use(cors.Default())
+// rpcServer declares the API of the RPC swagger servers on their server of kitexproxy.
+const rpcServer = `
+// Options are the options of the proxy and of its generic clients.
+type Options = kitexproxy.Options
 
-	cli := initializeGenericClient()
-	setupSwaggerRoutes(h)
-	setupProxyRoutes(h, cli)
+// ProtocolDetector reports whether a connection of the Kitex server carries HTTP requests served by the swagger
+// server, peeking at its first bytes without reading them.
+type ProtocolDetector = swaggerruntime.ProtocolDetector
 
-	hlog.Info("Swagger UI is available at: http://" + kitexAddr + "/swagger/index.html")
-	err := h.Engine.Init()
-	if err != nil {
-		panic(err)
-	}
+// IdlDir reads the IDL files from this directory, by the paths they are embedded with, instead of the files
+// embedded in the idl.go files, to try the edits of the IDL without regenerating. The files missing from it
+// stay embedded. It is read from the SWAGGER_IDL_DIR environment variable by default.
+var IdlDir = os.Getenv("SWAGGER_IDL_DIR")
 
-	hertzEngine = h.Engine
+// MixTransHandlerFactory creates the transport handlers of the Kitex server, which serve the HTTP requests
+// of its connections with the swagger server, and the other requests with the handlers of OriginFactory,
+// or of Kitex if nil. The swagger server is started with them, unless it is disabled by Access.
+type MixTransHandlerFactory struct {
+	OriginFactory remote.ServerTransHandlerFactory
 }
 
-func findThriftFile(fileName string) (string, error) {
-	workingDir, err := os.Getwd()
-	if err != nil {
-		return "", err
-	}
-
-	foundPath := ""
-	relativePath := fileName
-
-	err = filepath.Walk(workingDir, func(path string, info os.FileInfo, err error) error {
-		if err != nil {
-			return err
-		}
-
-		if !info.IsDir() {
-			relative, err := filepath.Rel(workingDir, path)
-			if err != nil {
-				return err
-			}
-
-			if relative == relativePath {
-				foundPath = path
-				return filepath.SkipDir
-			}
-		}
-		return nil
-	})
-
-	if err == nil && foundPath != "" {
-		return foundPath, nil
-	}
-
-	parentDir := filepath.Dir(workingDir)
-	for parentDir != "/" && parentDir != "." && parentDir != workingDir {
-		filePath := filepath.Join(parentDir, fileName)
-		if _, err := os.Stat(filePath); err == nil {
-			return filePath, nil
-		}
-		workingDir = parentDir
-		parentDir = filepath.Dir(parentDir)
-	}
-
-	return "", errors.New("thrift file not found: " + fileName)
+func (m MixTransHandlerFactory) NewTransHandler(opt *remote.ServerOption) (remote.ServerTransHandler, error) {
+	return proxy.NewTransHandler(m.OriginFactory, opt)
 }
 
-func initializeGenericClient() genericclient.Client {
-	thriftFile, err := findThriftFile(idlFile)
-	if err != nil {
-		hlog.Fatal("Failed to locate Thrift file:", err)
-	}
-
-	p, err := generic.NewThriftFileProviderWithDynamicGo(thriftFile)
-	if err != nil {
-		hlog.Fatal("Failed to create ThriftFileProvider:", err)
-	}
-
-	g, err := generic.JSONThriftGeneric(p)
-	if err != nil {
-		hlog.Fatal("Failed to create JsonThriftGeneric:", err)
-	}
-	var opts []client.Option
-	opts = append(opts, client.WithTransportProtocol(transport.TTHeader))
-	opts = append(opts, client.WithMetaHandler(transmeta.ClientTTHeaderHandler))
-	opts = append(opts, client.WithHostPorts(kitexAddr))
-	cli, err := genericclient.NewClient("swagger", g, opts...)
-	if err != nil {
-		hlog.Fatal("Failed to create generic client:", err)
-	}
-
-	return cli
+// StartServer starts the swagger server once, listening on ProxyOptions.Addr if set, and serving the HTTP
+// requests of the connections of the Kitex server otherwise. It is called when the Kitex server starts.
+func StartServer() {
+	proxy.Start()
 }
 
-func setupSwaggerRoutes(h *server.Hertz) {
-	h.GET("swagger/*any", swagger.WrapHandler(swaggerFiles.Handler, swagger.URL("/openapi.yaml")))
+// StopServer stops the swagger server and closes the generic clients of the proxy once its calls in flight
+// are done, the new ones being rejected. It is called when the Kitex server shuts down, and the swagger server
+// is not started again.
+func StopServer(ctx context.Context) error {
+	return proxy.Stop(ctx)
+}
 
-	h.GET("/openapi.yaml", func(c context.Context, ctx *app.RequestContext) {
-		ctx.Header("Content-Type", "application/x-yaml")
-		ctx.Write(openapiYAML)
-	})
+// DetectHTTP1 detects the HTTP/1.x requests by their method followed by a space, the Detector of ProxyOptions
+// by default.
+func DetectHTTP1(conn network.Conn) bool {
+	return swaggerruntime.DetectHTTP1(conn)
 }
+`
 
-func setupProxyRoutes(h *server.Hertz, cli genericclient.Client) {
-	h.Any("/*ServiceMethod", func(c context.Context, ctx *app.RequestContext) {
-		serviceMethod := ctx.Param("ServiceMethod")
-		if serviceMethod == "" {
-			handleError(ctx, "ServiceMethod not provided", http.StatusBadRequest)
-			return
-		}
+// runtimeOptions declares the options of the swagger endpoints of the swagger servers, loaded by swagger-runtime.
+const runtimeOptions = `
+// AccessOptions control the access to the swagger endpoints, and to the proxy of the RPC servers.
+type AccessOptions = swaggerruntime.AccessOptions
 
-		bodyBytes := ctx.Request.Body()
+// Access are the access options of the swagger endpoints, from the SWAGGER_ENABLED, SWAGGER_USERNAME,
+// SWAGGER_PASSWORD, SWAGGER_TOKEN, SWAGGER_ALLOWED_IPS and SWAGGER_CORS_ORIGINS environment variables,
+// the lists separated by commas. They are changed before the endpoints are bound.
+var Access = swaggerruntime.LoadAccessOptions()
 
-		queryMap := formatQueryParams(ctx)
+// UI is a UI of the documents served by the swagger endpoints.
+type UI = swaggerruntime.UI
 
-		for k, v := range queryMap {
-			if strings.HasPrefix(k, "p_") {
-				c = metainfo.WithPersistentValue(c, k, v)
-			} else {
-				c = metainfo.WithValue(c, k, v)
-			}
-		}
+const (
+	SwaggerUI = swaggerruntime.SwaggerUI
+	Redoc     = swaggerruntime.Redoc
+	RapiDoc   = swaggerruntime.RapiDoc
+	Scalar    = swaggerruntime.Scalar
+)
 
-		c = metainfo.WithBackwardValues(c)
+// Document is an OpenAPI document served by the swagger endpoints besides the generated ones, e.g. the document
+// of another package, listed by its name in the document selector of the UI.
+type Document = swaggerruntime.Document
 
-		jReq := string(bodyBytes)
+// SwaggerOptions are the options of the swagger endpoints.
+type SwaggerOptions = swaggerruntime.SwaggerOptions
 
-		jRsp, err := cli.GenericCall(c, serviceMethod, jReq)
-		if err != nil {
-			hlog.Errorf("GenericCall error: %v", err)
-			ctx.JSON(500, map[string]interface{}{
-				"error": err.Error(),
-			})
-			return
-		}
+// Swagger are the options of the swagger endpoints, from the SWAGGER_PREFIX, SWAGGER_UI, SWAGGER_REWRITE_SERVERS,
+// SWAGGER_BASE_PATH, SWAGGER_TRUSTED_PROXIES and SWAGGER_CDN environment variables, the lists separated by commas.
+// They are changed before the endpoints are bound.
+var Swagger = swaggerruntime.LoadSwaggerOptions()
+`
 
-		result := make(map[string]interface{})
-		if err := json.Unmarshal([]byte(jRsp.(string)), &result); err != nil {
-			hlog.Errorf("Failed to unmarshal response body: %v", err)
-			ctx.JSON(500, map[string]interface{}{
-				"error": "Failed to unmarshal response body",
-			})
-			return
-		}
+// runtimeOptionFuncs declares the options of BindSwagger in the HTTP swagger server.
+const runtimeOptionFuncs = `
+// SwaggerOption changes the SwaggerOptions of BindSwagger.
+type SwaggerOption = swaggerruntime.SwaggerOption
 
-		m := metainfo.RecvAllBackwardValues(c)
+// WithPrefix sets the prefix of the routes of the swagger endpoints.
+func WithPrefix(prefix string) SwaggerOption {
+	return swaggerruntime.WithPrefix(prefix)
+}
 
-		for key, value := range m {
-			result[key] = value
-		}
+// WithUI sets the UI of the documents.
+func WithUI(ui UI) SwaggerOption {
+	return swaggerruntime.WithUI(ui)
+}
 
-		respBody, err := json.Marshal(result)
-		if err != nil {
-			hlog.Errorf("Failed to marshal response body: %v", err)
-			ctx.JSON(500, map[string]interface{}{
-				"error": "Failed to marshal response body",
-			})
-			return
-		}
+// WithDocument serves a document besides the generated one, listed by its name in the document selector.
+func WithDocument(name string, document []byte) SwaggerOption {
+	return swaggerruntime.WithDocument(name, document)
+}
 
-		ctx.Data(http.StatusOK, "application/json", respBody)
+// WithServerRewrite rewrites the servers of the documents by the URL of their requests, followed by a base path.
+func WithServerRewrite(basePath string) SwaggerOption {
+	return swaggerruntime.WithServerRewrite(basePath)
+}
 
-	})
+// WithTrustedProxies trusts the X-Forwarded-* headers rewriting the servers only from the proxies of these IPs and CIDRs.
+func WithTrustedProxies(proxies ...string) SwaggerOption {
+	return swaggerruntime.WithTrustedProxies(proxies...)
 }
 
-func formatQueryParams(ctx *app.RequestContext) map[string]string {
-	var QueryParams = make(map[string]string)
-	ctx.Request.URI().QueryArgs().VisitAll(func(key, value []byte) {
-		QueryParams[string(key)] = string(value)
-	})
-	return QueryParams
+// WithAssets sets the assets the script of the UI is served from.
+func WithAssets(assets fs.FS) SwaggerOption {
+	return swaggerruntime.WithAssets(assets)
 }
 
-func handleError(ctx *app.RequestContext, errMsg string, statusCode int) {
-	hlog.Errorf("Error: %s", errMsg)
-	ctx.JSON(statusCode, map[string]interface{}{
-		"error": errMsg,
-	})
+// WithCDN loads the script of the UI from its CDN when it is missing from the assets.
+func WithCDN() SwaggerOption {
+	return swaggerruntime.WithCDN()
 }
 `
 
-const ServerTemplateRpcPb = `package swagger
+// proxyOptions declares the options of the thrift proxy in the no-op build of the swagger server, which does not
+// import swagger-runtime.
+const proxyOptions = `// Options are the options of the proxy and of its generic clients.
+type Options struct {
+	// Transport is the transport protocol of the calls: ttheader, ttheader_framed, framed or buffered.
+	Transport string
+	// RPCTimeout and ConnectTimeout are the timeouts of the calls and of the connections, those of Kitex if zero.
+	RPCTimeout     time.Duration
+	ConnectTimeout time.Duration
+	// MaxRetries is the maximum number of retries of the failed calls, without retry if zero.
+	MaxRetries int
+	// Codec is the payload codec of the calls: json calls the methods with the JSON bodies of the requests,
+	// binary forwards the bodies as binary thrift messages.
+	Codec string
+	// ClientOptions are the options of the application, appended to the options of the generic clients.
+	ClientOptions []client.Option
+	// MaxBodySize is the maximum size in bytes of the bodies of the requests, larger bodies are rejected with 413.
+	MaxBodySize int
+	// ReadOnly rejects with 403 the calls of the methods whose operations are not documented as safe by x-safe.
+	ReadOnly bool
+	// Addr is the address the swagger server listens on, e.g. :8889, instead of serving the HTTP requests
+	// on the port of the Kitex server.
+	Addr string
+	// Detector detects the connections of the Kitex server carrying HTTP requests, DetectHTTP1 by default.
+	Detector ProtocolDetector
+}
 
-import (
-	"context"
-	_ "embed"
-	"encoding/json"
-	"errors"
-	"fmt"
-	"net"
-	"net/http"
-	"os"
-	"path/filepath"
-	"regexp"
-	"strings"
+// ProtocolDetector reports whether a connection of the Kitex server carries HTTP requests served by the swagger
+// server, peeking at its first bytes without reading them.
+type ProtocolDetector func(conn network.Conn) bool
+`
 
-	"github.com/bytedance/gopkg/cloud/metainfo"
-	"github.com/cloudwego/dynamicgo/proto"
-	"github.com/cloudwego/hertz/pkg/app"
-	"github.com/cloudwego/hertz/pkg/app/server"
-	"github.com/cloudwego/hertz/pkg/common/hlog"
-	"github.com/cloudwego/hertz/pkg/network"
-	"github.com/cloudwego/hertz/pkg/route"
-	"github.com/cloudwego/kitex/client"
-	"github.com/cloudwego/kitex/client/genericclient"
-	"github.com/cloudwego/kitex/pkg/endpoint"
-	"github.com/cloudwego/kitex/pkg/generic"
-	"github.com/cloudwego/kitex/pkg/klog"
-	"github.com/cloudwego/kitex/pkg/remote"
-	"github.com/cloudwego/kitex/pkg/remote/trans/detection"
-	"github.com/cloudwego/kitex/pkg/remote/trans/netpoll"
-	"github.com/cloudwego/kitex/pkg/remote/trans/nphttp2"
-	"github.com/cloudwego/kitex/pkg/transmeta"
-	"github.com/cloudwego/kitex/transport"
-	"github.com/hertz-contrib/cors"
-	"github.com/hertz-contrib/swagger"
-	swaggerFiles "github.com/swaggo/files"
-)
+// proxyOptionsPb declares the options of the proto proxy in the no-op build of the swagger server.
+const proxyOptionsPb = `// Options are the options of the proxy and of its generic clients.
+type Options struct {
+	// Transport is the transport protocol of the calls: ttheader, ttheader_framed, framed or grpc.
+	Transport string
+	// RPCTimeout and ConnectTimeout are the timeouts of the calls and of the connections, those of Kitex if zero.
+	RPCTimeout     time.Duration
+	ConnectTimeout time.Duration
+	// MaxRetries is the maximum number of retries of the failed calls, without retry if zero.
+	MaxRetries int
+	// ClientOptions are the options of the application, appended to the options of the generic clients.
+	ClientOptions []client.Option
+	// MaxBodySize is the maximum size in bytes of the bodies of the requests, larger bodies are rejected with 413.
+	MaxBodySize int
+	// ReadOnly rejects with 403 the calls of the methods whose operations are not documented as safe by x-safe.
+	ReadOnly bool
+	// Addr is the address the swagger server listens on, e.g. :8889, instead of serving the HTTP requests
+	// on the port of the Kitex server.
+	Addr string
+	// Detector detects the connections of the Kitex server carrying HTTP requests, DetectHTTP1 by default.
+	Detector ProtocolDetector
+}
 
-var (
-	//go:embed openapi.yaml
-	openapiYAML []byte
-	hertzEngine *route.Engine
-	httpReg     = regexp.MustCompile("^(?:GET |POST|PUT|DELE|HEAD|OPTI|CONN|TRAC|PATC)$")
-)
+// ProtocolDetector reports whether a connection of the Kitex server carries HTTP requests served by the swagger
+// server, peeking at its first bytes without reading them.
+type ProtocolDetector func(conn network.Conn) bool
+`
+
+// accessOptions declares the options controlling the access to the swagger endpoints in the no-op builds.
+const accessOptions = `
+// AccessOptions control the access to the swagger endpoints, and to the proxy of the RPC servers.
+type AccessOptions struct {
+	// Enabled serves the endpoints, true by default.
+	Enabled bool
+	// Username and Password require the basic authentication of the requests when Username is set.
+	Username string
+	Password string
+	// Token requires the requests to carry it as a bearer token when it is set. Either credential is accepted
+	// when both are required.
+	Token string
+	// AllowedIPs are the IPs and the CIDRs of the clients allowed, all of them if empty. The address of
+	// the connection is checked, not the forwarding headers.
+	AllowedIPs []string
+	// CORS is the CORS configuration of the endpoints, allowing all the origins by default.
+	CORS cors.Config
+}
+`
+
+// swaggerOptions declares the options of the swagger endpoints in the no-op builds.
+const swaggerOptions = `
+// UI is a UI of the documents served by the swagger endpoints.
+type UI string
 
 const (
-	kitexAddr = "{{.KitexAddr}}"
-	idlFile   = "{{.IdlPath}}"
+	SwaggerUI UI = "swagger-ui"
+	Redoc     UI = "redoc"
+	RapiDoc   UI = "rapidoc"
+	Scalar    UI = "scalar"
 )
 
-type MixTransHandlerFactory struct {
-	OriginFactory remote.ServerTransHandlerFactory
+// Document is an OpenAPI document served by the swagger endpoints besides the generated ones, e.g. the document
+// of another package, listed by its name in the document selector of the UI.
+type Document struct {
+	Name string
+	// YAML is the content of the document.
+	YAML []byte
 }
 
-type transHandler struct {
-	remote.ServerTransHandler
-}
-
-func (t *transHandler) SetInvokeHandleFunc(inkHdlFunc endpoint.Endpoint) {
-	t.ServerTransHandler.(remote.InvokeHandleFuncSetter).SetInvokeHandleFunc(inkHdlFunc)
+// SwaggerOptions are the options of the swagger endpoints.
+type SwaggerOptions struct {
+	// Prefix is the prefix of the routes of the endpoints, e.g. /docs for /docs/swagger/index.html
+	// and /docs/openapi.yaml.
+	Prefix string
+	// UI is the UI served at {Prefix}/swagger/index.html, Swagger UI by default.
+	UI UI
+	// Documents are the documents served besides the generated ones, at {Prefix}/{Name}/openapi.yaml.
+	Documents []Document
+	// RewriteServers replaces the servers of the documents by the URL their requests are sent to, from their
+	// Host, X-Forwarded-Proto, X-Forwarded-Host and X-Forwarded-Prefix headers, followed by BasePath.
+	RewriteServers bool
+	// BasePath is the path of the routes of the API in the rewritten servers, e.g. /api.
+	BasePath string
+	// TrustedProxies are the IPs and CIDRs of the proxies whose X-Forwarded-* headers rewrite the servers,
+	// checked against the connection. The headers of every client are trusted when it is empty.
+	TrustedProxies []string
+	// Assets are the assets the script of Redoc, RapiDoc or Scalar is served from, redoc.standalone.js,
+	// rapidoc-min.js or scalar.standalone.js, the scripts embedded in github.com/hertz-contrib/swagger-generate/ui-assets
+	// by default. Swagger UI is served from the assets of github.com/swaggo/files.
+	Assets fs.FS
+	// CDN loads the script of the UI from its CDN when it is missing from Assets, instead of failing to bind.
+	CDN bool
 }
+`
 
-func (m MixTransHandlerFactory) NewTransHandler(opt *remote.ServerOption) (remote.ServerTransHandler, error) {
+// swaggerOptionFuncs declares the options of BindSwagger in the no-op build of the HTTP swagger server.
+const swaggerOptionFuncs = `
+// SwaggerOption changes the SwaggerOptions of BindSwagger.
+type SwaggerOption func(o *SwaggerOptions)
 
-	if hertzEngine == nil {
-		StartServer()
+// WithPrefix sets the prefix of the routes of the swagger endpoints.
+func WithPrefix(prefix string) SwaggerOption {
+	return func(o *SwaggerOptions) {
+		o.Prefix = prefix
 	}
+}
 
-	var kitexOrigin remote.ServerTransHandler
-	var err error
-
-	if m.OriginFactory != nil {
-		kitexOrigin, err = m.OriginFactory.NewTransHandler(opt)
-	} else {
-		kitexOrigin, err = detection.NewSvrTransHandlerFactory(netpoll.NewSvrTransHandlerFactory(), nphttp2.NewSvrTransHandlerFactory()).NewTransHandler(opt)
+// WithUI sets the UI of the documents.
+func WithUI(ui UI) SwaggerOption {
+	return func(o *SwaggerOptions) {
+		o.UI = ui
 	}
-	if err != nil {
-		return nil, err
-	}
-	return &transHandler{ServerTransHandler: kitexOrigin}, nil
-}
-
-func (t *transHandler) OnRead(ctx context.Context, conn net.Conn) error {
-	c, ok := conn.(network.Conn)
-	if ok {
-		pre, _ := c.Peek(4)
-		if httpReg.Match(pre) {
-			klog.Info("using Hertz to process request")
-			err := hertzEngine.Serve(ctx, c)
-			if err != nil {
-				err = errors.New(fmt.Sprintf("HERTZ: %s", err.Error()))
-			}
-			return err
-		}
-	}
-
-	return t.ServerTransHandler.OnRead(ctx, conn)
 }
 
-func StartServer() {
-	h := server.Default()
-	h.Use(cors.Default())
+// WithDocument serves a document besides the generated one, listed by its name in the document selector.
+func WithDocument(name string, document []byte) SwaggerOption {
+	return func(o *SwaggerOptions) {
+		o.Documents = append(append([]Document{}, o.Documents...), Document{Name: name, YAML: document})
+	}
+}
 
-	cli := initializeGenericClient()
-	setupSwaggerRoutes(h)
-	setupProxyRoutes(h, cli)
+// WithServerRewrite rewrites the servers of the documents by the URL of their requests, followed by a base path.
+func WithServerRewrite(basePath string) SwaggerOption {
+	return func(o *SwaggerOptions) {
+		o.RewriteServers, o.BasePath = true, basePath
+	}
+}
 
-	hlog.Info("Swagger UI is available at: http://" + kitexAddr + "/swagger/index.html")
-	err := h.Engine.Init()
-	if err != nil {
-		panic(err)
+// WithTrustedProxies trusts the X-Forwarded-* headers rewriting the servers only from the proxies of these IPs and CIDRs.
+func WithTrustedProxies(proxies ...string) SwaggerOption {
+	return func(o *SwaggerOptions) {
+		o.TrustedProxies = proxies
 	}
+}
 
-	hertzEngine = h.Engine
+// WithAssets sets the assets the script of the UI is served from.
+func WithAssets(assets fs.FS) SwaggerOption {
+	return func(o *SwaggerOptions) {
+		o.Assets = assets
+	}
 }
 
-func findPbFile(fileName string) (string, error) {
-	workingDir, err := os.Getwd()
-	if err != nil {
-		return "", err
+// WithCDN loads the script of the UI from its CDN when it is missing from the assets.
+func WithCDN() SwaggerOption {
+	return func(o *SwaggerOptions) {
+		o.CDN = true
 	}
+}
+`
 
-	foundPath := ""
-	relativePath := fileName
+// httpDetection detects the HTTP requests on the connections of the Kitex servers in the no-op builds.
+const httpDetection = `
+// httpMethods are the methods of the HTTP/1.x requests detected by DetectHTTP1.
+var httpMethods = []string{"GET", "HEAD", "POST", "PUT", "DELETE", "CONNECT", "OPTIONS", "TRACE", "PATCH"}
 
-	err = filepath.Walk(workingDir, func(path string, info os.FileInfo, err error) error {
+// DetectHTTP1 detects the HTTP/1.x requests by their method followed by a space, peeking at one more byte
+// while they may still match. The other protocols, like the HTTP/2 connection preface or TLS, are served by Kitex.
+func DetectHTTP1(conn network.Conn) bool {
+	for n := 1; ; n++ {
+		b, err := conn.Peek(n)
 		if err != nil {
-			return err
+			return false
 		}
-
-		if !info.IsDir() {
-			relative, err := filepath.Rel(workingDir, path)
-			if err != nil {
-				return err
-			}
-
-			if relative == relativePath {
-				foundPath = path
-				return filepath.SkipDir
+		prefix := false
+		for _, method := range httpMethods {
+			if request := method + " "; strings.HasPrefix(request, string(b)) {
+				if len(b) == len(request) {
+					return true
+				}
+				prefix = true
 			}
 		}
-		return nil
-	})
-
-	if err == nil && foundPath != "" {
-		return foundPath, nil
-	}
-
-	parentDir := filepath.Dir(workingDir)
-	for parentDir != "/" && parentDir != "." && parentDir != workingDir {
-		filePath := filepath.Join(parentDir, fileName)
-		if _, err := os.Stat(filePath); err == nil {
-			return filePath, nil
+		if !prefix {
+			return false
 		}
-		workingDir = parentDir
-		parentDir = filepath.Dir(parentDir)
 	}
-
-	return "", errors.New("proto file not found: " + fileName)
 }
+`
 
-func initializeGenericClient() genericclient.Client {
-	pbFile, err := findPbFile(idlFile)
-	if err != nil {
-		hlog.Fatal("Failed to locate Proto file:", err)
-	}
+// NoopTemplateHttp is the swagger file compiled instead of the HTTP swagger server with the noswagger build tag,
+// which binds no endpoint and embeds no document.
+const NoopTemplateHttp = `package swagger
 
-	dOpts := proto.Options{}
-	p, err := generic.NewPbFileProviderWithDynamicGo(pbFile, context.Background(), dOpts)
-	if err != nil {
-		hlog.Fatal("Failed to create PbFileProvider:", err)
-	}
+import (
+	"io/fs"
 
-	g, err := generic.JSONPbGeneric(p)
-	if err != nil {
-		hlog.Fatal("Failed to create JsonPbGeneric:", err)
-	}
-	var opts []client.Option
-	opts = append(opts, client.WithTransportProtocol(transport.TTHeader))
-	opts = append(opts, client.WithMetaHandler(transmeta.ClientTTHeaderHandler))
-	opts = append(opts, client.WithHostPorts(kitexAddr))
-	cli, err := genericclient.NewClient("swagger", g, opts...)
-	if err != nil {
-		hlog.Fatal("Failed to create generic client:", err)
-	}
+	"github.com/cloudwego/hertz/pkg/app/server"
+	"github.com/hertz-contrib/cors"
+)
+` + accessOptions + swaggerOptions + swaggerOptionFuncs + `
+// Access and Swagger are the options of the swagger endpoints, which are not served in the noswagger builds.
+var (
+	Access  AccessOptions
+	Swagger SwaggerOptions
+)
 
-	return cli
-}
+// BindSwagger binds no endpoint in the noswagger builds.
+func BindSwagger(h *server.Hertz, opts ...SwaggerOption) {}
+`
 
-func setupSwaggerRoutes(h *server.Hertz) {
-	h.GET("swagger/*any", swagger.WrapHandler(swaggerFiles.Handler, swagger.URL("/openapi.yaml")))
+// NoopTemplateRpc is the swagger file compiled instead of the RPC swagger server with the noswagger build tag,
+// which serves the Kitex server alone, without the swagger endpoints and the proxy.
+const NoopTemplateRpc = `package swagger
 
-	h.GET("/openapi.yaml", func(c context.Context, ctx *app.RequestContext) {
-		ctx.Header("Content-Type", "application/x-yaml")
-		ctx.Write(openapiYAML)
-	})
-}
+import (
+	"context"
+	"io/fs"
+	"strings"
+	"time"
 
-func setupProxyRoutes(h *server.Hertz, cli genericclient.Client) {
-	h.Any("/*ServiceMethod", func(c context.Context, ctx *app.RequestContext) {
-		serviceMethod := ctx.Param("ServiceMethod")
-		if serviceMethod == "" {
-			handleError(ctx, "ServiceMethod not provided", http.StatusBadRequest)
-			return
-		}
+	"github.com/cloudwego/hertz/pkg/network"
+	"github.com/cloudwego/kitex/client"
+	"github.com/cloudwego/kitex/pkg/remote"
+	"github.com/cloudwego/kitex/pkg/remote/trans/detection"
+	"github.com/cloudwego/kitex/pkg/remote/trans/netpoll"
+	"github.com/cloudwego/kitex/pkg/remote/trans/nphttp2"
+	"github.com/hertz-contrib/cors"
+)
 
-		bodyBytes := ctx.Request.Body()
+` + proxyOptions + noopRpc
 
-		queryMap := formatQueryParams(ctx)
+// NoopTemplateRpcPb is the NoopTemplateRpc of the proto RPC swagger server.
+const NoopTemplateRpcPb = `package swagger
 
-		for k, v := range queryMap {
-			if strings.HasPrefix(k, "p_") {
-				c = metainfo.WithPersistentValue(c, k, v)
-			} else {
-				c = metainfo.WithValue(c, k, v)
-			}
-		}
+import (
+	"context"
+	"io/fs"
+	"strings"
+	"time"
 
-		c = metainfo.WithBackwardValues(c)
+	"github.com/cloudwego/hertz/pkg/network"
+	"github.com/cloudwego/kitex/client"
+	"github.com/cloudwego/kitex/pkg/remote"
+	"github.com/cloudwego/kitex/pkg/remote/trans/detection"
+	"github.com/cloudwego/kitex/pkg/remote/trans/netpoll"
+	"github.com/cloudwego/kitex/pkg/remote/trans/nphttp2"
+	"github.com/hertz-contrib/cors"
+)
 
-		jReq := string(bodyBytes)
+` + proxyOptionsPb + noopRpc
 
-		jRsp, err := cli.GenericCall(c, serviceMethod, jReq)
-		if err != nil {
-			hlog.Errorf("GenericCall error: %v", err)
-			ctx.JSON(500, map[string]interface{}{
-				"error": err.Error(),
-			})
-			return
-		}
+const noopRpc = accessOptions + swaggerOptions + `
+// ProxyOptions, Access, Swagger and IdlDir are not used in the noswagger builds.
+var (
+	ProxyOptions Options
+	Access       AccessOptions
+	Swagger      SwaggerOptions
+	IdlDir       string
+)
 
-		result := make(map[string]interface{})
-		if err := json.Unmarshal([]byte(jRsp.(string)), &result); err != nil {
-			hlog.Errorf("Failed to unmarshal response body: %v", err)
-			ctx.JSON(500, map[string]interface{}{
-				"error": "Failed to unmarshal response body",
-			})
-			return
-		}
+// MixTransHandlerFactory creates the transport handlers of the Kitex server alone in the noswagger builds.
+type MixTransHandlerFactory struct {
+	OriginFactory remote.ServerTransHandlerFactory
+}
 
-		m := metainfo.RecvAllBackwardValues(c)
+func (m MixTransHandlerFactory) NewTransHandler(opt *remote.ServerOption) (remote.ServerTransHandler, error) {
+	if m.OriginFactory != nil {
+		return m.OriginFactory.NewTransHandler(opt)
+	}
+	return detection.NewSvrTransHandlerFactory(netpoll.NewSvrTransHandlerFactory(), nphttp2.NewSvrTransHandlerFactory()).NewTransHandler(opt)
+}
 
-		for key, value := range m {
-			result[key] = value
-		}
+// StartServer starts no server in the noswagger builds.
+func StartServer() {}
 
-		respBody, err := json.Marshal(result)
-		if err != nil {
-			hlog.Errorf("Failed to marshal response body: %v", err)
-			ctx.JSON(500, map[string]interface{}{
-				"error": "Failed to marshal response body",
-			})
-			return
-		}
+// StopServer stops no server in the noswagger builds.
+func StopServer(ctx context.Context) error {
+	return nil
+}
+` + httpDetection
 
-		ctx.Data(http.StatusOK, "application/json", respBody)
+const IdlTemplate = `package swagger
 
-	})
-}
+import (
+	_ "embed"
 
-func formatQueryParams(ctx *app.RequestContext) map[string]string {
-	var QueryParams = make(map[string]string)
-	ctx.Request.URI().QueryArgs().VisitAll(func(key, value []byte) {
-		QueryParams[string(key)] = string(value)
-	})
-	return QueryParams
-}
+	"github.com/hertz-contrib/swagger-generate/swagger-runtime/kitexproxy"
+)
 
-func handleError(ctx *app.RequestContext, errMsg string, statusCode int) {
-	hlog.Errorf("Error: %s", errMsg)
-	ctx.JSON(statusCode, map[string]interface{}{
-		"error": errMsg,
+//go:embed {{.DocumentFile}}
+var {{.DocumentVar}} []byte
+
+func init() {
+	proxy.Register(&kitexproxy.IDL{
+		Name:          {{printf "%q" .Name}},
+		Document:      {{.DocumentVar}},
+		ServicePrefix: {{.ServicePrefix}},
+		Services: []*kitexproxy.Service{
+{{- range .Services}}
+			{
+				Name:    {{printf "%q" .Name}},
+{{- if .Package}}
+				Package: {{printf "%q" .Package}},
+{{- end}}
+				File:    {{printf "%q" .File}},
+				Methods: []*kitexproxy.Method{
+{{- range .Methods}}
+					{Name: {{printf "%q" .Name}}{{if .Safe}}, Safe: true{{end}}{{if .Schema}}, Schema: {{printf "%q" .Schema}}{{end}}{{if .BaseField}}, BaseField: {{printf "%q" .BaseField}}, BaseKeys: {{printf "%#v" .BaseKeys}}{{end}}},
+{{- end}}
+				},
+			},
+{{- end}}
+		},
+		// Files are the contents of the IDL and of its includes, from which the generic clients are built.
+		Files: map[string]string{
+{{- range $path, $content := .Files}}
+			{{printf "%q" $path}}: {{quoteLines $content}},
+{{- end}}
+		},
+{{- if .Schemas}}
+		// Schemas are the schemas of the components referenced by the request schemas of the methods.
+		Schemas: {{quoteLines .Schemas}},
+{{- end}}
 	})
 }
 `
@@ -0,0 +1,79 @@
+/*
+ * Copyright 2024 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tpl
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+)
+
+// fragmentsSource is the package of the fragments tested by testdata/fragments, which only need the standard
+// library and the Conn of Hertz.
+const fragmentsSource = `package swagger
+
+import (
+	"strings"
+
+	"github.com/cloudwego/hertz/pkg/network"
+)
+` + httpDetection
+
+// hertzStub replaces Hertz with the part of network.Conn used by the fragments, so that they build offline.
+var hertzStub = map[string]string{
+	"go.mod":                 "module github.com/cloudwego/hertz\n\ngo 1.18\n",
+	"pkg/network/network.go": "package network\n\ntype Conn interface {\n\tPeek(n int) ([]byte, error)\n}\n",
+}
+
+// TestFragments runs the tests of testdata/fragments against the code that the templates generate.
+func TestFragments(t *testing.T) {
+	goTool, err := exec.LookPath("go")
+	if err != nil {
+		t.Skip("go is not installed")
+	}
+	test, err := os.ReadFile(filepath.Join("testdata", "fragments", "fragments_test.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dir := t.TempDir()
+	files := map[string]string{
+		"go.mod":            "module fragments\n\ngo 1.18\n\nrequire github.com/cloudwego/hertz v0.0.0\n\nreplace github.com/cloudwego/hertz => ./hertz\n",
+		"fragments.go":      fragmentsSource,
+		"fragments_test.go": string(test),
+	}
+	for name, content := range hertzStub {
+		files[filepath.Join("hertz", name)] = content
+	}
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err = os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err = os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	cmd := exec.Command(goTool, "test", ".")
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), "GOWORK=off", "GOFLAGS=-mod=mod", "GOPROXY=off")
+	if output, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("the tests of the fragments failed: %s\n%s", err, output)
+	}
+}
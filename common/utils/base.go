@@ -0,0 +1,131 @@
+/*
+ * Copyright 2024 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package utils
+
+import (
+	"fmt"
+
+	"github.com/cloudwego/thriftgo/thrift_reflection"
+	"github.com/hertz-contrib/swagger-generate/common/consts"
+)
+
+// BaseConventions describes the Kitex conventions of the `base.Base` field of the requests
+// and the `base.BaseResp` field of the responses, recognized by their struct and field ID.
+type BaseConventions struct {
+	descs          *Descriptors
+	baseStruct     string
+	baseRespStruct string
+	fieldID        int32
+	baseMode       string
+	baseRespMode   string
+}
+
+// NewBaseConventions returns the conventions of the Base and BaseResp structs, of their field ID
+// and of the modes documenting them, with the defaults of Kitex, for the fields of the descriptors.
+func NewBaseConventions(descs *Descriptors, baseStruct, baseRespStruct string, fieldID int, baseMode, baseRespMode string) (*BaseConventions, error) {
+	c := &BaseConventions{
+		descs:          descs,
+		baseStruct:     baseStruct,
+		baseRespStruct: baseRespStruct,
+		fieldID:        int32(fieldID),
+		baseMode:       baseMode,
+		baseRespMode:   baseRespMode,
+	}
+	if c.baseStruct == "" {
+		c.baseStruct = consts.DefaultBaseStruct
+	}
+	if c.baseRespStruct == "" {
+		c.baseRespStruct = consts.DefaultBaseRespStruct
+	}
+	if c.fieldID == 0 {
+		c.fieldID = consts.DefaultBaseFieldID
+	}
+	switch c.baseMode {
+	case "":
+		c.baseMode = consts.BaseModeKeep
+	case consts.BaseModeKeep, consts.BaseModeHide, consts.BaseModeHeader:
+	default:
+		return nil, fmt.Errorf("unknown BaseMode %s, expected %s, %s or %s", c.baseMode, consts.BaseModeKeep, consts.BaseModeHide, consts.BaseModeHeader)
+	}
+	switch c.baseRespMode {
+	case "":
+		c.baseRespMode = consts.BaseModeKeep
+	case consts.BaseModeKeep, consts.BaseModeHide, consts.BaseRespModeEnvelope:
+	default:
+		return nil, fmt.Errorf("unknown BaseRespMode %s, expected %s, %s or %s", c.baseRespMode, consts.BaseModeKeep, consts.BaseModeHide, consts.BaseRespModeEnvelope)
+	}
+	return c, nil
+}
+
+// BaseMode returns the mode documenting the Base fields.
+func (c *BaseConventions) BaseMode() string {
+	return c.baseMode
+}
+
+// BaseRespMode returns the mode documenting the BaseResp fields.
+func (c *BaseConventions) BaseRespMode() string {
+	return c.baseRespMode
+}
+
+// BaseRespStruct returns the name of the struct of the BaseResp fields.
+func (c *BaseConventions) BaseRespStruct() string {
+	return c.baseRespStruct
+}
+
+// isField reports whether a field has the ID of the conventions and a struct type of the given name.
+func (c *BaseConventions) isField(field *thrift_reflection.FieldDescriptor, structName string) bool {
+	if field.ID != c.fieldID {
+		return false
+	}
+	desc := c.descs.Struct(field.GetType())
+	return desc != nil && desc.GetName() == structName
+}
+
+// IsBase reports whether a field is the Base of a request.
+func (c *BaseConventions) IsBase(field *thrift_reflection.FieldDescriptor) bool {
+	return c.isField(field, c.baseStruct)
+}
+
+// IsBaseResp reports whether a field is the BaseResp of a response.
+func (c *BaseConventions) IsBaseResp(field *thrift_reflection.FieldDescriptor) bool {
+	return c.isField(field, c.baseRespStruct)
+}
+
+// Skip reports whether a field is left out of the schemas, as a Base or BaseResp field that is not kept.
+func (c *BaseConventions) Skip(field *thrift_reflection.FieldDescriptor) bool {
+	return c.baseMode != consts.BaseModeKeep && c.IsBase(field) ||
+		c.baseRespMode != consts.BaseModeKeep && c.IsBaseResp(field)
+}
+
+// BaseFields returns the Base field of a struct and the scalar fields of the Base, except the binary ones,
+// or nil if it has none.
+func (c *BaseConventions) BaseFields(desc *thrift_reflection.StructDescriptor) (*thrift_reflection.FieldDescriptor, []*thrift_reflection.FieldDescriptor) {
+	for _, field := range desc.GetFields() {
+		if !c.IsBase(field) {
+			continue
+		}
+		baseDesc := c.descs.Struct(field.GetType())
+		var scalars []*thrift_reflection.FieldDescriptor
+		for _, f := range baseDesc.GetFields() {
+			if f.GetType() != nil && f.GetType().IsBasic() && f.GetType().GetName() != "binary" {
+				scalars = append(scalars, f)
+			}
+		}
+		return field, scalars
+	}
+	return nil, nil
+}
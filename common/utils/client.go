@@ -0,0 +1,106 @@
+/*
+ * Copyright 2024 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package utils
+
+import (
+	"fmt"
+	"go/format"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hertz-contrib/swagger-generate/common/consts"
+)
+
+// ClientOptions are the options of the generic clients of an RPC swagger server, generated as the defaults
+// of its options.
+type ClientOptions struct {
+	Transport      string // Transport is the transport protocol of the calls, ttheader by default.
+	RPCTimeout     string // RPCTimeout is the timeout of the calls, as a duration like 3s.
+	ConnectTimeout string // ConnectTimeout is the timeout of the connections, as a duration like 500ms.
+	MaxRetries     int    // MaxRetries is the maximum number of retries of the failed calls.
+	Codec          string // Codec is the payload codec of the calls, json by default.
+}
+
+// Check sets the defaults of the options left empty, and returns an error if they are not valid
+// or not among the transports and codecs supported by the IDL.
+func (o *ClientOptions) Check(transports, codecs []string) error {
+	if o.Transport == "" {
+		o.Transport = consts.TransportTTHeader
+	}
+	if !Contains(transports, o.Transport) {
+		return fmt.Errorf("unknown transport %s, expected one of %s", o.Transport, strings.Join(transports, ", "))
+	}
+	if o.Codec == "" {
+		o.Codec = consts.CodecJSON
+	}
+	if !Contains(codecs, o.Codec) {
+		return fmt.Errorf("unknown codec %s, expected one of %s", o.Codec, strings.Join(codecs, ", "))
+	}
+	for _, timeout := range []string{o.RPCTimeout, o.ConnectTimeout} {
+		if timeout == "" {
+			continue
+		}
+		if d, err := time.ParseDuration(timeout); err != nil || d < 0 {
+			return fmt.Errorf("invalid timeout %s, expected a duration like 3s", timeout)
+		}
+	}
+	if o.MaxRetries < 0 {
+		return fmt.Errorf("invalid max retries %d", o.MaxRetries)
+	}
+	return nil
+}
+
+// Consts returns the constants of the generated server holding the options, with the defaults they had
+// in the servers generated before the options were introduced.
+func (o *ClientOptions) Consts() []GeneratedConst {
+	return []GeneratedConst{
+		{Name: "defaultTransport", Value: strconv.Quote(o.Transport), Default: strconv.Quote(consts.TransportTTHeader)},
+		{Name: "defaultRPCTimeout", Value: strconv.Quote(o.RPCTimeout), Default: `""`},
+		{Name: "defaultConnectTimeout", Value: strconv.Quote(o.ConnectTimeout), Default: `""`},
+		{Name: "defaultMaxRetries", Value: strconv.Itoa(o.MaxRetries), Default: "0"},
+		{Name: "defaultCodec", Value: strconv.Quote(o.Codec), Default: strconv.Quote(consts.CodecJSON)},
+	}
+}
+
+// GeneratedConst is a constant of a generated file, whose value is updated when the file is regenerated.
+type GeneratedConst struct {
+	Name    string
+	Value   string // Value is the Go literal of the value.
+	Default string // Default is the literal of the value the files missing the constant behave as.
+}
+
+// UpdateConsts replaces the values of constants in the content of a generated file. A file generated before
+// a constant was introduced behaves as with its default value, it is regenerated to use another value.
+func UpdateConsts(filePath, content string, generated []GeneratedConst) (string, error) {
+	for _, c := range generated {
+		pattern := regexp.MustCompile(`\b` + c.Name + `\s*=\s*("(?:[^"\\]|\\.)*"|[\w.]+)`)
+		if !pattern.MatchString(content) {
+			if c.Value != c.Default {
+				return "", fmt.Errorf("%s does not support the option of %s, remove it to regenerate", filePath, c.Name)
+			}
+			continue
+		}
+		content = pattern.ReplaceAllLiteralString(content, c.Name+" = "+c.Value)
+	}
+	// The values are aligned again, like in the generated files
+	if formatted, err := format.Source([]byte(content)); err == nil {
+		content = string(formatted)
+	}
+	return content, nil
+}
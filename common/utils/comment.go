@@ -0,0 +1,215 @@
+/*
+ * Copyright 2024 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package utils
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/hertz-contrib/swagger-generate/common/consts"
+)
+
+// Comment is the documentation of an IDL element, parsed from its comments.
+type Comment struct {
+	// Text is the whole comment without doc tags, with Markdown preserved.
+	Text string
+	// Summary is the first sentence or line of Text.
+	Summary string
+	// Description is the rest of Text after Summary.
+	Description string
+	// Examples holds the values of the `@example` tags.
+	Examples []string
+	// Deprecated is set by a `@deprecated` tag or a `Deprecated:` line.
+	Deprecated       bool
+	DeprecatedReason string
+	// See holds the links of the `@see <url> [description]` tags.
+	See []*CommentLink
+	// Internal is set by an `@internal` tag, the element is left out of the document.
+	Internal bool
+}
+
+// CommentLink is a link referenced by a `@see` tag.
+type CommentLink struct {
+	URL         string
+	Description string
+}
+
+const (
+	commentTagExample    = "@example"
+	commentTagDeprecated = "@deprecated"
+	commentTagSee        = "@see"
+	commentTagInternal   = "@internal"
+
+	commentDeprecatedPrefix = "Deprecated:"
+)
+
+var (
+	commentPattern    = regexp.MustCompile(consts.CommentPatternRegexp)
+	linterRulePattern = regexp.MustCompile(consts.LinterRulePatternRegexp)
+	sentenceEnd       = regexp.MustCompile(`[.!?]\s|[。！？]`)
+)
+
+// ParseComment parses the comments of an IDL element.
+// The texts are joined as separate paragraphs, e.g. leading detached, leading and trailing comments.
+// Thrift comments are passed with their `//` and `/* */` markers, proto comments without them.
+func ParseComment(texts ...string) *Comment {
+	var paragraphs []string
+	for _, text := range texts {
+		text = linterRulePattern.ReplaceAllString(text, "")
+		if lines := trimBlankLines(dedent(commentLines(text))); len(lines) > 0 {
+			paragraphs = append(paragraphs, strings.Join(lines, "\n"))
+		}
+	}
+
+	c := &Comment{}
+	var lines []string
+	if len(paragraphs) > 0 {
+		lines = strings.Split(strings.Join(paragraphs, "\n\n"), "\n")
+	}
+	lines = c.parseTags(lines)
+	c.Text = strings.Join(trimBlankLines(lines), "\n")
+	c.Summary, c.Description = splitSummary(c.Text)
+	return c
+}
+
+// commentLines returns the lines of a comment with the comment markers removed.
+func commentLines(text string) []string {
+	trimmed := strings.TrimSpace(text)
+	if !strings.HasPrefix(trimmed, "//") && !strings.HasPrefix(trimmed, "/*") {
+		return strings.Split(text, "\n")
+	}
+
+	matches := commentPattern.FindAllStringSubmatchIndex(text, -1)
+
+	var lines []string
+	for _, match := range matches {
+		if match[2] >= 0 {
+			// One-line comments keep the indentation after the first space, e.g. for Markdown lists.
+			lines = append(lines, strings.TrimPrefix(text[match[0]+2:match[1]], " "))
+			continue
+		}
+		block := strings.Split(text[match[4]:match[5]], "\n")
+		// Remove the leading '*' of the block comment lines, and the extra ones of `/**`
+		block[0] = strings.TrimLeft(block[0], "*")
+		for i, line := range block[1:] {
+			if trimmed := strings.TrimLeft(line, " \t"); strings.HasPrefix(trimmed, "*") {
+				block[i+1] = strings.TrimPrefix(strings.TrimPrefix(trimmed, "*"), " ")
+			}
+		}
+		lines = append(lines, trimBlankLines(dedent(block))...)
+	}
+	return lines
+}
+
+// parseTags collects the doc tags of the comment and returns the remaining lines.
+// A tag value continues on the following lines until a blank line or another tag.
+func (c *Comment) parseTags(lines []string) []string {
+	var rest []string
+	for i := 0; i < len(lines); i++ {
+		trimmed := strings.TrimSpace(lines[i])
+		tag, value := trimmed, ""
+		if idx := strings.IndexAny(trimmed, " \t"); idx > 0 {
+			tag, value = trimmed[:idx], strings.TrimSpace(trimmed[idx:])
+		}
+
+		switch tag {
+		case commentTagExample, commentTagDeprecated, commentTagSee, commentTagInternal:
+		default:
+			if strings.HasPrefix(trimmed, commentDeprecatedPrefix) && !c.Deprecated {
+				c.Deprecated = true
+				c.DeprecatedReason = strings.TrimSpace(strings.TrimPrefix(trimmed, commentDeprecatedPrefix))
+			}
+			rest = append(rest, lines[i])
+			continue
+		}
+
+		values := []string{value}
+		for tag != commentTagInternal && i+1 < len(lines) {
+			next := strings.TrimSpace(lines[i+1])
+			if next == "" || strings.HasPrefix(next, "@") {
+				break
+			}
+			values = append(values, lines[i+1])
+			i++
+		}
+
+		switch tag {
+		case commentTagExample:
+			if example := strings.TrimSpace(strings.Join(dedent(values), "\n")); example != "" {
+				c.Examples = append(c.Examples, example)
+			}
+		case commentTagDeprecated:
+			c.Deprecated = true
+			c.DeprecatedReason = strings.Join(strings.Fields(strings.Join(values, " ")), " ")
+		case commentTagSee:
+			fields := strings.Fields(strings.Join(values, " "))
+			if len(fields) > 0 {
+				c.See = append(c.See, &CommentLink{URL: fields[0], Description: strings.Join(fields[1:], " ")})
+			}
+		case commentTagInternal:
+			c.Internal = true
+		}
+	}
+	return rest
+}
+
+// splitSummary splits the first sentence or line of the text from the rest.
+func splitSummary(text string) (string, string) {
+	first, rest := text, ""
+	if idx := strings.Index(text, "\n"); idx >= 0 {
+		first, rest = text[:idx], text[idx+1:]
+	}
+	// A period only ends a sentence after a word, so that list markers like `1.` stay in the summary.
+	if loc := sentenceEnd.FindStringIndex(first); loc != nil && (first[loc[0]] != '.' || strings.ContainsAny(first[:loc[0]], " \t")) {
+		first, rest = first[:loc[1]], first[loc[1]:]+"\n"+rest
+	}
+	return strings.TrimSpace(first), strings.TrimSpace(rest)
+}
+
+// dedent removes the indentation shared by all non-blank lines.
+func dedent(lines []string) []string {
+	minIndent := -1
+	for _, line := range lines {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		indent := len(line) - len(strings.TrimLeft(line, " \t"))
+		if minIndent == -1 || indent < minIndent {
+			minIndent = indent
+		}
+	}
+	out := make([]string, len(lines))
+	for i, line := range lines {
+		line = strings.TrimRight(line, " \t\r")
+		if len(line) >= minIndent && minIndent > 0 {
+			line = line[minIndent:]
+		}
+		out[i] = line
+	}
+	return out
+}
+
+// trimBlankLines removes the leading and trailing blank lines.
+func trimBlankLines(lines []string) []string {
+	for len(lines) > 0 && strings.TrimSpace(lines[0]) == "" {
+		lines = lines[1:]
+	}
+	for len(lines) > 0 && strings.TrimSpace(lines[len(lines)-1]) == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
@@ -0,0 +1,130 @@
+/*
+ * Copyright 2024 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package utils
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseComment(t *testing.T) {
+	tests := []struct {
+		name  string
+		texts []string
+		want  *Comment
+	}{
+		{
+			name:  "line comments",
+			texts: []string{"// Get a pet. Returns the pet\n// with the given id."},
+			want: &Comment{
+				Text:        "Get a pet. Returns the pet\nwith the given id.",
+				Summary:     "Get a pet.",
+				Description: "Returns the pet\nwith the given id.",
+			},
+		},
+		{
+			name:  "block comment",
+			texts: []string{"/**\n   * Get a pet.\n   *\n   * - by id\n   *   - or name\n   */"},
+			want: &Comment{
+				Text:        "Get a pet.\n\n- by id\n  - or name",
+				Summary:     "Get a pet.",
+				Description: "- by id\n  - or name",
+			},
+		},
+		{
+			name:  "proto comment",
+			texts: []string{" Get a pet\n over several lines\n"},
+			want: &Comment{
+				Text:        "Get a pet\nover several lines",
+				Summary:     "Get a pet",
+				Description: "over several lines",
+			},
+		},
+		{
+			name:  "summary not split at a list marker",
+			texts: []string{"// 1. Get a pet"},
+			want:  &Comment{Text: "1. Get a pet", Summary: "1. Get a pet"},
+		},
+		{
+			name:  "summary split at a full-width period",
+			texts: []string{"// 获取宠物。按编号"},
+			want:  &Comment{Text: "获取宠物。按编号", Summary: "获取宠物。", Description: "按编号"},
+		},
+		{
+			name:  "paragraphs joined",
+			texts: []string{"// Detached.", "", "/* Leading. */", "// Trailing."},
+			want: &Comment{
+				Text:        "Detached.\n\nLeading.\n\nTrailing.",
+				Summary:     "Detached.",
+				Description: "Leading.\n\nTrailing.",
+			},
+		},
+		{
+			name: "tags",
+			texts: []string{"// Get a pet.\n// @example {\"id\": 1,\n//   \"name\": \"cat\"}\n// @see https://example.com the pets\n" +
+				"// @deprecated use\n//   GetPet2\n// @internal\n// More."},
+			want: &Comment{
+				Text:             "Get a pet.\nMore.",
+				Summary:          "Get a pet.",
+				Description:      "More.",
+				Examples:         []string{"{\"id\": 1,\n  \"name\": \"cat\"}"},
+				Deprecated:       true,
+				DeprecatedReason: "use GetPet2",
+				See:              []*CommentLink{{URL: "https://example.com", Description: "the pets"}},
+				Internal:         true,
+			},
+		},
+		{
+			name:  "tags with no value",
+			texts: []string{"// @deprecated\n// @see\n// @example"},
+			want:  &Comment{Deprecated: true},
+		},
+		{
+			name:  "unknown tags kept",
+			texts: []string{"// Get a pet.\n// @param id the id"},
+			want:  &Comment{Text: "Get a pet.\n@param id the id", Summary: "Get a pet.", Description: "@param id the id"},
+		},
+		{
+			name:  "deprecated line",
+			texts: []string{"// Get a pet.\n// Deprecated: use GetPet2."},
+			want: &Comment{
+				Text:             "Get a pet.\nDeprecated: use GetPet2.",
+				Summary:          "Get a pet.",
+				Description:      "Deprecated: use GetPet2.",
+				Deprecated:       true,
+				DeprecatedReason: "use GetPet2.",
+			},
+		},
+		{
+			name:  "linter rules removed",
+			texts: []string{"// Get a pet.\n// (-- api-linter: core::0131::request-name-field=disabled --)"},
+			want:  &Comment{Text: "Get a pet.", Summary: "Get a pet."},
+		},
+		{
+			name:  "no comment",
+			texts: []string{"", "//"},
+			want:  &Comment{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ParseComment(tt.texts...); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseComment() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,253 @@
+/*
+ * Copyright 2024 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package utils
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hertz-contrib/swagger-generate/common/consts"
+	"gopkg.in/yaml.v3"
+)
+
+// invalidComponentChars are the characters that are not allowed in the names of the components.
+var invalidComponentChars = regexp.MustCompile(`[^a-zA-Z0-9._-]+`)
+
+// componentOccurrence is an inline parameter, header or response of an operation.
+type componentOccurrence struct {
+	node *yaml.Node
+	// hint is the name of the header or the status of the response.
+	hint string
+	set  func(*yaml.Node)
+}
+
+// HoistComponents moves the parameters, headers and responses named by the `x-component` extension, and with
+// repeated those that are identical in several operations, to the components of a document and refers to them
+// with `$ref`s. It returns an error listing the names given to different elements, which are numbered instead.
+func HoistComponents(document *yaml.Node, repeated bool) error {
+	if document.Kind == yaml.DocumentNode && len(document.Content) == 1 {
+		document = document.Content[0]
+	}
+	h := &componentHoister{document: document, repeated: repeated}
+	h.hoist("parameters", h.parameters(), parameterComponentName)
+	h.hoist("headers", h.headers(), func(o *componentOccurrence) string { return o.hint })
+	h.hoist("responses", h.responses(), responseComponentName)
+	if len(h.conflicts) > 0 {
+		return fmt.Errorf("different elements are named %s", strings.Join(h.conflicts, ", "))
+	}
+	return nil
+}
+
+type componentHoister struct {
+	document *yaml.Node
+	// repeated hoists the unnamed elements repeated in several operations too.
+	repeated  bool
+	conflicts []string
+}
+
+// operations calls f for each operation of the document.
+func (h *componentHoister) operations(f func(operation *yaml.Node)) {
+	forEachMapping(mappingValue(h.document, "paths"), func(_ string, pathItem *yaml.Node) {
+		forEachMapping(pathItem, func(method string, operation *yaml.Node) {
+			if isOperationKey(method) {
+				f(operation)
+			}
+		})
+	})
+}
+
+func (h *componentHoister) parameters() []*componentOccurrence {
+	var occurrences []*componentOccurrence
+	addParameters := func(parameters *yaml.Node) {
+		if parameters == nil || parameters.Kind != yaml.SequenceNode {
+			return
+		}
+		for i, parameter := range parameters.Content {
+			i := i
+			occurrences = append(occurrences, &componentOccurrence{node: parameter, set: func(n *yaml.Node) { parameters.Content[i] = n }})
+		}
+	}
+	forEachMapping(mappingValue(h.document, "paths"), func(_ string, pathItem *yaml.Node) {
+		addParameters(mappingValue(pathItem, "parameters"))
+	})
+	h.operations(func(operation *yaml.Node) {
+		addParameters(mappingValue(operation, "parameters"))
+	})
+	return occurrences
+}
+
+func (h *componentHoister) headers() []*componentOccurrence {
+	var occurrences []*componentOccurrence
+	h.operations(func(operation *yaml.Node) {
+		forEachMapping(mappingValue(operation, "responses"), func(_ string, response *yaml.Node) {
+			headers := mappingValue(response, "headers")
+			forEachMapping(headers, func(name string, header *yaml.Node) {
+				occurrences = append(occurrences, &componentOccurrence{node: header, hint: name, set: func(n *yaml.Node) { setMappingValue(headers, name, n) }})
+			})
+		})
+	})
+	return occurrences
+}
+
+func (h *componentHoister) responses() []*componentOccurrence {
+	var occurrences []*componentOccurrence
+	h.operations(func(operation *yaml.Node) {
+		responses := mappingValue(operation, "responses")
+		forEachMapping(responses, func(status string, response *yaml.Node) {
+			occurrences = append(occurrences, &componentOccurrence{node: response, hint: status, set: func(n *yaml.Node) { setMappingValue(responses, status, n) }})
+		})
+	})
+	return occurrences
+}
+
+// hoist moves the occurrences that are named or repeated to the components of a kind.
+func (h *componentHoister) hoist(kind string, occurrences []*componentOccurrence, defaultName func(*componentOccurrence) string) {
+	type group struct {
+		name        string
+		canonical   string
+		occurrences []*componentOccurrence
+	}
+	var groups []*group
+	byKey := make(map[string]*group)
+	for _, o := range occurrences {
+		if o.node.Kind != yaml.MappingNode || mappingValue(o.node, "$ref") != nil {
+			continue
+		}
+		name := stringValue(o.node, consts.ExtensionComponent)
+		deleteMappingValue(o.node, consts.ExtensionComponent)
+		canonical := canonicalNode(o.node)
+		key := name + "\n" + canonical
+		g, ok := byKey[key]
+		if !ok {
+			g = &group{name: name, canonical: canonical}
+			byKey[key] = g
+			groups = append(groups, g)
+		}
+		g.occurrences = append(g.occurrences, o)
+	}
+
+	components := mappingValue(h.document, "components")
+	var hoisted *yaml.Node
+	for _, g := range groups {
+		if g.name == "" && (!h.repeated || len(g.occurrences) < 2) {
+			continue
+		}
+		if components == nil {
+			components = &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+			setMappingValue(h.document, "components", components)
+		}
+		if hoisted = mappingValue(components, kind); hoisted == nil {
+			hoisted = &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+			setMappingValue(components, kind, hoisted)
+		}
+
+		name := g.name
+		if name == "" {
+			name = defaultName(g.occurrences[0])
+		}
+		name = invalidComponentChars.ReplaceAllString(name, "_")
+		for i := 1; ; i++ {
+			candidate := name
+			if i > 1 {
+				candidate = name + "_" + strconv.Itoa(i)
+			}
+			existing := mappingValue(hoisted, candidate)
+			if existing == nil {
+				setMappingValue(hoisted, candidate, g.occurrences[0].node)
+			} else if canonicalNode(existing) != g.canonical {
+				if i == 1 && g.name != "" {
+					h.conflicts = append(h.conflicts, g.name)
+				}
+				continue
+			}
+			name = candidate
+			break
+		}
+		for _, o := range g.occurrences {
+			o.set(&yaml.Node{Kind: yaml.MappingNode, Tag: "!!map", Content: []*yaml.Node{
+				stringNode("$ref"), stringNode("#/components/" + kind + "/" + escapePointer(name)),
+			}})
+		}
+	}
+	if hoisted != nil {
+		sortMapping(hoisted)
+	}
+}
+
+// parameterComponentName names a parameter component after the parameter.
+func parameterComponentName(o *componentOccurrence) string {
+	if name := stringValue(o.node, "name"); name != "" {
+		return name
+	}
+	return "Parameter"
+}
+
+// responseComponentName names a response component after the schema of its content, its description or its status.
+func responseComponentName(o *componentOccurrence) string {
+	var name string
+	forEachMapping(mappingValue(o.node, "content"), func(_ string, media *yaml.Node) {
+		if ref := stringValue(mappingValue(media, "schema"), "$ref"); name == "" && strings.HasPrefix(ref, "#/components/schemas/") {
+			name = strings.TrimPrefix(ref, "#/components/schemas/")
+		}
+	})
+	if name != "" {
+		return name
+	}
+	if description := stringValue(o.node, "description"); description != "" && !strings.ContainsAny(description, " \n") {
+		return description
+	}
+	return "Response" + o.hint
+}
+
+// canonicalNode returns a node serialized without its style and comments, and with the keys of its mappings
+// sorted, to compare nodes.
+func canonicalNode(node *yaml.Node) string {
+	c := copyNode(node)
+	sortMappings(c)
+	b, err := yaml.Marshal(c)
+	if err != nil {
+		return ""
+	}
+	return string(b)
+}
+
+// sortMappings sorts the keys of the mappings of a node and of its children.
+func sortMappings(node *yaml.Node) {
+	for _, child := range node.Content {
+		sortMappings(child)
+	}
+	if node.Kind == yaml.MappingNode {
+		sortMapping(node)
+	}
+}
+
+// sortMapping sorts the keys of a mapping node.
+func sortMapping(node *yaml.Node) {
+	type pair struct{ key, value *yaml.Node }
+	pairs := make([]pair, 0, len(node.Content)/2)
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		pairs = append(pairs, pair{node.Content[i], node.Content[i+1]})
+	}
+	sort.SliceStable(pairs, func(i, j int) bool { return pairs[i].key.Value < pairs[j].key.Value })
+	node.Content = node.Content[:0]
+	for _, p := range pairs {
+		node.Content = append(node.Content, p.key, p.value)
+	}
+}
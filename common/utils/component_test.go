@@ -0,0 +1,123 @@
+/*
+ * Copyright 2024 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package utils
+
+import (
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+func TestHoistComponents(t *testing.T) {
+	tests := []struct {
+		name     string
+		document string
+		repeated bool
+		want     string
+		// conflicts are the names reported as given to different elements.
+		conflicts []string
+	}{
+		{
+			name: "repeated elements kept inline by default",
+			document: `paths:
+  /a: {get: {parameters: [{name: X-Request-Id, in: header, schema: {type: string}}]}}
+  /b: {get: {parameters: [{name: X-Request-Id, in: header, schema: {type: string}}]}}`,
+			want: `paths:
+  /a: {get: {parameters: [{name: X-Request-Id, in: header, schema: {type: string}}]}}
+  /b: {get: {parameters: [{name: X-Request-Id, in: header, schema: {type: string}}]}}`,
+		},
+		{
+			name: "equal elements with their keys in different orders",
+			document: `paths:
+  /a: {get: {parameters: [{name: X-Request-Id, in: header, schema: {type: string, format: uuid}}]}}
+  /b: {get: {parameters: [{schema: {format: uuid, type: string}, in: header, name: X-Request-Id}]}}`,
+			repeated: true,
+			want: `paths:
+  /a: {get: {parameters: [{$ref: '#/components/parameters/X-Request-Id'}]}}
+  /b: {get: {parameters: [{$ref: '#/components/parameters/X-Request-Id'}]}}
+components:
+  parameters:
+    X-Request-Id: {name: X-Request-Id, in: header, schema: {type: string, format: uuid}}`,
+		},
+		{
+			name: "named elements hoisted by default",
+			document: `paths:
+  /a: {get: {parameters: [{name: page, in: query, x-component: Page}], responses: {'200': {description: OK, headers: {X-Trace: {schema: {type: string}}}}}}}`,
+			want: `paths:
+  /a: {get: {parameters: [{$ref: '#/components/parameters/Page'}], responses: {'200': {description: OK, headers: {X-Trace: {schema: {type: string}}}}}}}
+components:
+  parameters:
+    Page: {name: page, in: query}`,
+		},
+		{
+			name: "different repeated elements of the same name",
+			document: `paths:
+  /a: {get: {parameters: [{name: id, in: query, schema: {type: integer}}]}}
+  /b: {get: {parameters: [{name: id, in: query, schema: {type: integer}}]}}
+  /c: {get: {parameters: [{name: id, in: query, schema: {type: string}}]}}
+  /d: {get: {parameters: [{name: id, in: query, schema: {type: string}}]}}`,
+			repeated: true,
+			want: `paths:
+  /a: {get: {parameters: [{$ref: '#/components/parameters/id'}]}}
+  /b: {get: {parameters: [{$ref: '#/components/parameters/id'}]}}
+  /c: {get: {parameters: [{$ref: '#/components/parameters/id_2'}]}}
+  /d: {get: {parameters: [{$ref: '#/components/parameters/id_2'}]}}
+components:
+  parameters:
+    id: {name: id, in: query, schema: {type: integer}}
+    id_2: {name: id, in: query, schema: {type: string}}`,
+		},
+		{
+			name: "different elements named the same",
+			document: `paths:
+  /a: {get: {responses: {'400': {description: Bad, x-component: Error}}}}
+  /b: {get: {responses: {'400': {description: Invalid, x-component: Error}}}}`,
+			want: `paths:
+  /a: {get: {responses: {'400': {$ref: '#/components/responses/Error'}}}}
+  /b: {get: {responses: {'400': {$ref: '#/components/responses/Error_2'}}}}
+components:
+  responses:
+    Error: {description: Bad}
+    Error_2: {description: Invalid}`,
+			conflicts: []string{"Error"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var document, want yaml.Node
+			if err := yaml.Unmarshal([]byte(tt.document), &document); err != nil {
+				t.Fatalf("failed to parse document: %s", err)
+			}
+			if err := yaml.Unmarshal([]byte(tt.want), &want); err != nil {
+				t.Fatalf("failed to parse wanted document: %s", err)
+			}
+			err := HoistComponents(&document, tt.repeated)
+			if tt.conflicts == nil && err != nil {
+				t.Errorf("HoistComponents() error = %s", err)
+			}
+			for _, name := range tt.conflicts {
+				if err == nil || !strings.Contains(err.Error(), name) {
+					t.Errorf("HoistComponents() error = %v, want the conflict of %s", err, name)
+				}
+			}
+			if got, want := canonicalNode(&document), canonicalNode(&want); got != want {
+				t.Errorf("HoistComponents() =\n%s\nwant\n%s", got, want)
+			}
+		})
+	}
+}
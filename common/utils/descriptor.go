@@ -0,0 +1,317 @@
+/*
+ * Copyright 2024 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package utils
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/cloudwego/thriftgo/parser"
+	"github.com/cloudwego/thriftgo/thrift_reflection"
+	thriftutils "github.com/cloudwego/thriftgo/utils"
+)
+
+// Descriptors are the reflection descriptors of a thrift file and its includes. They resolve the types of
+// the fields and read the options of the annotations in these files only, unlike the descriptors of
+// thrift_reflection.RegisterAST, which are looked up in a registry of thriftgo that is never emptied.
+type Descriptors struct {
+	files map[string]*thrift_reflection.FileDescriptor
+}
+
+// NewDescriptors returns the descriptors of a thrift file and its includes, and the descriptor of the file.
+func NewDescriptors(ast *parser.Thrift) (*Descriptors, *thrift_reflection.FileDescriptor) {
+	d := &Descriptors{files: make(map[string]*thrift_reflection.FileDescriptor)}
+	return d, d.add(ast)
+}
+
+func (d *Descriptors) add(ast *parser.Thrift) *thrift_reflection.FileDescriptor {
+	if fd, ok := d.files[ast.Filename]; ok {
+		return fd
+	}
+	fd := thrift_reflection.GetFileDescriptor(ast)
+	d.files[ast.Filename] = fd
+	for _, include := range ast.Includes {
+		if ref := include.GetReference(); ref != nil {
+			d.add(ref)
+		}
+	}
+	return fd
+}
+
+// lookup returns the file declaring a name used in a file, through the alias of its include, and the name in it.
+func (d *Descriptors) lookup(filepath, name string) (*thrift_reflection.FileDescriptor, string) {
+	prefix, name := thriftutils.ParseAlias(name)
+	fd := d.files[filepath]
+	if fd != nil && prefix != "" {
+		fd = d.files[fd.Includes[prefix]]
+	}
+	return fd, name
+}
+
+// declared returns the file declaring a type and its name in it, or nil if it is a base or container type.
+func (d *Descriptors) declared(t *thrift_reflection.TypeDescriptor) (*thrift_reflection.FileDescriptor, string) {
+	if t == nil || t.IsBasic() || t.IsContainer() {
+		return nil, ""
+	}
+	return d.lookup(t.GetFilepath(), t.GetName())
+}
+
+// Struct returns the struct of a type, or nil if it is not a struct.
+func (d *Descriptors) Struct(t *thrift_reflection.TypeDescriptor) *thrift_reflection.StructDescriptor {
+	fd, name := d.declared(t)
+	return fd.GetStructDescriptor(name)
+}
+
+// Union returns the union of a type, or nil if it is not a union.
+func (d *Descriptors) Union(t *thrift_reflection.TypeDescriptor) *thrift_reflection.StructDescriptor {
+	fd, name := d.declared(t)
+	return fd.GetUnionDescriptor(name)
+}
+
+// Exception returns the exception of a type, or nil if it is not an exception.
+func (d *Descriptors) Exception(t *thrift_reflection.TypeDescriptor) *thrift_reflection.StructDescriptor {
+	fd, name := d.declared(t)
+	return fd.GetExceptionDescriptor(name)
+}
+
+// Enum returns the enum of a type, or nil if it is not an enum.
+func (d *Descriptors) Enum(t *thrift_reflection.TypeDescriptor) *thrift_reflection.EnumDescriptor {
+	fd, name := d.declared(t)
+	return fd.GetEnumDescriptor(name)
+}
+
+// Typedef returns the typedef of a type, or nil if it is not a typedef.
+func (d *Descriptors) Typedef(t *thrift_reflection.TypeDescriptor) *thrift_reflection.TypedefDescriptor {
+	fd, name := d.declared(t)
+	return fd.GetTypedefDescriptor(name)
+}
+
+// annotated is a descriptor with annotations.
+type annotated interface {
+	GetAnnotations() map[string][]string
+	GetFilepath() string
+}
+
+// ParseOption reads the option of an annotation, like `openapi.schema` declared by the `_StructOptions` of
+// openapi.thrift, into obj by its JSON encoding, like thrift_option in map mode. The option is declared by
+// the struct of the kind in the file included by the alias of the annotation. It does nothing if the file of
+// the descriptor does not include it or the annotations do not set it.
+func (d *Descriptors) ParseOption(desc annotated, kind, annotation string, obj interface{}) error {
+	optionFile, name := d.lookup(desc.GetFilepath(), annotation)
+	if optionFile == nil {
+		return nil
+	}
+	field := optionFile.GetStructDescriptor(kind).GetFieldByName(name)
+	if field == nil || field.GetType() == nil {
+		return nil
+	}
+	content := d.optionContent(desc, optionFile.GetFilepath(), name)
+	if content == "" {
+		return nil
+	}
+	content = strings.TrimSpace(strings.NewReplacer("\n", " ", "\t", " ").Replace(content))
+	value, err := d.optionValue(field.GetType(), content)
+	if err != nil {
+		return fmt.Errorf("failed to parse option %s: %s", annotation, err)
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, obj)
+}
+
+// optionContent returns the value of the annotations of an option, or of its subpaths like `openapi.schema.title`
+// assembled into a struct.
+func (d *Descriptors) optionContent(desc annotated, optionFilepath, name string) string {
+	fd := d.files[desc.GetFilepath()]
+	subpaths := make(map[string]string)
+	for key, values := range desc.GetAnnotations() {
+		parts := strings.SplitN(key, ".", 3)
+		if len(values) == 0 || len(parts) < 2 || parts[1] != name || fd.Includes[parts[0]] != optionFilepath {
+			continue
+		}
+		if len(parts) == 2 {
+			return values[len(values)-1]
+		}
+		subpaths[parts[2]] = values[len(values)-1]
+	}
+	if len(subpaths) == 0 {
+		return ""
+	}
+	tree := make(map[string]interface{})
+	for path, value := range subpaths {
+		node := tree
+		keys := strings.Split(path, ".")
+		for _, key := range keys[:len(keys)-1] {
+			child, ok := node[key].(map[string]interface{})
+			if !ok {
+				child = make(map[string]interface{})
+				node[key] = child
+			}
+			node = child
+		}
+		node[keys[len(keys)-1]] = value
+	}
+	return formatOptionTree(tree)
+}
+
+// formatOptionTree formats the subpaths of an option as a struct value, in the order of their names.
+func formatOptionTree(tree map[string]interface{}) string {
+	keys := make([]string, 0, len(tree))
+	for key := range tree {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	entries := make([]string, 0, len(keys))
+	for _, key := range keys {
+		switch v := tree[key].(type) {
+		case string:
+			entries = append(entries, key+":\""+v+"\"")
+		case map[string]interface{}:
+			entries = append(entries, key+":"+formatOptionTree(v))
+		}
+	}
+	return "{" + strings.Join(entries, ",") + "}"
+}
+
+// optionValue returns the value of an option of a type, with maps of string keys for the structs and maps.
+func (d *Descriptors) optionValue(t *thrift_reflection.TypeDescriptor, content string) (interface{}, error) {
+	content = trimOptionQuotes(content)
+	switch {
+	case t.IsBasic():
+		return basicOptionValue(t.GetName(), content)
+	case t.IsMap():
+		kv, err := thriftutils.ParseKV(content)
+		if err != nil {
+			return nil, err
+		}
+		values := make(map[string]interface{}, len(kv))
+		for k, v := range kv {
+			key, err := d.optionValue(t.GetKeyType(), k)
+			if err != nil {
+				return nil, err
+			}
+			if values[fmt.Sprint(key)], err = d.optionValue(t.GetValueType(), v); err != nil {
+				return nil, err
+			}
+		}
+		return values, nil
+	case t.IsList():
+		items, err := thriftutils.ParseArr(content)
+		if err != nil {
+			return nil, err
+		}
+		values := make([]interface{}, 0, len(items))
+		for _, item := range items {
+			value, err := d.optionValue(t.GetValueType(), item)
+			if err != nil {
+				return nil, err
+			}
+			values = append(values, value)
+		}
+		return values, nil
+	}
+	if s := d.Struct(t); s != nil {
+		return d.structOptionValue(s, content)
+	}
+	if e := d.Enum(t); e != nil {
+		for _, v := range e.GetValues() {
+			if v.GetName() == content {
+				return v.GetValue(), nil
+			}
+		}
+		return nil, fmt.Errorf("enum value %s not found for %s", content, e.GetName())
+	}
+	if td := d.Typedef(t); td != nil {
+		return d.optionValue(td.GetType(), content)
+	}
+	return nil, fmt.Errorf("unknown type %s", t.GetName())
+}
+
+// structOptionValue returns the value of an option of a struct, with the defaults of the fields it does not set.
+func (d *Descriptors) structOptionValue(s *thrift_reflection.StructDescriptor, content string) (interface{}, error) {
+	kv, err := thriftutils.ParseKV(content)
+	if err != nil {
+		return nil, err
+	}
+	for k := range kv {
+		if s.GetFieldByName(k) == nil {
+			return nil, fmt.Errorf("field %s not found in %s", k, s.GetName())
+		}
+	}
+	values := make(map[string]interface{})
+	for _, f := range s.GetFields() {
+		content, ok := kv[f.GetName()]
+		if !ok {
+			if f.GetDefaultValue() == nil {
+				continue
+			}
+			content = d.constString(s.GetFilepath(), f.GetDefaultValue())
+		}
+		if values[f.GetName()], err = d.optionValue(f.GetType(), content); err != nil {
+			return nil, err
+		}
+	}
+	return values, nil
+}
+
+// constString returns a constant value as in an annotation, with the value of the constant it names if any.
+func (d *Descriptors) constString(filepath string, v *thrift_reflection.ConstValueDescriptor) string {
+	if v.GetType() == thrift_reflection.ConstValueType_IDENTIFIER {
+		fd, name := d.lookup(filepath, v.GetValueIdentifier())
+		if c := fd.GetConstDescriptor(name); c != nil {
+			return d.constString(fd.GetFilepath(), c.GetValue())
+		}
+		return ""
+	}
+	return v.GetValueAsString()
+}
+
+func trimOptionQuotes(value string) string {
+	if len(value) >= 2 && (value[0] == '\'' || value[0] == '"') && value[len(value)-1] == value[0] {
+		return value[1 : len(value)-1]
+	}
+	return value
+}
+
+func basicOptionValue(typ, value string) (interface{}, error) {
+	switch typ {
+	case "bool":
+		return strconv.ParseBool(value)
+	case "byte", "i8":
+		return strconv.ParseInt(value, 10, 8)
+	case "i16":
+		return strconv.ParseInt(value, 10, 16)
+	case "i32":
+		return strconv.ParseInt(value, 10, 32)
+	case "i64":
+		return strconv.ParseInt(value, 10, 64)
+	case "double":
+		return strconv.ParseFloat(value, 64)
+	case "binary":
+		return hex.DecodeString(value)
+	case "string":
+		return value, nil
+	}
+	return nil, errors.New("unsupported basic type " + typ)
+}
@@ -0,0 +1,153 @@
+/*
+ * Copyright 2024 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package utils
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/cloudwego/thriftgo/parser"
+	"github.com/cloudwego/thriftgo/semantic"
+	"github.com/cloudwego/thriftgo/thrift_reflection"
+)
+
+// descriptorFiles are the thrift files of the tests: the options, a file of shared types and a service.
+var descriptorFiles = map[string]string{
+	"options.thrift": `
+enum Level { LOW = 1, HIGH = 2 }
+typedef list<string> Tags
+struct Schema {
+	1: string title
+	2: i32 max_length
+	3: Level level
+	4: Tags tags
+	5: map<string, i64> limits
+	6: bool read_only = true
+}
+struct _StructOptions { 1: Schema schema }
+struct _FieldOptions { 1: Schema property }
+`,
+	"types.thrift": `
+typedef string ID
+enum Kind { CAT, DOG }
+union Owner { 1: string name, 2: i64 id }
+exception NotFound { 1: string message }
+`,
+	"pet.thrift": `
+include "options.thrift"
+include "types.thrift"
+struct Pet {
+	1: types.ID id
+	2: types.Kind kind (options.property.title = "kind", options.property.level = "HIGH")
+	3: types.Owner owner
+	4: list<Pet> friends
+} (options.schema = '{title: "pet", max_length: "8", tags: ["a", "b"], limits: {"x": "1"}}')
+service PetService { Pet GetPet(1: Pet req) throws (1: types.NotFound err) }
+`,
+}
+
+// parseDescriptors returns the descriptors of pet.thrift and its includes, and the descriptor of pet.thrift.
+func parseDescriptors(t *testing.T) (*Descriptors, *thrift_reflection.FileDescriptor) {
+	t.Helper()
+	dir := t.TempDir()
+	for name, content := range descriptorFiles {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	ast, err := parser.ParseFile(filepath.Join(dir, "pet.thrift"), nil, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = semantic.ResolveSymbols(ast); err != nil {
+		t.Fatal(err)
+	}
+	descs, fd := NewDescriptors(ast)
+	if fd.GetStructDescriptor("Pet") == nil {
+		t.Fatal("NewDescriptors() returned the descriptor of another file")
+	}
+	return descs, fd
+}
+
+func TestDescriptorsTypes(t *testing.T) {
+	descs, fd := parseDescriptors(t)
+	pet := fd.GetStructDescriptor("Pet")
+	fields := pet.GetFields()
+	if descs.Typedef(fields[0].GetType()) == nil || descs.Struct(fields[0].GetType()) != nil {
+		t.Errorf("the id is not resolved as a typedef")
+	}
+	if e := descs.Enum(fields[1].GetType()); e == nil || e.GetName() != "Kind" {
+		t.Errorf("Enum(kind) = %v, want Kind", e)
+	}
+	if u := descs.Union(fields[2].GetType()); u == nil || u.GetName() != "Owner" {
+		t.Errorf("Union(owner) = %v, want Owner", u)
+	}
+	if s := descs.Struct(fields[3].GetType().GetValueType()); s != pet {
+		t.Errorf("Struct(friends item) = %v, want Pet", s)
+	}
+	if descs.Struct(fields[3].GetType()) != nil {
+		t.Errorf("Struct(list) is not nil")
+	}
+	method := fd.GetServiceDescriptor("PetService").GetMethods()[0]
+	if e := descs.Exception(method.ThrowExceptions[0].GetType()); e == nil || e.GetName() != "NotFound" {
+		t.Errorf("Exception(err) = %v, want NotFound", e)
+	}
+}
+
+func TestDescriptorsParseOption(t *testing.T) {
+	descs, fd := parseDescriptors(t)
+	pet := fd.GetStructDescriptor("Pet")
+
+	var schema map[string]interface{}
+	if err := descs.ParseOption(pet, "_StructOptions", "options.schema", &schema); err != nil {
+		t.Fatalf("ParseOption(schema) error = %s", err)
+	}
+	want := map[string]interface{}{
+		"title":      "pet",
+		"max_length": float64(8),
+		"tags":       []interface{}{"a", "b"},
+		"limits":     map[string]interface{}{"x": float64(1)},
+		"read_only":  true,
+	}
+	if !reflect.DeepEqual(schema, want) {
+		t.Errorf("ParseOption(schema) = %v, want %v", schema, want)
+	}
+
+	// The subpaths of an option are assembled into its struct.
+	var property map[string]interface{}
+	if err := descs.ParseOption(pet.GetFields()[1], "_FieldOptions", "options.property", &property); err != nil {
+		t.Fatalf("ParseOption(property) error = %s", err)
+	}
+	want = map[string]interface{}{"title": "kind", "level": float64(2), "read_only": true}
+	if !reflect.DeepEqual(property, want) {
+		t.Errorf("ParseOption(property) = %v, want %v", property, want)
+	}
+
+	// The options that are not set, not declared or not included are ignored.
+	for _, tt := range []struct{ kind, annotation string }{
+		{"_FieldOptions", "options.property"},
+		{"_StructOptions", "options.unknown"},
+		{"_StructOptions", "openapi.schema"},
+	} {
+		var v map[string]interface{}
+		if err := descs.ParseOption(pet.GetFields()[0], tt.kind, tt.annotation, &v); err != nil || v != nil {
+			t.Errorf("ParseOption(%s) = %v, %v, want nothing", tt.annotation, v, err)
+		}
+	}
+}
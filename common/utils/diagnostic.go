@@ -0,0 +1,110 @@
+/*
+ * Copyright 2024 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package utils
+
+import "fmt"
+
+// Severity is the severity of a diagnostic.
+type Severity int
+
+const (
+	SeverityWarning Severity = iota
+	SeverityError
+)
+
+func (s Severity) String() string {
+	if s == SeverityError {
+		return "error"
+	}
+	return "warning"
+}
+
+// The codes of the diagnostics, the issues of the linter use the names of their rules.
+const (
+	DiagnosticInvalidOption        = "invalid-option"        // An annotation or option of the IDL cannot be parsed or merged.
+	DiagnosticUnsupportedType      = "unsupported-type"      // A type of the IDL cannot be documented.
+	DiagnosticUnresolvedType       = "unresolved-type"       // A type of the IDL cannot be found.
+	DiagnosticIgnoredArgument      = "ignored-argument"      // A method has more than one argument, only the first is documented.
+	DiagnosticConflictingOperation = "conflicting-operation" // Methods declare the same route, only the first is documented.
+	DiagnosticInvalidExample       = "invalid-example"       // The examples cannot be added to the document.
+	DiagnosticIDL                  = "idl"                   // A warning of the IDL parser.
+	DiagnosticOverlay              = "overlay"               // An action of the overlay file cannot be applied.
+	DiagnosticConflictingComponent = "conflicting-component" // Different elements are named after the same component.
+	DiagnosticUnproxiedMethod      = "unproxied-method"      // A method cannot be called by the proxy of the swagger server.
+	DiagnosticDeprecatedOption     = "deprecated-option"     // An option of the plugin is spelled by a deprecated name.
+)
+
+// Diagnostic is a problem found by a generator, which is returned to the caller instead of being logged.
+type Diagnostic struct {
+	Severity Severity
+	Code     string
+	// Location is where the element is declared in the IDL, e.g. `hello.thrift:12:1 (HelloService.Hello)`, empty if unknown.
+	Location string
+	Message  string
+}
+
+// String returns the diagnostic like `location: message [code]`.
+func (d *Diagnostic) String() string {
+	s := d.Message
+	if d.Location != "" {
+		s = fmt.Sprintf("%s: %s", d.Location, s)
+	}
+	if d.Code != "" {
+		s = fmt.Sprintf("%s [%s]", s, d.Code)
+	}
+	return s
+}
+
+// DeprecatedArgDiagnostics returns the warnings of the arguments spelled by the deprecated names of their fields.
+func DeprecatedArgDiagnostics(keys []string) []*Diagnostic {
+	diagnostics := make([]*Diagnostic, 0, len(keys))
+	for _, key := range keys {
+		diagnostics = append(diagnostics, &Diagnostic{
+			Severity: SeverityWarning,
+			Code:     DiagnosticDeprecatedOption,
+			Message:  fmt.Sprintf("option %s is deprecated, use %s instead", key, ArgName(key)),
+		})
+	}
+	return diagnostics
+}
+
+// CheckDiagnostics returns an error if the diagnostics fail the generation: in strict mode if some are errors,
+// and with warnings as errors if there are any, in which case the warnings become errors.
+func CheckDiagnostics(diagnostics []*Diagnostic, strict, warningsAsErrors bool) error {
+	if warningsAsErrors {
+		for _, d := range diagnostics {
+			d.Severity = SeverityError
+		}
+		strict = true
+	}
+	if !strict {
+		return nil
+	}
+	errors := 0
+	for _, d := range diagnostics {
+		if d.Severity == SeverityError {
+			errors++
+		}
+	}
+	switch {
+	case errors == 1:
+		return fmt.Errorf("found 1 error in strict mode")
+	case errors > 1:
+		return fmt.Errorf("found %d errors in strict mode", errors)
+	}
+	return nil
+}
@@ -0,0 +1,62 @@
+/*
+ * Copyright 2024 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package utils
+
+import "testing"
+
+func TestCheckDiagnostics(t *testing.T) {
+	tests := []struct {
+		name             string
+		severities       []Severity
+		strict           bool
+		warningsAsErrors bool
+		// err is the error, empty when the diagnostics do not fail the generation.
+		err string
+	}{
+		{"warnings", []Severity{SeverityWarning}, false, false, ""},
+		{"errors", []Severity{SeverityError, SeverityWarning}, false, false, ""},
+		{"strict with warnings", []Severity{SeverityWarning, SeverityWarning}, true, false, ""},
+		{"strict with an error", []Severity{SeverityError, SeverityWarning}, true, false, "found 1 error in strict mode"},
+		{"strict with errors", []Severity{SeverityError, SeverityError}, true, false, "found 2 errors in strict mode"},
+		{"warnings as errors", []Severity{SeverityWarning, SeverityWarning}, false, true, "found 2 errors in strict mode"},
+		{"warnings as errors without diagnostics", nil, false, true, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var diagnostics []*Diagnostic
+			for _, severity := range tt.severities {
+				diagnostics = append(diagnostics, &Diagnostic{Severity: severity, Code: LintKebabCasePath})
+			}
+			err := CheckDiagnostics(diagnostics, tt.strict, tt.warningsAsErrors)
+			if (err == nil && tt.err != "") || (err != nil && err.Error() != tt.err) {
+				t.Errorf("CheckDiagnostics() error = %v, want %q", err, tt.err)
+			}
+			for _, d := range diagnostics {
+				if tt.warningsAsErrors && d.Severity != SeverityError {
+					t.Errorf("CheckDiagnostics() left a warning with warnings as errors")
+				}
+			}
+		})
+	}
+}
+
+func TestDiagnosticString(t *testing.T) {
+	d := &Diagnostic{Code: LintKebabCasePath, Location: "hello.thrift:3:1 (Hello)", Message: "path segment pet_owners is not kebab-case"}
+	if got, want := d.String(), "hello.thrift:3:1 (Hello): path segment pet_owners is not kebab-case [kebab-case-path]"; got != want {
+		t.Errorf("String() = %s, want %s", got, want)
+	}
+}
@@ -0,0 +1,121 @@
+/*
+ * Copyright 2024 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package utils
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"regexp"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/hertz-contrib/swagger-generate/common/consts"
+	"github.com/hertz-contrib/swagger-generate/common/tpl"
+)
+
+// Idl is an IDL generated into the package of an RPC swagger server: its document, and the services of the proxy
+// with the IDL files from which their generic clients are built.
+type Idl struct {
+	Name          string            // Name is the name of the IDL in a package generated from several IDLs, empty by default.
+	ServicePrefix bool              // ServicePrefix routes the methods as /{Service}/{Method} instead of /{Method}.
+	Services      []*IdlService     // Services are the services of the IDL, proxied by the server.
+	Files         map[string]string // Files are the contents of the IDL and of its includes, keyed by path.
+	Schemas       string            // Schemas are the JSON schemas of the components referenced by the request schemas.
+}
+
+// IdlService is a service of an IDL, whose generic client is built from the IDL file declaring it.
+type IdlService struct {
+	Name    string
+	Package string // Package is the package of a proto service.
+	File    string // File is the path of the IDL file declaring the service, in the files of the IDL.
+	Methods []*IdlMethod
+}
+
+// IdlMethod is a method of a service, routed by the proxy when its operation is documented.
+type IdlMethod struct {
+	Name      string
+	Safe      bool              // Safe allows the method in the read-only mode of the proxy, by the x-safe extension of its operation.
+	Schema    string            // Schema is the JSON schema of the request bodies, validated by the proxy, empty if it has none.
+	BaseField string            // BaseField is the Kitex Base field of the requests, filled by the proxy, empty if they have none.
+	BaseKeys  map[string]string // BaseKeys are the thrift types of the scalar fields of the Base by name.
+}
+
+var idlNameRegexp = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_]*$`)
+
+// CheckIdlName returns an error if a name of IDL cannot name its files and its routes.
+func CheckIdlName(name string) error {
+	if name != "" && !idlNameRegexp.MatchString(name) {
+		return fmt.Errorf("invalid IDL name %s, expected letters, digits and underscores starting with a letter", name)
+	}
+	return nil
+}
+
+// IdlOutputFiles returns the names of the document and of the Go file generated for an IDL of a name:
+// openapi.yaml and idl.go for the IDL without name, and [name].openapi.yaml and [name]_idl.go otherwise.
+func IdlOutputFiles(name string) (document, idl string) {
+	if name == "" {
+		return consts.DefaultOutputYamlFile, consts.DefaultOutputIdlFile
+	}
+	return name + "." + consts.DefaultOutputYamlFile, name + "_" + consts.DefaultOutputIdlFile
+}
+
+// DocumentFile returns the name of the document of the IDL, embedded into the server.
+func (i *Idl) DocumentFile() string {
+	document, _ := IdlOutputFiles(i.Name)
+	return document
+}
+
+// DocumentVar returns the name of the variable the document of the IDL is embedded into.
+func (i *Idl) DocumentVar() string {
+	if i.Name == "" {
+		return "document"
+	}
+	return "document" + strings.ToUpper(i.Name[:1]) + i.Name[1:]
+}
+
+// EmbedIdl returns the Go file that registers an IDL into the swagger server, embedding its document
+// and the contents of its files, preceded by the code generation comment of the plugin.
+func EmbedIdl(comment string, idl *Idl) ([]byte, error) {
+	tmpl, err := template.New("idl").Funcs(template.FuncMap{"quoteLines": quoteLines}).Parse(consts.BuildConstraintSwagger + "\n\n" + comment + " DO NOT EDIT.\n\n" + tpl.IdlTemplate)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse template: %w", err)
+	}
+
+	var buf bytes.Buffer
+	if err = tmpl.Execute(&buf, idl); err != nil {
+		return nil, fmt.Errorf("failed to execute template: %w", err)
+	}
+	return format.Source(buf.Bytes())
+}
+
+// quoteLines quotes a text as the concatenation of its lines, which keeps the changes of the IDL readable.
+func quoteLines(text string) string {
+	if text == "" {
+		return `""`
+	}
+	lines := strings.SplitAfter(text, "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	quoted := make([]string, len(lines))
+	for i, line := range lines {
+		quoted[i] = strconv.Quote(line)
+	}
+	return `"" +` + "\n\t\t" + strings.Join(quoted, " +\n\t\t")
+}
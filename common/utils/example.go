@@ -0,0 +1,380 @@
+/*
+ * Copyright 2024 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package utils
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/hertz-contrib/swagger-generate/common/consts"
+	"gopkg.in/yaml.v3"
+)
+
+// OperationExamples holds the examples of an operation read from the examples file,
+// they replace the synthesized examples of the operation.
+type OperationExamples struct {
+	// RequestBody is the example of every media type of the request body.
+	RequestBody *yaml.Node
+	// Parameters are the examples of the parameters by name.
+	Parameters map[string]*yaml.Node
+	// Responses are the examples of the responses by status code.
+	Responses map[string]*yaml.Node
+}
+
+// exampleMaxDepth limits the nesting of the synthesized examples.
+const exampleMaxDepth = 8
+
+// exampleStrings are the string examples by format.
+var exampleStrings = map[string]string{
+	"date-time":  "2024-01-01T00:00:00Z",
+	"date":       "2024-01-01",
+	"time":       "00:00:00",
+	"email":      "user@example.com",
+	"uuid":       "3fa85f64-5717-4562-b3fc-2c963f66afa6",
+	"uri":        "https://example.com",
+	"url":        "https://example.com",
+	"hostname":   "example.com",
+	"ipv4":       "127.0.0.1",
+	"ipv6":       "::1",
+	"byte":       "c3RyaW5n",
+	"bytes":      "c3RyaW5n",
+	"int64":      "0",
+	"uint64":     "0",
+	"field-mask": "field",
+	"duration":   "1s",
+}
+
+// LoadOperationExamples reads the examples file, a YAML map of operation IDs to their examples.
+func LoadOperationExamples(file string) (map[string]*OperationExamples, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read examples file: %s", err)
+	}
+	var document yaml.Node
+	if err = yaml.Unmarshal(data, &document); err != nil {
+		return nil, fmt.Errorf("failed to parse examples file %s: %s", file, err)
+	}
+	examples := make(map[string]*OperationExamples)
+	if len(document.Content) == 0 {
+		return examples, nil
+	}
+	if document.Content[0].Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("examples file %s is not a map of operation IDs", file)
+	}
+	forEachMapping(document.Content[0], func(id string, operation *yaml.Node) {
+		examples[id] = &OperationExamples{
+			RequestBody: mappingValue(operation, "requestBody"),
+			Parameters:  mappingValues(mappingValue(operation, "parameters")),
+			Responses:   mappingValues(mappingValue(operation, "responses")),
+		}
+	})
+	return examples, nil
+}
+
+// AddExamples attaches examples to the request bodies, parameters and responses of a document.
+// The examples of the examples file come first, then the existing ones, and the others are
+// synthesized from the schemas when synthesize is set.
+// It returns an error listing the operations of the examples file that are not in the document.
+func AddExamples(document *yaml.Node, overrides map[string]*OperationExamples, synthesize bool) error {
+	s := &exampleSynthesizer{
+		schemas:    make(map[string]*yaml.Node),
+		refs:       make(map[string]bool),
+		synthesize: synthesize,
+	}
+	schemas := mappingValue(mappingValue(document, "components"), "schemas")
+	forEachMapping(schemas, func(name string, schema *yaml.Node) {
+		s.schemas[name] = schema
+	})
+
+	used := make(map[string]bool)
+	forEachMapping(mappingValue(document, "paths"), func(_ string, pathItem *yaml.Node) {
+		s.addParameterExamples(mappingValue(pathItem, "parameters"), nil)
+		forEachMapping(pathItem, func(method string, operation *yaml.Node) {
+			if !isOperationKey(method) {
+				return
+			}
+			override := &OperationExamples{}
+			if id := mappingValue(operation, "operationId"); id != nil && overrides[id.Value] != nil {
+				override = overrides[id.Value]
+				used[id.Value] = true
+			}
+			s.addParameterExamples(mappingValue(operation, "parameters"), override.Parameters)
+			s.addContentExamples(mappingValue(mappingValue(operation, "requestBody"), "content"), override.RequestBody)
+			forEachMapping(mappingValue(operation, "responses"), func(code string, response *yaml.Node) {
+				s.addContentExamples(mappingValue(response, "content"), override.Responses[code])
+				forEachMapping(mappingValue(response, "headers"), func(_ string, header *yaml.Node) {
+					s.addExample(header, nil)
+				})
+			})
+		})
+	})
+
+	var unknown []string
+	for id := range overrides {
+		if !used[id] {
+			unknown = append(unknown, id)
+		}
+	}
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+		return fmt.Errorf("unknown operations in examples file: %s", strings.Join(unknown, ", "))
+	}
+	return nil
+}
+
+// MarshalDocument serializes a YAML document node with a head comment.
+func MarshalDocument(document *yaml.Node, comment string) ([]byte, error) {
+	return yaml.Marshal(&yaml.Node{
+		Kind:        yaml.DocumentNode,
+		Content:     []*yaml.Node{document},
+		HeadComment: comment,
+	})
+}
+
+type exampleSynthesizer struct {
+	schemas map[string]*yaml.Node
+	// refs are the schemas being expanded, to stop recursive schemas.
+	refs       map[string]bool
+	synthesize bool
+}
+
+func (s *exampleSynthesizer) addParameterExamples(parameters *yaml.Node, overrides map[string]*yaml.Node) {
+	if parameters == nil || parameters.Kind != yaml.SequenceNode {
+		return
+	}
+	for _, parameter := range parameters.Content {
+		var override *yaml.Node
+		if name := mappingValue(parameter, "name"); name != nil {
+			override = overrides[name.Value]
+		}
+		s.addExample(parameter, override)
+	}
+}
+
+func (s *exampleSynthesizer) addContentExamples(content, override *yaml.Node) {
+	forEachMapping(content, func(_ string, mediaType *yaml.Node) {
+		s.addExample(mediaType, override)
+	})
+}
+
+// addExample sets the example of a parameter, header or media type, which is
+// synthesized from its schema if it has no example and none is overridden.
+func (s *exampleSynthesizer) addExample(node, override *yaml.Node) {
+	if node == nil || node.Kind != yaml.MappingNode || mappingValue(node, "$ref") != nil {
+		return
+	}
+	if override != nil {
+		deleteMappingValue(node, "examples")
+		setMappingValue(node, "example", copyNode(override))
+		return
+	}
+	if !s.synthesize || mappingValue(node, "example") != nil || mappingValue(node, "examples") != nil {
+		return
+	}
+	example := s.example(mappingValue(node, "schema"), 0)
+	if example == nil || (example.Kind == yaml.MappingNode && len(example.Content) == 0) {
+		return
+	}
+	setMappingValue(node, "example", example)
+}
+
+// example synthesizes an example of a schema, or returns nil if there is none,
+// e.g. for recursive schemas and binary strings.
+func (s *exampleSynthesizer) example(schema *yaml.Node, depth int) *yaml.Node {
+	if schema == nil || schema.Kind != yaml.MappingNode || depth > exampleMaxDepth {
+		return nil
+	}
+	if ref := mappingValue(schema, "$ref"); ref != nil {
+		name := strings.TrimPrefix(ref.Value, consts.ComponentSchemaPrefix)
+		if s.refs[name] {
+			return nil
+		}
+		s.refs[name] = true
+		defer delete(s.refs, name)
+		return s.example(s.schemas[name], depth+1)
+	}
+	for _, key := range []string{"example", "default"} {
+		if value := mappingValue(schema, key); value != nil {
+			return copyNode(value)
+		}
+	}
+	if enum := mappingValue(schema, "enum"); enum != nil && enum.Kind == yaml.SequenceNode && len(enum.Content) > 0 {
+		return copyNode(enum.Content[0])
+	}
+	if allOf := mappingValue(schema, "allOf"); allOf != nil {
+		return s.allOfExample(allOf, depth)
+	}
+	for _, key := range []string{"oneOf", "anyOf"} {
+		if schemas := mappingValue(schema, key); schemas != nil {
+			for _, item := range schemas.Content {
+				if example := s.example(item, depth+1); example != nil {
+					return example
+				}
+			}
+			return nil
+		}
+	}
+
+	var schemaType, format string
+	if value := mappingValue(schema, "type"); value != nil {
+		schemaType = value.Value
+	}
+	if value := mappingValue(schema, "format"); value != nil {
+		format = value.Value
+	}
+	switch schemaType {
+	case consts.SchemaObjectType, "":
+		if schemaType == "" && mappingValue(schema, "properties") == nil {
+			return nil
+		}
+		example := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+		forEachMapping(mappingValue(schema, "properties"), func(name string, property *yaml.Node) {
+			if value := s.example(property, depth+1); value != nil {
+				example.Content = append(example.Content, stringNode(name), value)
+			}
+		})
+		if value := s.example(mappingValue(schema, "additionalProperties"), depth+1); value != nil {
+			example.Content = append(example.Content, stringNode("key"), value)
+		}
+		return example
+	case "array":
+		example := &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq"}
+		if value := s.example(mappingValue(schema, "items"), depth+1); value != nil {
+			example.Content = append(example.Content, value)
+		}
+		return example
+	case "string":
+		if format == "binary" {
+			return nil
+		}
+		if value, ok := exampleStrings[format]; ok {
+			return stringNode(value)
+		}
+		return stringNode("string")
+	case "integer", "number":
+		if minimum := mappingValue(schema, "minimum"); minimum != nil {
+			return copyNode(minimum)
+		}
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!int", Value: "0"}
+	case "boolean":
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!bool", Value: "true"}
+	}
+	return nil
+}
+
+// allOfExample merges the examples of the schemas of an `allOf`.
+func (s *exampleSynthesizer) allOfExample(allOf *yaml.Node, depth int) *yaml.Node {
+	var example *yaml.Node
+	for _, item := range allOf.Content {
+		value := s.example(item, depth+1)
+		switch {
+		case value == nil:
+		case example == nil:
+			example = value
+		case example.Kind == yaml.MappingNode && value.Kind == yaml.MappingNode:
+			forEachMapping(value, func(name string, v *yaml.Node) {
+				if mappingValue(example, name) == nil {
+					example.Content = append(example.Content, stringNode(name), v)
+				}
+			})
+		}
+	}
+	return example
+}
+
+// isOperationKey reports whether a key of a path item is an operation.
+func isOperationKey(key string) bool {
+	switch key {
+	case "get", "put", "post", "delete", "options", "head", "patch", "trace":
+		return true
+	}
+	return false
+}
+
+// mappingValue returns the value of a key of a mapping node, or nil if it has none.
+func mappingValue(node *yaml.Node, key string) *yaml.Node {
+	if node == nil || node.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+	return nil
+}
+
+// setMappingValue sets the value of a key of a mapping node, appending the key if it is missing.
+func setMappingValue(node *yaml.Node, key string, value *yaml.Node) {
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			node.Content[i+1] = value
+			return
+		}
+	}
+	node.Content = append(node.Content, stringNode(key), value)
+}
+
+// deleteMappingValue removes a key of a mapping node.
+func deleteMappingValue(node *yaml.Node, key string) {
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			node.Content = append(node.Content[:i], node.Content[i+2:]...)
+			return
+		}
+	}
+}
+
+// mappingValues returns the values of a mapping node by key.
+func mappingValues(node *yaml.Node) map[string]*yaml.Node {
+	values := make(map[string]*yaml.Node)
+	forEachMapping(node, func(key string, value *yaml.Node) {
+		values[key] = value
+	})
+	return values
+}
+
+// forEachMapping calls f for each key and value of a mapping node in order.
+func forEachMapping(node *yaml.Node, f func(key string, value *yaml.Node)) {
+	if node == nil || node.Kind != yaml.MappingNode {
+		return
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		f(node.Content[i].Value, node.Content[i+1])
+	}
+}
+
+func stringNode(value string) *yaml.Node {
+	return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: value}
+}
+
+// copyNode returns a deep copy of a node in block style, without its comments and position.
+func copyNode(node *yaml.Node) *yaml.Node {
+	if node.Kind == yaml.DocumentNode && len(node.Content) == 1 {
+		return copyNode(node.Content[0])
+	}
+	c := &yaml.Node{Kind: node.Kind, Style: node.Style &^ yaml.FlowStyle, Tag: node.Tag, Value: node.Value}
+	if node.Kind == yaml.AliasNode && node.Alias != nil {
+		return copyNode(node.Alias)
+	}
+	for _, child := range node.Content {
+		c.Content = append(c.Content, copyNode(child))
+	}
+	return c
+}
@@ -0,0 +1,232 @@
+/*
+ * Copyright 2024 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package utils
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+// exampleSchemas are the components of the documents of the tests.
+const exampleSchemas = `
+components:
+  schemas:
+    Node:
+      type: object
+      properties:
+        name: {type: string}
+        next: {$ref: '#/components/schemas/Node'}
+    A:
+      type: object
+      properties:
+        b: {$ref: '#/components/schemas/B'}
+    B:
+      type: object
+      properties:
+        id: {type: integer}
+        a: {$ref: '#/components/schemas/A'}
+`
+
+// operationDocument returns a document with the components and a `GET /pets` operation.
+func operationDocument(t *testing.T, operation string) *yaml.Node {
+	t.Helper()
+	var document yaml.Node
+	if err := yaml.Unmarshal([]byte("paths:\n  /pets:\n    get:\n"+operation+exampleSchemas), &document); err != nil {
+		t.Fatalf("failed to parse document: %s", err)
+	}
+	return document.Content[0]
+}
+
+// nodeAt returns the value at the keys of nested mapping nodes.
+func nodeAt(node *yaml.Node, keys ...string) *yaml.Node {
+	for _, key := range keys {
+		node = mappingValue(node, key)
+	}
+	return node
+}
+
+// decodeExample decodes an example, or returns nil if there is none.
+func decodeExample(t *testing.T, node *yaml.Node) interface{} {
+	t.Helper()
+	if node == nil {
+		return nil
+	}
+	var v interface{}
+	if err := node.Decode(&v); err != nil {
+		t.Fatalf("failed to decode example: %s", err)
+	}
+	return v
+}
+
+func decodeYAML(t *testing.T, s string) interface{} {
+	t.Helper()
+	var v interface{}
+	if err := yaml.Unmarshal([]byte(s), &v); err != nil {
+		t.Fatalf("failed to parse %q: %s", s, err)
+	}
+	return v
+}
+
+func TestAddExamplesSynthesized(t *testing.T) {
+	tests := []struct {
+		name   string
+		schema string
+		// want is the example in YAML, empty when there is none.
+		want string
+	}{
+		{"string", `{type: string}`, `string`},
+		{"format", `{type: string, format: date-time}`, `"2024-01-01T00:00:00Z"`},
+		{"int64 as string", `{type: string, format: int64}`, `"0"`},
+		{"binary has none", `{type: string, format: binary}`, ``},
+		{"integer", `{type: integer}`, `0`},
+		{"minimum", `{type: number, minimum: 1.5}`, `1.5`},
+		{"boolean", `{type: boolean}`, `true`},
+		{"enum", `{type: string, enum: [cat, dog]}`, `cat`},
+		{"explicit example", `{type: integer, example: 7, default: 3}`, `7`},
+		{"default", `{type: integer, default: 3}`, `3`},
+		{"array", `{type: array, items: {type: integer}}`, `[0]`},
+		{"map", `{type: object, additionalProperties: {type: boolean}}`, `{key: true}`},
+		{"recursive", `{$ref: '#/components/schemas/Node'}`, `{name: string}`},
+		{"cyclic", `{$ref: '#/components/schemas/A'}`, `{b: {id: 0}}`},
+		{"oneOf first with an example", `{oneOf: [{type: string, format: binary}, {type: integer}]}`, `0`},
+		{"allOf merged", `{allOf: [{properties: {id: {type: integer}}}, {properties: {id: {type: string}, name: {type: string}}}]}`, `{id: 0, name: string}`},
+		{"empty object has none", `{type: object}`, ``},
+		{"untyped has none", `{description: anything}`, ``},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			document := operationDocument(t, "      responses:\n        '200':\n          content:\n            application/json:\n              schema: "+tt.schema+"\n")
+			if err := AddExamples(document, nil, true); err != nil {
+				t.Fatalf("AddExamples() error = %s", err)
+			}
+			mediaType := nodeAt(document, "paths", "/pets", "get", "responses", "200", "content", "application/json")
+			var want interface{}
+			if tt.want != "" {
+				want = decodeYAML(t, tt.want)
+			}
+			if got := decodeExample(t, mappingValue(mediaType, "example")); !reflect.DeepEqual(got, want) {
+				t.Errorf("example = %#v, want %#v", got, want)
+			}
+		})
+	}
+}
+
+func TestAddExamplesOverridden(t *testing.T) {
+	document := operationDocument(t, `      operationId: ListPets
+      parameters:
+        - {name: limit, in: query, schema: {type: integer}}
+        - {name: tag, in: query, schema: {type: string}, example: cat}
+        - {name: kind, in: query, schema: {type: string}}
+      requestBody:
+        content:
+          application/json: {schema: {type: string}, examples: {a: {value: a}}}
+      responses:
+        '200':
+          content:
+            application/json: {schema: {type: string}}
+          headers:
+            X-Total: {schema: {type: integer}}
+`)
+	overrides := map[string]*OperationExamples{
+		"ListPets": {
+			RequestBody: &yaml.Node{Kind: yaml.ScalarNode, Value: "body"},
+			Parameters:  map[string]*yaml.Node{"limit": {Kind: yaml.ScalarNode, Tag: "!!int", Value: "10"}},
+			Responses:   map[string]*yaml.Node{"200": {Kind: yaml.ScalarNode, Value: "pets"}},
+		},
+	}
+	if err := AddExamples(document, overrides, false); err != nil {
+		t.Fatalf("AddExamples() error = %s", err)
+	}
+	operation := nodeAt(document, "paths", "/pets", "get")
+	parameters := mappingValue(operation, "parameters").Content
+	requestBody := nodeAt(operation, "requestBody", "content", "application/json")
+	tests := []struct {
+		name string
+		node *yaml.Node
+		want interface{}
+	}{
+		{"overridden parameter", parameters[0], 10},
+		{"explicit parameter example kept", parameters[1], "cat"},
+		// The examples are not synthesized, only the examples file and the IDL add them.
+		{"parameter not synthesized", parameters[2], nil},
+		{"overridden request body", requestBody, "body"},
+		{"overridden response", nodeAt(operation, "responses", "200", "content", "application/json"), "pets"},
+		{"header not synthesized", nodeAt(operation, "responses", "200", "headers", "X-Total"), nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := decodeExample(t, mappingValue(tt.node, "example")); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("example = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+	if mappingValue(requestBody, "examples") != nil {
+		t.Errorf("the examples of the overridden request body are kept")
+	}
+}
+
+func TestAddExamplesUnknownOperations(t *testing.T) {
+	document := operationDocument(t, "      operationId: ListPets\n")
+	overrides := map[string]*OperationExamples{"ListPets": {}, "GetPet": {}, "CreatePet": {}}
+	err := AddExamples(document, overrides, true)
+	if err == nil || err.Error() != "unknown operations in examples file: CreatePet, GetPet" {
+		t.Errorf("AddExamples() error = %v, want the unknown operations", err)
+	}
+}
+
+func TestLoadOperationExamples(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+
+	examples, err := LoadOperationExamples(write("examples.yaml", `
+ListPets:
+  parameters: {limit: 10}
+  requestBody: {name: cat}
+  responses: {'200': [cat]}
+`))
+	if err != nil {
+		t.Fatalf("LoadOperationExamples() error = %s", err)
+	}
+	e := examples["ListPets"]
+	if e == nil || decodeExample(t, e.Parameters["limit"]) != 10 || decodeExample(t, e.Responses["200"]) == nil ||
+		!reflect.DeepEqual(decodeExample(t, e.RequestBody), map[string]interface{}{"name": "cat"}) {
+		t.Errorf("LoadOperationExamples() = %+v", e)
+	}
+
+	for name, content := range map[string]string{
+		"list.yaml":    "- ListPets\n",
+		"invalid.yaml": "ListPets: [\n",
+	} {
+		if _, err = LoadOperationExamples(write(name, content)); err == nil || !strings.Contains(err.Error(), name) {
+			t.Errorf("LoadOperationExamples(%s) error = %v, want an error", name, err)
+		}
+	}
+	if _, err = LoadOperationExamples(filepath.Join(dir, "missing.yaml")); err == nil {
+		t.Errorf("LoadOperationExamples() of a missing file error = nil, want an error")
+	}
+}
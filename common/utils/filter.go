@@ -0,0 +1,145 @@
+/*
+ * Copyright 2024 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package utils
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/hertz-contrib/swagger-generate/common/consts"
+	"gopkg.in/yaml.v3"
+)
+
+// Filter selects the methods and fields documented, by globs of the `Service.Method` names of the methods
+// and by the visibility of the services, methods and fields.
+type Filter struct {
+	include    []string
+	exclude    []string
+	visibility string
+}
+
+// NewFilter returns a filter of the include and exclude globs of `Service.Method` names, e.g. `User*.Get*`,
+// and of a visibility: public documents only the public elements, internal documents all of them.
+func NewFilter(include, exclude []string, visibility string) (*Filter, error) {
+	for _, pattern := range append(append([]string{}, include...), exclude...) {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return nil, fmt.Errorf("invalid glob %s: %s", pattern, err)
+		}
+	}
+	switch visibility {
+	case "", consts.VisibilityPublic, consts.VisibilityInternal:
+	default:
+		return nil, fmt.Errorf("unknown visibility %s, expected %s or %s", visibility, consts.VisibilityPublic, consts.VisibilityInternal)
+	}
+	return &Filter{include: include, exclude: exclude, visibility: visibility}, nil
+}
+
+// Active reports whether the filter can leave elements out of the document.
+func (f *Filter) Active() bool {
+	return len(f.include) > 0 || len(f.exclude) > 0 || f.visibility == consts.VisibilityPublic
+}
+
+// IncludesMethod reports whether a method is selected by the include and exclude globs.
+func (f *Filter) IncludesMethod(service, method string) bool {
+	name := service + "." + method
+	if len(f.include) > 0 && !matchAny(f.include, name) {
+		return false
+	}
+	return !matchAny(f.exclude, name)
+}
+
+// Visible reports whether an element of a visibility is documented, the elements without visibility being public.
+// It returns an error for the visibilities other than public and internal, whose elements are documented.
+func (f *Filter) Visible(visibility string) (bool, error) {
+	switch visibility {
+	case "", consts.VisibilityPublic:
+		return true, nil
+	case consts.VisibilityInternal:
+		return f.visibility != consts.VisibilityPublic, nil
+	default:
+		return true, fmt.Errorf("unknown visibility %s, expected %s or %s", visibility, consts.VisibilityPublic, consts.VisibilityInternal)
+	}
+}
+
+func matchAny(patterns []string, name string) bool {
+	for _, pattern := range patterns {
+		if ok, _ := path.Match(pattern, name); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// PruneSchemas removes the schemas of the components that are not referenced by the rest of the document,
+// directly or through other schemas, e.g. the schemas of the methods and fields left out by a filter.
+func PruneSchemas(document *yaml.Node) {
+	if document.Kind == yaml.DocumentNode && len(document.Content) == 1 {
+		document = document.Content[0]
+	}
+	components := mappingValue(document, "components")
+	schemas := mappingValue(components, "schemas")
+	if schemas == nil {
+		return
+	}
+
+	referenced := make(map[string]bool)
+	var pending []string
+	var collect func(node *yaml.Node)
+	collect = func(node *yaml.Node) {
+		if node == nil {
+			return
+		}
+		if ref := stringValue(node, "$ref"); strings.HasPrefix(ref, consts.ComponentSchemaPrefix) {
+			name := strings.NewReplacer("~1", "/", "~0", "~").Replace(strings.TrimPrefix(ref, consts.ComponentSchemaPrefix))
+			if !referenced[name] {
+				referenced[name] = true
+				pending = append(pending, name)
+			}
+		}
+		for _, child := range node.Content {
+			collect(child)
+		}
+	}
+	forEachMapping(document, func(key string, value *yaml.Node) {
+		if key != "components" {
+			collect(value)
+		}
+	})
+	forEachMapping(components, func(key string, value *yaml.Node) {
+		if key != "schemas" {
+			collect(value)
+		}
+	})
+	for len(pending) > 0 {
+		name := pending[0]
+		pending = pending[1:]
+		collect(mappingValue(schemas, name))
+	}
+
+	for name := range mappingValues(schemas) {
+		if !referenced[name] {
+			deleteMappingValue(schemas, name)
+		}
+	}
+	if len(schemas.Content) == 0 {
+		deleteMappingValue(components, "schemas")
+	}
+	if len(components.Content) == 0 {
+		deleteMappingValue(document, "components")
+	}
+}
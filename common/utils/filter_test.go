@@ -0,0 +1,129 @@
+/*
+ * Copyright 2024 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package utils
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/hertz-contrib/swagger-generate/common/consts"
+	"gopkg.in/yaml.v3"
+)
+
+func TestFilter(t *testing.T) {
+	f, err := NewFilter([]string{"Pet*.*"}, []string{"*.Delete*", "PetAdmin.*"}, consts.VisibilityPublic)
+	if err != nil {
+		t.Fatalf("NewFilter() error = %s", err)
+	}
+	tests := []struct {
+		service, method string
+		want            bool
+	}{
+		{"PetService", "GetPet", true},
+		{"PetService", "DeletePet", false},
+		{"PetAdmin", "GetPet", false},
+		{"UserService", "GetUser", false},
+	}
+	for _, tt := range tests {
+		if got := f.IncludesMethod(tt.service, tt.method); got != tt.want {
+			t.Errorf("IncludesMethod(%s, %s) = %v, want %v", tt.service, tt.method, got, tt.want)
+		}
+	}
+
+	for visibility, want := range map[string]bool{"": true, consts.VisibilityPublic: true, consts.VisibilityInternal: false} {
+		if got, err := f.Visible(visibility); got != want || err != nil {
+			t.Errorf("Visible(%q) = %v, %v, want %v", visibility, got, err, want)
+		}
+	}
+	if got, err := f.Visible("private"); !got || err == nil {
+		t.Errorf("Visible(private) = %v, %v, want true and an error", got, err)
+	}
+	internal, err := NewFilter(nil, nil, consts.VisibilityInternal)
+	if err != nil {
+		t.Fatalf("NewFilter() error = %s", err)
+	}
+	if visible, _ := internal.Visible(consts.VisibilityInternal); !visible || internal.Active() || !f.Active() {
+		t.Errorf("the internal filter hides internal elements or is active")
+	}
+
+	if _, err = NewFilter([]string{"Pet["}, nil, ""); err == nil {
+		t.Errorf("NewFilter() error = nil, want an error for an invalid glob")
+	}
+	if _, err = NewFilter(nil, nil, "private"); err == nil {
+		t.Errorf("NewFilter() error = nil, want an error for an unknown visibility")
+	}
+}
+
+func TestPruneSchemas(t *testing.T) {
+	tests := []struct {
+		name     string
+		document string
+		// want are the schemas kept, nil when the components are removed.
+		want []string
+	}{
+		{
+			name: "transitive references kept",
+			document: `paths: {/pets: {get: {responses: {'200': {content: {application/json: {schema: {$ref: '#/components/schemas/Pets'}}}}}}}}
+components:
+  schemas:
+    Pets: {type: array, items: {$ref: '#/components/schemas/Pet'}}
+    Pet: {allOf: [{$ref: '#/components/schemas/Animal'}, {properties: {owner: {$ref: '#/components/schemas/Owner'}}}]}
+    Animal: {type: object}
+    Owner: {type: object, additionalProperties: {$ref: '#/components/schemas/a~1b'}}
+    a/b: {type: string}
+    User: {type: object}`,
+			want: []string{"Pets", "Pet", "Animal", "Owner", "a/b"},
+		},
+		{
+			name: "unreferenced removed transitively",
+			document: `paths: {}
+components:
+  schemas:
+    User: {type: object, properties: {pet: {$ref: '#/components/schemas/Pet'}}}
+    Pet: {type: object, properties: {user: {$ref: '#/components/schemas/User'}}}`,
+		},
+		{
+			name: "referenced by other components",
+			document: `components:
+  schemas:
+    Error: {type: object}
+    Filter: {type: object}
+  responses: {Error: {content: {application/json: {schema: {$ref: '#/components/schemas/Error'}}}}}`,
+			want: []string{"Error"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var document yaml.Node
+			if err := yaml.Unmarshal([]byte(tt.document), &document); err != nil {
+				t.Fatalf("failed to parse document: %s", err)
+			}
+			PruneSchemas(&document)
+			components := mappingValue(document.Content[0], "components")
+			var got []string
+			forEachMapping(mappingValue(components, "schemas"), func(name string, _ *yaml.Node) {
+				got = append(got, name)
+			})
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("PruneSchemas() kept %q, want %q", got, tt.want)
+			}
+			if tt.want == nil && components != nil && mappingValue(components, "responses") == nil {
+				t.Errorf("PruneSchemas() kept the empty components")
+			}
+		})
+	}
+}
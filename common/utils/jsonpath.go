@@ -0,0 +1,575 @@
+/*
+ * Copyright 2024 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package utils
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// JSONPath is a parsed JSONPath expression, e.g. `$.paths['/pets'].get` or `$..parameters[?(@.in == 'query')]`.
+// The supported syntax is the root `$`, child names `.name` and `['name']`, indexes `[0]` and `[-1]`,
+// wildcards `*`, slices `[1:3]`, descendants `..` and filters `[?(...)]` comparing the relative
+// paths `@.name` to literals with `==`, `!=`, `<`, `<=`, `>` and `>=`, combined with `&&`, `||` and `!`.
+type JSONPath struct {
+	segments []*pathSegment
+}
+
+// PathMatch is a node selected by a JSONPath, with its parent to remove it.
+type PathMatch struct {
+	Node *yaml.Node
+	// Parent is the mapping or sequence that contains the node, nil for the root.
+	Parent *yaml.Node
+}
+
+type pathSegment struct {
+	descendant bool
+	selectors  []*pathSelector
+}
+
+type selectorKind int
+
+const (
+	selectName selectorKind = iota
+	selectWildcard
+	selectIndex
+	selectSlice
+	selectFilter
+)
+
+type pathSelector struct {
+	kind       selectorKind
+	name       string
+	index      int
+	start, end *int
+	filter     filterExpr
+}
+
+// ParseJSONPath parses a JSONPath expression.
+func ParseJSONPath(expression string) (*JSONPath, error) {
+	p := &pathParser{s: strings.TrimSpace(expression)}
+	if !p.consume("$") {
+		return nil, fmt.Errorf("invalid JSONPath %s: it must start with $", expression)
+	}
+	segments, err := p.segments(false)
+	if err == nil && !p.done() {
+		err = p.errorf("unexpected %q", p.s[p.i:])
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid JSONPath %s: %s", expression, err)
+	}
+	return &JSONPath{segments: segments}, nil
+}
+
+// Select returns the nodes of a document selected by the path, in document order.
+func (p *JSONPath) Select(document *yaml.Node) []*PathMatch {
+	if document != nil && document.Kind == yaml.DocumentNode && len(document.Content) == 1 {
+		document = document.Content[0]
+	}
+	return selectSegments([]*PathMatch{{Node: document}}, p.segments, document)
+}
+
+func selectSegments(matches []*PathMatch, segments []*pathSegment, root *yaml.Node) []*PathMatch {
+	for _, segment := range segments {
+		var next []*PathMatch
+		for _, m := range matches {
+			candidates := []*PathMatch{m}
+			if segment.descendant {
+				candidates = descendants(m)
+			}
+			for _, c := range candidates {
+				for _, selector := range segment.selectors {
+					next = append(next, selector.apply(c.Node, root)...)
+				}
+			}
+		}
+		matches = next
+	}
+	return matches
+}
+
+// descendants returns a node and all the nodes in it.
+func descendants(m *PathMatch) []*PathMatch {
+	matches := []*PathMatch{m}
+	for _, child := range children(m.Node) {
+		matches = append(matches, descendants(child)...)
+	}
+	return matches
+}
+
+// children returns the values of a mapping or the elements of a sequence.
+func children(node *yaml.Node) []*PathMatch {
+	var matches []*PathMatch
+	switch node.Kind {
+	case yaml.MappingNode:
+		for i := 1; i < len(node.Content); i += 2 {
+			matches = append(matches, &PathMatch{Node: node.Content[i], Parent: node})
+		}
+	case yaml.SequenceNode:
+		for _, element := range node.Content {
+			matches = append(matches, &PathMatch{Node: element, Parent: node})
+		}
+	}
+	return matches
+}
+
+func (s *pathSelector) apply(node, root *yaml.Node) []*PathMatch {
+	switch s.kind {
+	case selectName:
+		if value := mappingValue(node, s.name); value != nil {
+			return []*PathMatch{{Node: value, Parent: node}}
+		}
+	case selectWildcard:
+		return children(node)
+	case selectIndex:
+		if node.Kind == yaml.SequenceNode {
+			index := s.index
+			if index < 0 {
+				index += len(node.Content)
+			}
+			if index >= 0 && index < len(node.Content) {
+				return []*PathMatch{{Node: node.Content[index], Parent: node}}
+			}
+		}
+	case selectSlice:
+		if node.Kind == yaml.SequenceNode {
+			start, end := sliceBound(s.start, 0, len(node.Content)), sliceBound(s.end, len(node.Content), len(node.Content))
+			var matches []*PathMatch
+			for i := start; i < end; i++ {
+				matches = append(matches, &PathMatch{Node: node.Content[i], Parent: node})
+			}
+			return matches
+		}
+	case selectFilter:
+		var matches []*PathMatch
+		for _, child := range children(node) {
+			if s.filter.test(child.Node, root) {
+				matches = append(matches, child)
+			}
+		}
+		return matches
+	}
+	return nil
+}
+
+func sliceBound(bound *int, defaultValue, length int) int {
+	if bound == nil {
+		return defaultValue
+	}
+	b := *bound
+	if b < 0 {
+		b += length
+	}
+	if b < 0 {
+		return 0
+	}
+	if b > length {
+		return length
+	}
+	return b
+}
+
+// filterExpr is an expression of a filter selector, tested on the children of the selected nodes.
+type filterExpr interface {
+	test(current, root *yaml.Node) bool
+}
+
+type orExpr struct{ left, right filterExpr }
+
+func (e *orExpr) test(current, root *yaml.Node) bool {
+	return e.left.test(current, root) || e.right.test(current, root)
+}
+
+type andExpr struct{ left, right filterExpr }
+
+func (e *andExpr) test(current, root *yaml.Node) bool {
+	return e.left.test(current, root) && e.right.test(current, root)
+}
+
+type notExpr struct{ expr filterExpr }
+
+func (e *notExpr) test(current, root *yaml.Node) bool {
+	return !e.expr.test(current, root)
+}
+
+// existsExpr tests that a relative or absolute path selects a node.
+type existsExpr struct{ operand *filterOperand }
+
+func (e *existsExpr) test(current, root *yaml.Node) bool {
+	return e.operand.value(current, root) != nil
+}
+
+type compareExpr struct {
+	op          string
+	left, right *filterOperand
+}
+
+func (e *compareExpr) test(current, root *yaml.Node) bool {
+	left, right := e.left.value(current, root), e.right.value(current, root)
+	if left == nil || right == nil {
+		// A missing value only equals another missing value.
+		switch e.op {
+		case "==", "<=", ">=":
+			return left == nil && right == nil
+		case "!=":
+			return (left == nil) != (right == nil)
+		}
+		return false
+	}
+	if left.Kind != yaml.ScalarNode || right.Kind != yaml.ScalarNode {
+		return false
+	}
+	c, comparable := compareScalars(left, right)
+	switch e.op {
+	case "==":
+		return comparable && c == 0
+	case "!=":
+		return !comparable || c != 0
+	case "<":
+		return comparable && c < 0
+	case "<=":
+		return comparable && c <= 0
+	case ">":
+		return comparable && c > 0
+	case ">=":
+		return comparable && c >= 0
+	}
+	return false
+}
+
+// compareScalars compares two scalars as numbers if both are numbers, and as strings otherwise.
+func compareScalars(a, b *yaml.Node) (int, bool) {
+	if isNumberNode(a) && isNumberNode(b) {
+		x, _ := strconv.ParseFloat(a.Value, 64)
+		y, _ := strconv.ParseFloat(b.Value, 64)
+		switch {
+		case x < y:
+			return -1, true
+		case x > y:
+			return 1, true
+		}
+		return 0, true
+	}
+	if isNumberNode(a) != isNumberNode(b) || a.Tag == "!!bool" != (b.Tag == "!!bool") || a.Tag == "!!null" != (b.Tag == "!!null") {
+		return 0, false
+	}
+	return strings.Compare(a.Value, b.Value), true
+}
+
+func isNumberNode(node *yaml.Node) bool {
+	return node.Tag == "!!int" || node.Tag == "!!float"
+}
+
+// filterOperand is a literal, or a singular path relative to the current node `@` or to the root `$`.
+type filterOperand struct {
+	literal  *yaml.Node
+	relative bool
+	segments []*pathSegment
+}
+
+func (o *filterOperand) value(current, root *yaml.Node) *yaml.Node {
+	if o.literal != nil {
+		return o.literal
+	}
+	start := root
+	if o.relative {
+		start = current
+	}
+	if matches := selectSegments([]*PathMatch{{Node: start}}, o.segments, root); len(matches) > 0 {
+		return matches[0].Node
+	}
+	return nil
+}
+
+type pathParser struct {
+	s string
+	i int
+}
+
+func (p *pathParser) errorf(format string, a ...interface{}) error {
+	return fmt.Errorf("at offset %d: %s", p.i, fmt.Sprintf(format, a...))
+}
+
+func (p *pathParser) done() bool {
+	return p.i >= len(p.s)
+}
+
+func (p *pathParser) skipSpaces() {
+	for !p.done() && (p.s[p.i] == ' ' || p.s[p.i] == '\t') {
+		p.i++
+	}
+}
+
+func (p *pathParser) peek(prefix string) bool {
+	return strings.HasPrefix(p.s[p.i:], prefix)
+}
+
+func (p *pathParser) consume(prefix string) bool {
+	if p.peek(prefix) {
+		p.i += len(prefix)
+		return true
+	}
+	return false
+}
+
+// segments parses the segments after `$` or `@`, singular ones only in the operands of filters.
+func (p *pathParser) segments(singular bool) ([]*pathSegment, error) {
+	var segments []*pathSegment
+	for !p.done() {
+		var segment *pathSegment
+		var err error
+		switch {
+		case p.consume(".."):
+			if singular {
+				return nil, p.errorf("descendants are not allowed in a filter")
+			}
+			segment, err = p.dotSegment(true)
+		case p.consume("."):
+			segment, err = p.dotSegment(false)
+		case p.peek("["):
+			segment, err = p.bracketSegment(singular)
+		default:
+			return segments, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		segments = append(segments, segment)
+	}
+	return segments, nil
+}
+
+func (p *pathParser) dotSegment(descendant bool) (*pathSegment, error) {
+	if p.peek("[") && descendant {
+		segment, err := p.bracketSegment(false)
+		if err != nil {
+			return nil, err
+		}
+		segment.descendant = true
+		return segment, nil
+	}
+	if p.consume("*") {
+		return &pathSegment{descendant: descendant, selectors: []*pathSelector{{kind: selectWildcard}}}, nil
+	}
+	start := p.i
+	for !p.done() && isNameChar(p.s[p.i]) {
+		p.i++
+	}
+	if start == p.i {
+		return nil, p.errorf("expected a name")
+	}
+	return &pathSegment{descendant: descendant, selectors: []*pathSelector{{kind: selectName, name: p.s[start:p.i]}}}, nil
+}
+
+func isNameChar(c byte) bool {
+	return c == '_' || c == '-' || c == '$' || c >= '0' && c <= '9' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= 0x80
+}
+
+func (p *pathParser) bracketSegment(singular bool) (*pathSegment, error) {
+	p.consume("[")
+	segment := &pathSegment{}
+	for {
+		p.skipSpaces()
+		selector, err := p.selector()
+		if err != nil {
+			return nil, err
+		}
+		if singular && selector.kind != selectName && selector.kind != selectIndex {
+			return nil, p.errorf("only names and indexes are allowed in a filter")
+		}
+		segment.selectors = append(segment.selectors, selector)
+		p.skipSpaces()
+		if p.consume("]") {
+			return segment, nil
+		}
+		if singular || !p.consume(",") {
+			return nil, p.errorf("expected ]")
+		}
+	}
+}
+
+func (p *pathParser) selector() (*pathSelector, error) {
+	switch {
+	case p.peek("'") || p.peek(`"`):
+		name, err := p.quoted()
+		if err != nil {
+			return nil, err
+		}
+		return &pathSelector{kind: selectName, name: name}, nil
+	case p.consume("*"):
+		return &pathSelector{kind: selectWildcard}, nil
+	case p.consume("?"):
+		p.skipSpaces()
+		filter, err := p.or()
+		if err != nil {
+			return nil, err
+		}
+		return &pathSelector{kind: selectFilter, filter: filter}, nil
+	}
+	start, ok := p.integer()
+	p.skipSpaces()
+	if !p.consume(":") {
+		if !ok {
+			return nil, p.errorf("expected a selector")
+		}
+		return &pathSelector{kind: selectIndex, index: *start}, nil
+	}
+	p.skipSpaces()
+	end, _ := p.integer()
+	return &pathSelector{kind: selectSlice, start: start, end: end}, nil
+}
+
+func (p *pathParser) integer() (*int, bool) {
+	start := p.i
+	p.consume("-")
+	for !p.done() && p.s[p.i] >= '0' && p.s[p.i] <= '9' {
+		p.i++
+	}
+	n, err := strconv.Atoi(p.s[start:p.i])
+	if err != nil {
+		p.i = start
+		return nil, false
+	}
+	return &n, true
+}
+
+// quoted parses a single or double quoted string, with backslash escapes.
+func (p *pathParser) quoted() (string, error) {
+	quote := p.s[p.i]
+	p.i++
+	var b strings.Builder
+	for !p.done() {
+		c := p.s[p.i]
+		p.i++
+		switch {
+		case c == quote:
+			return b.String(), nil
+		case c == '\\' && !p.done():
+			b.WriteByte(p.s[p.i])
+			p.i++
+		default:
+			b.WriteByte(c)
+		}
+	}
+	return "", p.errorf("unterminated string")
+}
+
+func (p *pathParser) or() (filterExpr, error) {
+	left, err := p.and()
+	if err != nil {
+		return nil, err
+	}
+	for p.skipSpaces(); p.consume("||"); p.skipSpaces() {
+		right, err := p.and()
+		if err != nil {
+			return nil, err
+		}
+		left = &orExpr{left, right}
+	}
+	return left, nil
+}
+
+func (p *pathParser) and() (filterExpr, error) {
+	left, err := p.unary()
+	if err != nil {
+		return nil, err
+	}
+	for p.skipSpaces(); p.consume("&&"); p.skipSpaces() {
+		right, err := p.unary()
+		if err != nil {
+			return nil, err
+		}
+		left = &andExpr{left, right}
+	}
+	return left, nil
+}
+
+func (p *pathParser) unary() (filterExpr, error) {
+	p.skipSpaces()
+	if p.consume("!") && !p.peek("=") {
+		expr, err := p.unary()
+		if err != nil {
+			return nil, err
+		}
+		return &notExpr{expr}, nil
+	}
+	if p.consume("(") {
+		expr, err := p.or()
+		if err != nil {
+			return nil, err
+		}
+		p.skipSpaces()
+		if !p.consume(")") {
+			return nil, p.errorf("expected )")
+		}
+		return expr, nil
+	}
+	left, err := p.operand()
+	if err != nil {
+		return nil, err
+	}
+	p.skipSpaces()
+	for _, op := range []string{"==", "!=", "<=", ">=", "<", ">"} {
+		if p.consume(op) {
+			p.skipSpaces()
+			right, err := p.operand()
+			if err != nil {
+				return nil, err
+			}
+			return &compareExpr{op: op, left: left, right: right}, nil
+		}
+	}
+	if left.literal != nil {
+		return nil, p.errorf("expected a comparison")
+	}
+	return &existsExpr{left}, nil
+}
+
+func (p *pathParser) operand() (*filterOperand, error) {
+	switch {
+	case p.consume("@"), p.consume("$"):
+		relative := p.s[p.i-1] == '@'
+		segments, err := p.segments(true)
+		if err != nil {
+			return nil, err
+		}
+		return &filterOperand{relative: relative, segments: segments}, nil
+	case p.peek("'") || p.peek(`"`):
+		s, err := p.quoted()
+		if err != nil {
+			return nil, err
+		}
+		return &filterOperand{literal: stringNode(s)}, nil
+	}
+	for _, keyword := range []struct{ value, tag string }{{"true", "!!bool"}, {"false", "!!bool"}, {"null", "!!null"}} {
+		if p.consume(keyword.value) {
+			return &filterOperand{literal: &yaml.Node{Kind: yaml.ScalarNode, Tag: keyword.tag, Value: keyword.value}}, nil
+		}
+	}
+	start := p.i
+	for !p.done() && strings.IndexByte("-+.eE0123456789", p.s[p.i]) >= 0 {
+		p.i++
+	}
+	if _, err := strconv.ParseFloat(p.s[start:p.i], 64); err != nil {
+		p.i = start
+		return nil, p.errorf("expected a path or a literal")
+	}
+	return &filterOperand{literal: &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!float", Value: p.s[start:p.i]}}, nil
+}
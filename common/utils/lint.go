@@ -0,0 +1,292 @@
+/*
+ * Copyright 2024 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package utils
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/hertz-contrib/swagger-generate/common/consts"
+	"gopkg.in/yaml.v3"
+)
+
+// The rules of the linter.
+const (
+	LintOperationDescription = "operation-description"
+	LintPropertyDescription  = "property-description"
+	LintUniqueOperationID    = "unique-operation-id"
+	LintKebabCasePath        = "kebab-case-path"
+	LintScalarQuery          = "scalar-query-parameter"
+	LintDocumentedException  = "documented-exception"
+	LintNoEmptySchema        = "no-empty-schema"
+)
+
+// LintRules are the names of all the rules of the linter.
+var LintRules = []string{
+	LintOperationDescription,
+	LintPropertyDescription,
+	LintUniqueOperationID,
+	LintKebabCasePath,
+	LintScalarQuery,
+	LintDocumentedException,
+	LintNoEmptySchema,
+}
+
+// referenceMaxDepth stops the resolution of references that refer to each other.
+const referenceMaxDepth = 16
+
+var kebabCaseSegment = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+
+// schemaKeywords are the keywords that give a schema a type, a schema with none of them accepts any value.
+var schemaKeywords = []string{"type", "$ref", "allOf", "oneOf", "anyOf", "not", "properties", "items", "additionalProperties", "enum"}
+
+// LintIssue is a violation of a rule of the linter.
+type LintIssue struct {
+	Rule string
+	// Pointer is the JSON pointer of the element, e.g. `#/components/schemas/Pet/properties/name`.
+	Pointer string
+	Message string
+}
+
+func (i *LintIssue) String() string {
+	return fmt.Sprintf("%s [%s]", i.Message, i.Rule)
+}
+
+// CheckLintRules returns an error if one of the rules is unknown.
+func CheckLintRules(rules []string) error {
+	for _, rule := range rules {
+		if !Contains(LintRules, rule) {
+			return fmt.Errorf("unknown lint rule %s, the rules are %s", rule, strings.Join(LintRules, ", "))
+		}
+	}
+	return nil
+}
+
+// LintLocations are the IDL locations of the elements of a document, keyed by JSON pointer.
+type LintLocations map[string]string
+
+// Lookup returns the location of the closest element that contains the pointer, or the default location.
+func (l LintLocations) Lookup(pointer, defaultLocation string) string {
+	for p := pointer; p != ""; {
+		if location, ok := l[p]; ok {
+			return location
+		}
+		i := strings.LastIndex(p, "/")
+		if i < 0 {
+			break
+		}
+		p = p[:i]
+	}
+	return defaultLocation
+}
+
+// SchemaPointer returns the JSON pointer of a schema of the components, or of one of its properties.
+func SchemaPointer(schema string, property ...string) string {
+	pointer := "#/components/schemas/" + escapePointer(schema)
+	for _, p := range property {
+		pointer += "/properties/" + escapePointer(p)
+	}
+	return pointer
+}
+
+// PathPointer returns the JSON pointer of a path item.
+func PathPointer(path string) string {
+	return "#/paths/" + escapePointer(path)
+}
+
+// OperationPointer returns the JSON pointer of an operation.
+func OperationPointer(method, path string) string {
+	return PathPointer(path) + "/" + strings.ToLower(method)
+}
+
+// LintDocument checks the rules of the linter on a document node, except the disabled ones.
+func LintDocument(document *yaml.Node, disabled []string) []*LintIssue {
+	if document != nil && document.Kind == yaml.DocumentNode && len(document.Content) == 1 {
+		document = document.Content[0]
+	}
+	l := &linter{root: document, disabled: disabled, operationIDs: make(map[string]string)}
+	forEachMapping(mappingValue(document, "paths"), func(path string, pathItem *yaml.Node) {
+		l.lintPath(path, pathItem)
+	})
+	forEachMapping(mappingValue(mappingValue(document, "components"), "schemas"), func(name string, schema *yaml.Node) {
+		l.lintSchema(schema, SchemaPointer(name))
+	})
+	return l.issues
+}
+
+type linter struct {
+	root     *yaml.Node
+	disabled []string
+	// operationIDs holds the pointers of the operations by operation ID.
+	operationIDs map[string]string
+	issues       []*LintIssue
+}
+
+func (l *linter) report(rule, pointer, format string, a ...interface{}) {
+	if Contains(l.disabled, rule) {
+		return
+	}
+	l.issues = append(l.issues, &LintIssue{Rule: rule, Pointer: pointer, Message: fmt.Sprintf(format, a...)})
+}
+
+func (l *linter) lintPath(path string, pathItem *yaml.Node) {
+	pointer := PathPointer(path)
+	for _, segment := range strings.Split(path, "/") {
+		// Templates are named after the fields of the IDL, only the literal parts of a segment are checked.
+		literal := pathParamPattern.ReplaceAllString(segment, "")
+		if literal != "" && !kebabCaseSegment.MatchString(literal) {
+			l.report(LintKebabCasePath, pointer, "path segment %s is not kebab-case", segment)
+		}
+	}
+
+	l.lintParameters(mappingValue(pathItem, "parameters"), pointer+"/parameters")
+	forEachMapping(pathItem, func(method string, operation *yaml.Node) {
+		if isOperationKey(method) {
+			l.lintOperation(operation, pointer+"/"+method)
+		}
+	})
+}
+
+func (l *linter) lintOperation(operation *yaml.Node, pointer string) {
+	if stringValue(operation, "summary") == "" && stringValue(operation, "description") == "" {
+		l.report(LintOperationDescription, pointer, "operation has no description")
+	}
+	if id := stringValue(operation, "operationId"); id != "" {
+		if existing, ok := l.operationIDs[id]; ok {
+			l.report(LintUniqueOperationID, pointer, "operation ID %s is already used by %s", id, existing)
+		} else {
+			l.operationIDs[id] = pointer
+		}
+	}
+
+	l.lintParameters(mappingValue(operation, "parameters"), pointer+"/parameters")
+	l.lintContent(mappingValue(mappingValue(operation, "requestBody"), "content"), pointer+"/requestBody/content")
+	forEachMapping(mappingValue(operation, "responses"), func(status string, response *yaml.Node) {
+		responsePointer := pointer + "/responses/" + escapePointer(status)
+		if ref := mappingValue(response, "$ref"); ref != nil {
+			response = resolvePointer(l.root, ref.Value)
+		}
+		if isErrorStatus(status) {
+			if description := stringValue(response, "description"); description == "" || description == consts.DefaultExceptionDesc {
+				l.report(LintDocumentedException, responsePointer, "exception response %s has no description", status)
+			}
+		}
+		forEachMapping(mappingValue(response, "headers"), func(name string, header *yaml.Node) {
+			l.lintSchema(mappingValue(header, "schema"), responsePointer+"/headers/"+escapePointer(name)+"/schema")
+		})
+		l.lintContent(mappingValue(response, "content"), responsePointer+"/content")
+	})
+}
+
+func (l *linter) lintParameters(parameters *yaml.Node, pointer string) {
+	if parameters == nil || parameters.Kind != yaml.SequenceNode {
+		return
+	}
+	for i, parameter := range parameters.Content {
+		parameterPointer := fmt.Sprintf("%s/%d", pointer, i)
+		if ref := mappingValue(parameter, "$ref"); ref != nil {
+			parameter = resolvePointer(l.root, ref.Value)
+		}
+		schema := mappingValue(parameter, "schema")
+		if stringValue(parameter, "in") == "query" {
+			if resolved := l.resolveSchema(schema); stringValue(resolved, "type") == consts.SchemaObjectType || mappingValue(resolved, "properties") != nil {
+				l.report(LintScalarQuery, parameterPointer, "query parameter %s is a struct", stringValue(parameter, "name"))
+			}
+		}
+		l.lintSchema(schema, parameterPointer+"/schema")
+	}
+}
+
+func (l *linter) lintContent(content *yaml.Node, pointer string) {
+	forEachMapping(content, func(mediaType string, media *yaml.Node) {
+		l.lintSchema(mappingValue(media, "schema"), pointer+"/"+escapePointer(mediaType)+"/schema")
+	})
+}
+
+// lintSchema checks a schema and the schemas in it, without following the references.
+func (l *linter) lintSchema(schema *yaml.Node, pointer string) {
+	if schema == nil || schema.Kind != yaml.MappingNode {
+		return
+	}
+	empty := true
+	for _, keyword := range schemaKeywords {
+		if mappingValue(schema, keyword) != nil {
+			empty = false
+		}
+	}
+	if empty {
+		l.report(LintNoEmptySchema, pointer, "schema accepts any value")
+	}
+
+	forEachMapping(mappingValue(schema, "properties"), func(name string, property *yaml.Node) {
+		propertyPointer := pointer + "/properties/" + escapePointer(name)
+		if !l.hasDescription(property) {
+			l.report(LintPropertyDescription, propertyPointer, "property %s has no description", name)
+		}
+		l.lintSchema(property, propertyPointer)
+	})
+	l.lintSchema(mappingValue(schema, "items"), pointer+"/items")
+	l.lintSchema(mappingValue(schema, "additionalProperties"), pointer+"/additionalProperties")
+	l.lintSchema(mappingValue(schema, "not"), pointer+"/not")
+	for _, keyword := range []string{"allOf", "oneOf", "anyOf"} {
+		if list := mappingValue(schema, keyword); list != nil && list.Kind == yaml.SequenceNode {
+			for i, item := range list.Content {
+				l.lintSchema(item, fmt.Sprintf("%s/%s/%d", pointer, keyword, i))
+			}
+		}
+	}
+}
+
+// hasDescription reports whether a schema, or the schema it refers to, has a description.
+func (l *linter) hasDescription(schema *yaml.Node) bool {
+	if stringValue(schema, "description") != "" {
+		return true
+	}
+	if ref := mappingValue(schema, "$ref"); ref != nil {
+		return stringValue(resolvePointer(l.root, ref.Value), "description") != ""
+	}
+	return false
+}
+
+// resolveSchema follows the references of a schema, and the `allOf` wrapping a single reference.
+func (l *linter) resolveSchema(schema *yaml.Node) *yaml.Node {
+	for i := 0; schema != nil && i < referenceMaxDepth; i++ {
+		if ref := mappingValue(schema, "$ref"); ref != nil {
+			schema = resolvePointer(l.root, ref.Value)
+			continue
+		}
+		if allOf := mappingValue(schema, "allOf"); allOf != nil && len(allOf.Content) == 1 && mappingValue(schema, "type") == nil {
+			schema = allOf.Content[0]
+			continue
+		}
+		break
+	}
+	return schema
+}
+
+// isErrorStatus reports whether a status of the responses is an error, e.g. `400`, `5XX` or `default`.
+func isErrorStatus(status string) bool {
+	return status == "default" || strings.HasPrefix(status, "4") || strings.HasPrefix(status, "5")
+}
+
+func stringValue(node *yaml.Node, key string) string {
+	if value := mappingValue(node, key); value != nil && value.Kind == yaml.ScalarNode {
+		return value.Value
+	}
+	return ""
+}
@@ -0,0 +1,156 @@
+/*
+ * Copyright 2024 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package utils
+
+import (
+	"reflect"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+func TestLintDocument(t *testing.T) {
+	tests := []struct {
+		name     string
+		rule     string
+		document string
+		// want are the pointers of the issues of the rule.
+		want []string
+	}{
+		{
+			name:     "operation without description",
+			rule:     LintOperationDescription,
+			document: `paths: {/pets: {get: {operationId: ListPets}, post: {summary: Create a pet.}, put: {description: Update a pet.}}}`,
+			want:     []string{"#/paths/~1pets/get"},
+		},
+		{
+			name: "property without description",
+			rule: LintPropertyDescription,
+			document: `components: {schemas: {
+  Pet: {type: object, description: A pet., properties: {
+    id: {type: integer},
+    name: {type: string, description: The name.},
+    owner: {$ref: '#/components/schemas/Owner'},
+    tag: {$ref: '#/components/schemas/Tag'}}},
+  Owner: {type: object, description: An owner.},
+  Tag: {type: string}}}`,
+			want: []string{"#/components/schemas/Pet/properties/id", "#/components/schemas/Pet/properties/tag"},
+		},
+		{
+			name:     "operation ID used twice",
+			rule:     LintUniqueOperationID,
+			document: `paths: {/pets: {get: {operationId: ListPets}, post: {operationId: CreatePet}}, '/pets/{id}': {get: {operationId: ListPets}}}`,
+			want:     []string{"#/paths/~1pets~1{id}/get"},
+		},
+		{
+			name:     "path not kebab-case",
+			rule:     LintKebabCasePath,
+			document: `paths: {'/pet-owners/{ownerId}': {}, /pet_owners: {}, '/petOwners/{id}': {}}`,
+			want:     []string{"#/paths/~1pet_owners", "#/paths/~1petOwners~1{id}"},
+		},
+		{
+			name: "struct query parameter",
+			rule: LintScalarQuery,
+			document: `paths: {/pets: {get: {parameters: [
+  {name: filter, in: query, schema: {allOf: [{$ref: '#/components/schemas/Filter'}]}},
+  {name: limit, in: query, schema: {type: integer}},
+  {name: body, in: header, schema: {type: object}},
+  {$ref: '#/components/parameters/page'}]}}}
+components:
+  schemas: {Filter: {properties: {name: {type: string}}}}
+  parameters: {page: {name: page, in: query, schema: {type: object}}}`,
+			want: []string{"#/paths/~1pets/get/parameters/0", "#/paths/~1pets/get/parameters/3"},
+		},
+		{
+			name: "exception without description",
+			rule: LintDocumentedException,
+			document: `paths: {/pets: {get: {responses: {
+  '200': {description: ''},
+  '404': {description: Not found.},
+  '500': {description: Exception response},
+  default: {$ref: '#/components/responses/Error'}}}}}
+components: {responses: {Error: {description: ''}}}`,
+			want: []string{"#/paths/~1pets/get/responses/500", "#/paths/~1pets/get/responses/default"},
+		},
+		{
+			name: "empty schema",
+			rule: LintNoEmptySchema,
+			document: `paths: {/pets: {post: {requestBody: {content: {application/json: {schema: {description: Anything.}}}}}}}
+components: {schemas: {Pet: {type: object, properties: {
+  data: {},
+  tags: {type: array, items: {description: A tag.}},
+  kind: {enum: [cat, dog]}}}}}`,
+			want: []string{
+				"#/paths/~1pets/post/requestBody/content/application~1json/schema",
+				"#/components/schemas/Pet/properties/data",
+				"#/components/schemas/Pet/properties/tags/items",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var document yaml.Node
+			if err := yaml.Unmarshal([]byte(tt.document), &document); err != nil {
+				t.Fatalf("failed to parse document: %s", err)
+			}
+			var got []string
+			for _, issue := range LintDocument(&document, nil) {
+				if issue.Rule == tt.rule {
+					got = append(got, issue.Pointer)
+				}
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("LintDocument() = %q, want %q", got, tt.want)
+			}
+			for _, issue := range LintDocument(&document, []string{tt.rule}) {
+				if issue.Rule == tt.rule {
+					t.Errorf("LintDocument() with %s disabled reported %s", tt.rule, issue)
+				}
+			}
+		})
+	}
+}
+
+func TestCheckLintRules(t *testing.T) {
+	if err := CheckLintRules(LintRules); err != nil {
+		t.Errorf("CheckLintRules() error = %s", err)
+	}
+	if err := CheckLintRules([]string{LintKebabCasePath, "camel-case-path"}); err == nil {
+		t.Errorf("CheckLintRules() error = nil, want an error for an unknown rule")
+	}
+}
+
+func TestLintLocationsLookup(t *testing.T) {
+	locations := LintLocations{
+		SchemaPointer("Pet"):         "pet.thrift:3:1",
+		SchemaPointer("Pet", "name"): "pet.thrift:5:3",
+	}
+	tests := []struct {
+		pointer string
+		want    string
+	}{
+		{SchemaPointer("Pet", "name"), "pet.thrift:5:3"},
+		{SchemaPointer("Pet", "id"), "pet.thrift:3:1"},
+		{SchemaPointer("Pet") + "/properties/tags/items", "pet.thrift:3:1"},
+		{SchemaPointer("Owner"), "pet.thrift"},
+	}
+	for _, tt := range tests {
+		if got := locations.Lookup(tt.pointer, "pet.thrift"); got != tt.want {
+			t.Errorf("Lookup(%s) = %s, want %s", tt.pointer, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,395 @@
+/*
+ * Copyright 2024 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package utils
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// MergeExtension is the extension of an annotation whose value MergeReplace makes it replace the generated element
+// instead of being merged into it, e.g. `specification_extension: [{name: "x-merge", value: {yaml: "replace"}}]`.
+const (
+	MergeExtension = "x-merge"
+	MergeReplace   = "replace"
+)
+
+// unionTypes are the structs of the thrift openapi model that are oneofs in the protobuf model, only one of their fields is set.
+var unionTypes = []string{
+	"AdditionalPropertiesItem", "AnyOrExpression", "CallbackOrReference", "DefaultType", "ExampleOrReference",
+	"HeaderOrReference", "LinkOrReference", "ParameterOrReference", "RequestBodyOrReference", "ResponseOrReference",
+	"SchemaOrReference", "SecuritySchemeOrReference", "SpecificationExtension",
+}
+
+// MergeStructs deep merges src into dst, two pointers to structs of the thrift openapi model:
+//   - the non-zero scalars of src replace those of dst, and the structs are merged field by field,
+//   - the lists of named elements, like responses, properties, parameters, headers, tags or servers, are merged by key,
+//     the lists of strings are merged as sets, and the other lists of src replace those of dst,
+//   - an element of src with the `x-merge: replace` extension replaces the element of dst.
+//
+// The `x-merge` extensions are removed from dst. MergeMessages merges the protobuf model the same way.
+func MergeStructs(dst, src interface{}) error {
+	dstVal := reflect.ValueOf(dst)
+	srcVal := reflect.ValueOf(src)
+
+	// Ensure both dst and src are pointers to structs.
+	if dstVal.Kind() != reflect.Ptr || srcVal.Kind() != reflect.Ptr {
+		return errors.New("both dst and src must be pointers")
+	}
+	if dstVal.Elem().Kind() != reflect.Struct || srcVal.Elem().Kind() != reflect.Struct {
+		return errors.New("both dst and src must be pointers to structs")
+	}
+	if dstVal.Type() != srcVal.Type() {
+		return errors.New("dst and src must be of the same type")
+	}
+
+	if replacesStruct(srcVal.Elem()) {
+		dstVal.Elem().Set(srcVal.Elem())
+	} else {
+		mergeStruct(dstVal.Elem(), srcVal.Elem())
+	}
+	removeMergeExtensions(dstVal)
+	return nil
+}
+
+func mergeStruct(dst, src reflect.Value) {
+	if Contains(unionTypes, dst.Type().Name()) {
+		if i, j := setField(dst), setField(src); i >= 0 && j >= 0 && i != j {
+			dst.Set(src)
+			return
+		}
+	}
+	for i := 0; i < dst.NumField(); i++ {
+		if dst.Field(i).CanSet() {
+			mergeValue(dst.Field(i), src.Field(i))
+		}
+	}
+}
+
+func mergeValue(dst, src reflect.Value) {
+	if src.IsZero() {
+		return
+	}
+	switch {
+	case src.Kind() == reflect.Ptr && src.Elem().Kind() == reflect.Struct:
+		if dst.IsNil() || replacesStruct(src.Elem()) {
+			dst.Set(src)
+		} else {
+			mergeStruct(dst.Elem(), src.Elem())
+		}
+	case src.Kind() == reflect.Slice && src.Type().Elem().Kind() != reflect.Uint8:
+		dst.Set(mergeList(dst, src))
+	default:
+		dst.Set(src)
+	}
+}
+
+// mergeList merges a list of src into the list of dst, and returns the merged list.
+func mergeList(dst, src reflect.Value) reflect.Value {
+	if src.Type().Elem().Kind() == reflect.String {
+		ret := dst
+		for i := 0; i < src.Len(); i++ {
+			if !containsValue(ret, src.Index(i).String()) {
+				ret = reflect.Append(ret, src.Index(i))
+			}
+		}
+		return ret
+	}
+	if !isKeyedStruct(src.Type().Elem()) {
+		return src
+	}
+	ret := dst
+	indexes := make(map[string]int, dst.Len())
+	for i := 0; i < dst.Len(); i++ {
+		indexes[structKey(dst.Index(i))] = i
+	}
+	for i := 0; i < src.Len(); i++ {
+		key := structKey(src.Index(i))
+		if j, ok := indexes[key]; ok {
+			mergeValue(ret.Index(j), src.Index(i))
+			continue
+		}
+		indexes[key] = ret.Len()
+		ret = reflect.Append(ret, src.Index(i))
+	}
+	return ret
+}
+
+func containsValue(list reflect.Value, s string) bool {
+	for i := 0; i < list.Len(); i++ {
+		if list.Index(i).String() == s {
+			return true
+		}
+	}
+	return false
+}
+
+// isKeyedStruct reports whether the elements of a list are pointers to structs identified by a name, a URL or a parameter.
+func isKeyedStruct(t reflect.Type) bool {
+	if t.Kind() != reflect.Ptr || t.Elem().Kind() != reflect.Struct {
+		return false
+	}
+	for _, name := range []string{"Name", "URL", "Parameter"} {
+		if _, ok := t.Elem().FieldByName(name); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// structKey returns the key of an element of a keyed list, a parameter is identified by its location and name.
+func structKey(v reflect.Value) string {
+	if v.IsNil() {
+		return ""
+	}
+	v = v.Elem()
+	if parameter := v.FieldByName("Parameter"); parameter.IsValid() {
+		if !parameter.IsNil() {
+			return structKey(parameter)
+		}
+		if reference := v.FieldByName("Reference"); !reference.IsNil() {
+			return "$ref " + reference.Elem().FieldByName("Xref").String()
+		}
+		return ""
+	}
+	if in := v.FieldByName("In"); in.IsValid() {
+		return in.String() + " " + v.FieldByName("Name").String()
+	}
+	if name := v.FieldByName("Name"); name.IsValid() {
+		return name.String()
+	}
+	return v.FieldByName("URL").String()
+}
+
+// setField returns the index of the first non-zero field of a struct, or -1.
+func setField(v reflect.Value) int {
+	for i := 0; i < v.NumField(); i++ {
+		if !v.Field(i).IsZero() {
+			return i
+		}
+	}
+	return -1
+}
+
+// replacesStruct reports whether a struct has the `x-merge: replace` extension.
+func replacesStruct(v reflect.Value) bool {
+	extensions := v.FieldByName("SpecificationExtension")
+	if !extensions.IsValid() {
+		return false
+	}
+	for i := 0; i < extensions.Len(); i++ {
+		if e := extensions.Index(i).Elem(); e.FieldByName("Name").String() == MergeExtension {
+			value := e.FieldByName("Value")
+			return !value.IsNil() && strings.TrimSpace(value.Elem().FieldByName("Yaml").String()) == MergeReplace
+		}
+	}
+	return false
+}
+
+// removeMergeExtensions removes the `x-merge` extensions of a value and of the values in it.
+func removeMergeExtensions(v reflect.Value) {
+	switch v.Kind() {
+	case reflect.Ptr:
+		if !v.IsNil() {
+			removeMergeExtensions(v.Elem())
+		}
+	case reflect.Slice:
+		for i := 0; i < v.Len(); i++ {
+			removeMergeExtensions(v.Index(i))
+		}
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			if v.Type().Field(i).Name == "SpecificationExtension" && v.Field(i).CanSet() {
+				v.Field(i).Set(filterMergeExtensions(v.Field(i)))
+			}
+			removeMergeExtensions(v.Field(i))
+		}
+	}
+}
+
+func filterMergeExtensions(extensions reflect.Value) reflect.Value {
+	ret := reflect.MakeSlice(extensions.Type(), 0, extensions.Len())
+	for i := 0; i < extensions.Len(); i++ {
+		if e := extensions.Index(i); e.IsNil() || e.Elem().FieldByName("Name").String() != MergeExtension {
+			ret = reflect.Append(ret, e)
+		}
+	}
+	if ret.Len() == 0 {
+		return reflect.Zero(extensions.Type())
+	}
+	return ret
+}
+
+// MergeMessages deep merges src into dst, two messages of the protobuf openapi model, like MergeStructs.
+func MergeMessages(dst, src proto.Message) {
+	// src is usually an option of a descriptor, its messages must not be shared with dst.
+	src = proto.Clone(src)
+	d, s := dst.ProtoReflect(), src.ProtoReflect()
+	if replacesMessage(s) {
+		proto.Reset(dst)
+		proto.Merge(dst, src)
+	} else {
+		mergeMessage(d, s)
+	}
+	removeMessageMergeExtensions(d)
+}
+
+func mergeMessage(dst, src protoreflect.Message) {
+	src.Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		switch {
+		case fd.IsList():
+			mergeMessageList(dst, fd, v.List())
+		case fd.Message() != nil && !fd.IsMap():
+			// A different field of a oneof replaces the one of dst.
+			if !dst.Has(fd) || replacesMessage(v.Message()) {
+				dst.Set(fd, v)
+			} else {
+				mergeMessage(dst.Mutable(fd).Message(), v.Message())
+			}
+		default:
+			dst.Set(fd, v)
+		}
+		return true
+	})
+}
+
+func mergeMessageList(dst protoreflect.Message, fd protoreflect.FieldDescriptor, src protoreflect.List) {
+	list := dst.Mutable(fd).List()
+	switch {
+	case fd.Kind() == protoreflect.StringKind:
+		for i := 0; i < src.Len(); i++ {
+			if !containsListValue(list, src.Get(i).String()) {
+				list.Append(src.Get(i))
+			}
+		}
+	case fd.Message() != nil && isKeyedMessage(fd.Message()):
+		indexes := make(map[string]int, list.Len())
+		for i := 0; i < list.Len(); i++ {
+			indexes[messageKey(list.Get(i).Message())] = i
+		}
+		for i := 0; i < src.Len(); i++ {
+			m := src.Get(i).Message()
+			key := messageKey(m)
+			if j, ok := indexes[key]; ok {
+				if replacesMessage(m) {
+					list.Set(j, src.Get(i))
+				} else {
+					mergeMessage(list.Get(j).Message(), m)
+				}
+				continue
+			}
+			indexes[key] = list.Len()
+			list.Append(src.Get(i))
+		}
+	default:
+		list.Truncate(0)
+		for i := 0; i < src.Len(); i++ {
+			list.Append(src.Get(i))
+		}
+	}
+}
+
+func containsListValue(list protoreflect.List, s string) bool {
+	for i := 0; i < list.Len(); i++ {
+		if list.Get(i).String() == s {
+			return true
+		}
+	}
+	return false
+}
+
+// isKeyedMessage reports whether the elements of a list are identified by a name, a URL or a parameter.
+func isKeyedMessage(md protoreflect.MessageDescriptor) bool {
+	for _, name := range []protoreflect.Name{"name", "url", "parameter"} {
+		if md.Fields().ByName(name) != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// messageKey returns the key of an element of a keyed list, like structKey.
+func messageKey(m protoreflect.Message) string {
+	fields := m.Descriptor().Fields()
+	get := func(m protoreflect.Message, name protoreflect.Name) protoreflect.Value {
+		return m.Get(m.Descriptor().Fields().ByName(name))
+	}
+	if parameter := fields.ByName("parameter"); parameter != nil {
+		if m.Has(parameter) {
+			return messageKey(m.Get(parameter).Message())
+		}
+		if reference := fields.ByName("reference"); m.Has(reference) {
+			return "$ref " + get(m.Get(reference).Message(), "_ref").String()
+		}
+		return ""
+	}
+	if fields.ByName("in") != nil {
+		return get(m, "in").String() + " " + get(m, "name").String()
+	}
+	if fields.ByName("name") != nil {
+		return get(m, "name").String()
+	}
+	return get(m, "url").String()
+}
+
+// replacesMessage reports whether a message has the `x-merge: replace` extension.
+func replacesMessage(m protoreflect.Message) bool {
+	fd := m.Descriptor().Fields().ByName("specification_extension")
+	if fd == nil || !fd.IsList() {
+		return false
+	}
+	extensions := m.Get(fd).List()
+	for i := 0; i < extensions.Len(); i++ {
+		e := extensions.Get(i).Message()
+		if e.Get(e.Descriptor().Fields().ByName("name")).String() == MergeExtension {
+			value := e.Get(e.Descriptor().Fields().ByName("value")).Message()
+			return strings.TrimSpace(value.Get(value.Descriptor().Fields().ByName("yaml")).String()) == MergeReplace
+		}
+	}
+	return false
+}
+
+// removeMessageMergeExtensions removes the `x-merge` extensions of a message and of the messages in it.
+func removeMessageMergeExtensions(m protoreflect.Message) {
+	m.Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		switch {
+		case fd.IsList() && fd.Message() != nil:
+			list := m.Mutable(fd).List()
+			if fd.Name() == "specification_extension" {
+				n := 0
+				for i := 0; i < list.Len(); i++ {
+					e := list.Get(i).Message()
+					if e.Get(e.Descriptor().Fields().ByName("name")).String() != MergeExtension {
+						list.Set(n, list.Get(i))
+						n++
+					}
+				}
+				list.Truncate(n)
+			}
+			for i := 0; i < list.Len(); i++ {
+				removeMessageMergeExtensions(list.Get(i).Message())
+			}
+		case fd.Message() != nil && !fd.IsMap():
+			removeMessageMergeExtensions(v.Message())
+		}
+		return true
+	})
+}
@@ -0,0 +1,202 @@
+/*
+ * Copyright 2024 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package utils_test
+
+import (
+	"testing"
+
+	common "github.com/hertz-contrib/swagger-generate/common/utils"
+	pb "github.com/hertz-contrib/swagger-generate/idl/protobuf/openapi"
+	"github.com/hertz-contrib/swagger-generate/idl/thrift"
+	"gopkg.in/yaml.v3"
+)
+
+// mergeTests merge the annotations of a document into the generated one.
+var mergeTests = []struct {
+	name           string
+	dst, src, want string
+}{
+	{
+		name: "annotations",
+		dst: `
+openapi: 3.0.3
+info: {title: generated, version: 1.0.0}
+paths:
+  /pets:
+    get:
+      operationId: ListPets
+      parameters:
+        - {name: limit, in: query, description: generated}
+      responses:
+        '200': {description: ok}
+components:
+  schemas:
+    Pet:
+      type: object
+      required: [id]
+      properties:
+        id: {type: integer}
+        owner: {type: object}
+    Kind: {type: string, enum: [cat, dog]}
+    Error:
+      type: object
+      properties:
+        code: {type: integer}
+`,
+		src: `
+info: {title: Pets}
+paths:
+  /pets:
+    get:
+      parameters:
+        - {name: limit, in: query, description: The number of pets.}
+        - {name: limit, in: header}
+      responses:
+        '404': {description: not found}
+components:
+  schemas:
+    Pet:
+      required: [id, name]
+      properties:
+        name: {type: string}
+        owner: {$ref: '#/components/schemas/Owner'}
+    Kind: {enum: [bird]}
+    Error: {type: string, x-merge: replace}
+`,
+		// Parameters match by location and name, properties by name and responses by code, string lists are
+		// merged as sets and other lists replaced, and x-merge replaces the whole value.
+		want: `
+openapi: 3.0.3
+info: {title: Pets, version: 1.0.0}
+paths:
+  /pets:
+    get:
+      operationId: ListPets
+      parameters:
+        - {name: limit, in: query, description: The number of pets.}
+        - {name: limit, in: header}
+      responses:
+        '200': {description: ok}
+        '404': {description: not found}
+components:
+  schemas:
+    Pet:
+      type: object
+      required: [id, name]
+      properties:
+        id: {type: integer}
+        owner: {$ref: '#/components/schemas/Owner'}
+        name: {type: string}
+    Kind: {type: string, enum: [bird]}
+    Error: {type: string}
+`,
+	},
+	{
+		name: "document replaced",
+		dst:  "info: {title: generated, version: 1.0.0, description: generated}",
+		src:  "info: {title: annotated, version: 2.0.0}\nx-merge: replace",
+		want: "info: {title: annotated, version: 2.0.0}",
+	},
+	{
+		name: "other x-merge values merged",
+		dst:  "info: {title: generated, version: 1.0.0}",
+		src:  "info: {description: annotated}\nx-merge: deep",
+		want: "info: {title: generated, version: 1.0.0, description: annotated}",
+	},
+}
+
+// documentNode parses a YAML document, the tests only write the fields they merge.
+func documentNode(t *testing.T, s string) *yaml.Node {
+	t.Helper()
+	var node yaml.Node
+	if err := yaml.Unmarshal([]byte(s), &node); err != nil {
+		t.Fatalf("failed to parse %q: %s", s, err)
+	}
+	return &node
+}
+
+func marshal(t *testing.T, node *yaml.Node) string {
+	t.Helper()
+	b, err := yaml.Marshal(node)
+	if err != nil {
+		t.Fatalf("failed to marshal document: %s", err)
+	}
+	return string(b)
+}
+
+func thriftDocument(t *testing.T, s string) *openapi.Document {
+	t.Helper()
+	d, err := openapi.ParseDocumentNode(documentNode(t, s))
+	if err != nil {
+		t.Fatalf("failed to read %q: %s", s, err)
+	}
+	return d
+}
+
+func protobufDocument(t *testing.T, s string) *pb.Document {
+	t.Helper()
+	d, err := pb.ParseDocumentNode(documentNode(t, s))
+	if err != nil {
+		t.Fatalf("failed to read %q: %s", s, err)
+	}
+	return d
+}
+
+func TestMergeStructs(t *testing.T) {
+	for _, tt := range mergeTests {
+		t.Run(tt.name, func(t *testing.T) {
+			dst := thriftDocument(t, tt.dst)
+			if err := common.MergeStructs(dst, thriftDocument(t, tt.src)); err != nil {
+				t.Fatalf("MergeStructs() error = %s", err)
+			}
+			if got, want := marshal(t, dst.ToRawInfo()), marshal(t, thriftDocument(t, tt.want).ToRawInfo()); got != want {
+				t.Errorf("MergeStructs() =\n%s\nwant\n%s", got, want)
+			}
+		})
+	}
+}
+
+func TestMergeMessages(t *testing.T) {
+	for _, tt := range mergeTests {
+		t.Run(tt.name, func(t *testing.T) {
+			dst := protobufDocument(t, tt.dst)
+			common.MergeMessages(dst, protobufDocument(t, tt.src))
+			if got, want := marshal(t, dst.ToRawInfo()), marshal(t, protobufDocument(t, tt.want).ToRawInfo()); got != want {
+				t.Errorf("MergeMessages() =\n%s\nwant\n%s", got, want)
+			}
+		})
+	}
+}
+
+func TestMergeStructsErrors(t *testing.T) {
+	d := &openapi.Document{}
+	tests := []struct {
+		name     string
+		dst, src interface{}
+	}{
+		{"not pointers", openapi.Document{}, openapi.Document{}},
+		{"not structs", new(string), new(string)},
+		{"different types", d, &openapi.Info{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := common.MergeStructs(tt.dst, tt.src); err == nil {
+				t.Errorf("MergeStructs() error = nil, want an error")
+			}
+		})
+	}
+}
@@ -0,0 +1,87 @@
+/*
+ * Copyright 2024 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package utils
+
+import (
+	"fmt"
+	"net/textproto"
+	"regexp"
+	"strings"
+
+	"github.com/hertz-contrib/swagger-generate/common/consts"
+)
+
+var metaKeyPattern = regexp.MustCompile(`^[A-Za-z0-9_]+$`)
+
+// MetaKeys are the metainfo keys of the calls of an RPC swagger server, documented as the X-Meta-* and
+// X-Meta-Persist-* headers of the requests and the X-Meta-Backward-* headers of the responses.
+type MetaKeys struct {
+	transient  []string
+	persistent []string
+	backward   []string
+}
+
+// MetaHeader is a metainfo header of a request or a response.
+type MetaHeader struct {
+	Name        string
+	Description string
+}
+
+func NewMetaKeys(transient, persistent, backward []string) (*MetaKeys, error) {
+	for _, key := range append(append(append([]string{}, transient...), persistent...), backward...) {
+		if !metaKeyPattern.MatchString(key) {
+			return nil, fmt.Errorf("invalid metainfo key %q, expected letters, digits and underscores", key)
+		}
+	}
+	return &MetaKeys{transient: transient, persistent: persistent, backward: backward}, nil
+}
+
+// RequestHeaders returns the headers of the transient and persistent keys.
+func (k *MetaKeys) RequestHeaders() []*MetaHeader {
+	var headers []*MetaHeader
+	for _, key := range k.transient {
+		headers = append(headers, &MetaHeader{
+			Name:        MetaHeaderName(consts.MetaHeaderPrefix, key),
+			Description: fmt.Sprintf(consts.MetaHeaderDescription, key),
+		})
+	}
+	for _, key := range k.persistent {
+		headers = append(headers, &MetaHeader{
+			Name:        MetaHeaderName(consts.MetaHeaderPrefixPersistent, key),
+			Description: fmt.Sprintf(consts.MetaHeaderDescriptionPersistent, key),
+		})
+	}
+	return headers
+}
+
+// ResponseHeaders returns the headers of the backward keys.
+func (k *MetaKeys) ResponseHeaders() []*MetaHeader {
+	var headers []*MetaHeader
+	for _, key := range k.backward {
+		headers = append(headers, &MetaHeader{
+			Name:        MetaHeaderName(consts.MetaHeaderPrefixBackward, key),
+			Description: fmt.Sprintf(consts.MetaHeaderDescriptionBackward, key),
+		})
+	}
+	return headers
+}
+
+// MetaHeaderName returns the header of a metainfo key with a prefix. The key is converted like the CGI variables
+// of metainfo, which the proxy converts back, e.g. USER_ID is X-Meta-User-Id.
+func MetaHeaderName(prefix, key string) string {
+	return prefix + textproto.CanonicalMIMEHeaderKey(strings.ToLower(strings.ReplaceAll(key, "_", "-")))
+}
@@ -0,0 +1,192 @@
+/*
+ * Copyright 2024 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package utils
+
+import (
+	"fmt"
+	"os"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ApplyOverlay applies an overlay file to a document node, and returns the problems of the overlay as diagnostics.
+// The file is either an OpenAPI Overlay 1.0, whose actions update or remove the nodes selected by JSONPath targets,
+// or a merge patch, a YAML document merged into the document where `null` values remove the keys.
+func ApplyOverlay(document *yaml.Node, file string) []*Diagnostic {
+	o := &overlay{file: file}
+	data, err := os.ReadFile(file)
+	if err != nil {
+		o.errorf(nil, "failed to read overlay file: %s", err)
+		return o.diagnostics
+	}
+	var overlayDocument yaml.Node
+	if err = yaml.Unmarshal(data, &overlayDocument); err != nil {
+		o.errorf(nil, "failed to parse overlay file: %s", err)
+		return o.diagnostics
+	}
+	if len(overlayDocument.Content) == 0 {
+		return nil
+	}
+	if document.Kind == yaml.DocumentNode && len(document.Content) == 1 {
+		document = document.Content[0]
+	}
+
+	root := overlayDocument.Content[0]
+	if mappingValue(root, "overlay") == nil {
+		if root.Kind != yaml.MappingNode {
+			o.errorf(root, "merge patch is not a map")
+			return o.diagnostics
+		}
+		mergePatch(document, root)
+		return o.diagnostics
+	}
+	actions := mappingValue(root, "actions")
+	if actions == nil || actions.Kind != yaml.SequenceNode {
+		o.errorf(root, "overlay has no actions")
+		return o.diagnostics
+	}
+	for _, action := range actions.Content {
+		o.apply(document, action)
+	}
+	return o.diagnostics
+}
+
+type overlay struct {
+	file        string
+	diagnostics []*Diagnostic
+}
+
+// location returns the position of a node of the overlay file, or the file if the node is nil.
+func (o *overlay) location(node *yaml.Node) string {
+	if node == nil {
+		return o.file
+	}
+	return fmt.Sprintf("%s:%d:%d", o.file, node.Line, node.Column)
+}
+
+func (o *overlay) warnf(node *yaml.Node, format string, a ...interface{}) {
+	o.diagnostics = append(o.diagnostics, &Diagnostic{Severity: SeverityWarning, Code: DiagnosticOverlay, Location: o.location(node), Message: fmt.Sprintf(format, a...)})
+}
+
+func (o *overlay) errorf(node *yaml.Node, format string, a ...interface{}) {
+	o.diagnostics = append(o.diagnostics, &Diagnostic{Severity: SeverityError, Code: DiagnosticOverlay, Location: o.location(node), Message: fmt.Sprintf(format, a...)})
+}
+
+// apply applies an action: `remove: true` removes the targets, otherwise `update` is merged into them.
+func (o *overlay) apply(document, action *yaml.Node) {
+	target := stringValue(action, "target")
+	if target == "" {
+		o.errorf(action, "action has no target")
+		return
+	}
+	path, err := ParseJSONPath(target)
+	if err != nil {
+		o.errorf(action, "%s", err)
+		return
+	}
+	remove := stringValue(action, "remove") == "true"
+	update := mappingValue(action, "update")
+	if !remove && update == nil {
+		o.warnf(action, "action on %s has neither update nor remove", target)
+		return
+	}
+
+	matches := path.Select(document)
+	if len(matches) == 0 {
+		o.warnf(action, "target %s matches nothing", target)
+		return
+	}
+	for _, m := range matches {
+		if remove {
+			if m.Parent == nil {
+				o.errorf(action, "target %s cannot remove the document", target)
+				return
+			}
+			removeChild(m.Parent, m.Node)
+			continue
+		}
+		switch m.Node.Kind {
+		case yaml.MappingNode:
+			if update.Kind != yaml.MappingNode {
+				o.errorf(action, "update of target %s is not a map", target)
+				return
+			}
+			mergeUpdate(m.Node, update)
+		case yaml.SequenceNode:
+			if update.Kind == yaml.SequenceNode {
+				for _, element := range update.Content {
+					m.Node.Content = append(m.Node.Content, copyNode(element))
+				}
+			} else {
+				m.Node.Content = append(m.Node.Content, copyNode(update))
+			}
+		default:
+			o.errorf(action, "target %s is not a map or a list", target)
+			return
+		}
+	}
+}
+
+// mergeUpdate merges the update of an action into a map: maps are merged, other values replace the existing ones.
+func mergeUpdate(node, update *yaml.Node) {
+	forEachMapping(update, func(key string, value *yaml.Node) {
+		if existing := mappingValue(node, key); existing != nil && existing.Kind == yaml.MappingNode && value.Kind == yaml.MappingNode {
+			mergeUpdate(existing, value)
+			return
+		}
+		setMappingValue(node, key, copyNode(value))
+	})
+}
+
+// mergePatch merges a patch into a map like a JSON merge patch (RFC 7386): maps are merged,
+// `null` values remove the keys and other values replace the existing ones.
+func mergePatch(node, patch *yaml.Node) {
+	forEachMapping(patch, func(key string, value *yaml.Node) {
+		switch existing := mappingValue(node, key); {
+		case value.Tag == "!!null":
+			deleteMappingValue(node, key)
+		case value.Kind == yaml.MappingNode:
+			if existing == nil || existing.Kind != yaml.MappingNode {
+				existing = &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+				setMappingValue(node, key, existing)
+			}
+			mergePatch(existing, value)
+		default:
+			setMappingValue(node, key, copyNode(value))
+		}
+	})
+}
+
+// removeChild removes a value of a mapping or an element of a sequence.
+func removeChild(parent, child *yaml.Node) {
+	switch parent.Kind {
+	case yaml.MappingNode:
+		for i := 1; i < len(parent.Content); i += 2 {
+			if parent.Content[i] == child {
+				parent.Content = append(parent.Content[:i-1], parent.Content[i+1:]...)
+				return
+			}
+		}
+	case yaml.SequenceNode:
+		for i, element := range parent.Content {
+			if element == child {
+				parent.Content = append(parent.Content[:i], parent.Content[i+1:]...)
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,185 @@
+/*
+ * Copyright 2024 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package utils
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+const overlayDocument = `
+openapi: 3.0.3
+info: {title: Pets, version: 1.0.0}
+tags: [{name: pets}, {name: stores}, {name: internal}, {name: admin}]
+paths:
+  /pets:
+    get:
+      parameters:
+        - {name: limit, in: query, schema: {type: integer, maximum: 100}}
+        - {name: X-Debug, in: header}
+    post:
+      parameters:
+        - {name: X-Debug, in: header}
+        - {name: X-Debug, in: query}
+  /admin:
+    get: {}
+`
+
+// applyOverlay applies an overlay to overlayDocument, and returns the document and the diagnostics.
+func applyOverlay(t *testing.T, content string) (interface{}, []string) {
+	t.Helper()
+	file := filepath.Join(t.TempDir(), "overlay.yaml")
+	if err := os.WriteFile(file, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	var document yaml.Node
+	if err := yaml.Unmarshal([]byte(overlayDocument), &document); err != nil {
+		t.Fatalf("failed to parse document: %s", err)
+	}
+	var diagnostics []string
+	for _, d := range ApplyOverlay(&document, file) {
+		// The file is a temporary one, only its position is kept.
+		d.Location = d.Location[len(file):]
+		diagnostics = append(diagnostics, d.Severity.String()+" "+d.String())
+	}
+	var got interface{}
+	if err := document.Decode(&got); err != nil {
+		t.Fatalf("failed to decode document: %s", err)
+	}
+	return got, diagnostics
+}
+
+func TestApplyOverlay(t *testing.T) {
+	tests := []struct {
+		name    string
+		overlay string
+		want    string
+	}{
+		{
+			name: "overlay",
+			overlay: `
+overlay: 1.0.0
+info: {title: public, version: 1.0.0}
+actions:
+  - target: $.info
+    update: {description: The pets API., x-logo: {url: logo.png}}
+  - target: $..parameters[?(@.in == 'header' && @.name == 'X-Debug')]
+    remove: true
+  - target: $.paths['/admin']
+    remove: true
+  - target: $.tags[2:]
+    remove: true
+  - target: $.tags
+    update: [{name: owners}]
+  - target: $.paths.*.get.parameters[?(@.schema.maximum >= 100)].schema
+    update: {maximum: 50}
+`,
+			want: `
+openapi: 3.0.3
+info: {title: Pets, version: 1.0.0, description: The pets API., x-logo: {url: logo.png}}
+tags: [{name: pets}, {name: stores}, {name: owners}]
+paths:
+  /pets:
+    get:
+      parameters:
+        - {name: limit, in: query, schema: {type: integer, maximum: 50}}
+    post:
+      parameters:
+        - {name: X-Debug, in: query}
+`,
+		},
+		{
+			name: "merge patch",
+			overlay: `
+info: {description: The pets API.}
+paths:
+  /admin: null
+x-tagGroups: [{name: Pets, tags: [pets]}]
+`,
+			want: `
+openapi: 3.0.3
+info: {title: Pets, version: 1.0.0, description: The pets API.}
+tags: [{name: pets}, {name: stores}, {name: internal}, {name: admin}]
+paths:
+  /pets:
+    get:
+      parameters:
+        - {name: limit, in: query, schema: {type: integer, maximum: 100}}
+        - {name: X-Debug, in: header}
+    post:
+      parameters:
+        - {name: X-Debug, in: header}
+        - {name: X-Debug, in: query}
+x-tagGroups: [{name: Pets, tags: [pets]}]
+`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, diagnostics := applyOverlay(t, tt.overlay)
+			if len(diagnostics) > 0 {
+				t.Errorf("ApplyOverlay() diagnostics = %q, want none", diagnostics)
+			}
+			var want interface{}
+			if err := yaml.Unmarshal([]byte(tt.want), &want); err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("ApplyOverlay() =\n%v\nwant\n%v", got, want)
+			}
+		})
+	}
+}
+
+func TestApplyOverlayDiagnostics(t *testing.T) {
+	_, diagnostics := applyOverlay(t, `
+overlay: 1.0.0
+actions:
+  - update: {}
+  - {target: $.info., remove: true}
+  - {target: $.servers, update: {url: /}}
+  - {target: $.info}
+  - {target: $, remove: true}
+  - {target: $.info.title, update: {}}
+  - {target: $.info, update: [a]}
+`)
+	want := []string{
+		"error :4:5: action has no target [overlay]",
+		"error :5:5: invalid JSONPath $.info.: at offset 7: expected a name [overlay]",
+		"warning :6:5: target $.servers matches nothing [overlay]",
+		"warning :7:5: action on $.info has neither update nor remove [overlay]",
+		"error :8:5: target $ cannot remove the document [overlay]",
+		"error :9:5: target $.info.title is not a map or a list [overlay]",
+		"error :10:5: update of target $.info is not a map [overlay]",
+	}
+	if !reflect.DeepEqual(diagnostics, want) {
+		t.Errorf("ApplyOverlay() diagnostics = %q, want %q", diagnostics, want)
+	}
+
+	for content, want := range map[string]string{
+		"- a\n":            "error :1:1: merge patch is not a map [overlay]",
+		"overlay: 1.0.0\n": "error :1:1: overlay has no actions [overlay]",
+	} {
+		if _, diagnostics = applyOverlay(t, content); !reflect.DeepEqual(diagnostics, []string{want}) {
+			t.Errorf("ApplyOverlay(%q) diagnostics = %q, want %q", content, diagnostics, want)
+		}
+	}
+}
@@ -0,0 +1,136 @@
+/*
+ * Copyright 2024 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package utils
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/hertz-contrib/swagger-generate/common/consts"
+	"gopkg.in/yaml.v3"
+)
+
+// ApplyDocuments keeps the methods of the services that are documented by the POST operations of the documents
+// at their routes, which the proxy only allows, with the request schemas and the safety of the operations.
+// The services left without methods are removed, and the schemas referenced by the request schemas are kept.
+func (i *Idl) ApplyDocuments(documents ...*yaml.Node) error {
+	operations := make(map[string]*yaml.Node)
+	schemas := make(map[string]*yaml.Node)
+	for _, document := range documents {
+		if document.Kind == yaml.DocumentNode && len(document.Content) == 1 {
+			document = document.Content[0]
+		}
+		forEachMapping(mappingValue(document, "paths"), func(path string, item *yaml.Node) {
+			if post := mappingValue(item, "post"); post != nil {
+				operations[path] = post
+			}
+		})
+		forEachMapping(mappingValue(mappingValue(document, "components"), "schemas"), func(name string, schema *yaml.Node) {
+			schemas[name] = schema
+		})
+	}
+
+	referenced := make(map[string]interface{})
+	var services []*IdlService
+	for _, s := range i.Services {
+		var methods []*IdlMethod
+		for _, m := range s.Methods {
+			path := "/" + m.Name
+			if i.ServicePrefix {
+				path = "/" + s.Name + path
+			}
+			operation, ok := operations[path]
+			if !ok {
+				continue
+			}
+			if safe := mappingValue(operation, consts.ExtensionSafe); safe != nil {
+				if err := safe.Decode(&m.Safe); err != nil {
+					return fmt.Errorf("invalid %s of %s: %w", consts.ExtensionSafe, path, err)
+				}
+			}
+			schema := mappingValue(mappingValue(mappingValue(mappingValue(operation, "requestBody"), "content"),
+				consts.ContentTypeJSON), "schema")
+			if schema != nil {
+				if err := referenceSchemas(schema, schemas, referenced); err != nil {
+					return fmt.Errorf("invalid request schema of %s: %w", path, err)
+				}
+				b, err := jsonNode(schema)
+				if err != nil {
+					return fmt.Errorf("invalid request schema of %s: %w", path, err)
+				}
+				m.Schema = string(b)
+			}
+			methods = append(methods, m)
+		}
+		if len(methods) > 0 {
+			s.Methods = methods
+			services = append(services, s)
+		}
+	}
+	i.Services = services
+
+	i.Schemas = ""
+	if len(referenced) > 0 {
+		b, err := json.MarshalIndent(referenced, "", "  ")
+		if err != nil {
+			return fmt.Errorf("invalid schemas: %w", err)
+		}
+		i.Schemas = string(b) + "\n"
+	}
+	return nil
+}
+
+// referenceSchemas adds the schemas of the components referenced by a schema, directly or through other schemas,
+// decoded into JSON values.
+func referenceSchemas(node *yaml.Node, schemas map[string]*yaml.Node, referenced map[string]interface{}) error {
+	if node == nil {
+		return nil
+	}
+	if ref := stringValue(node, "$ref"); strings.HasPrefix(ref, consts.ComponentSchemaPrefix) {
+		name := strings.NewReplacer("~1", "/", "~0", "~").Replace(strings.TrimPrefix(ref, consts.ComponentSchemaPrefix))
+		if _, ok := referenced[name]; !ok {
+			schema, ok := schemas[name]
+			if !ok {
+				return fmt.Errorf("unresolved reference %s", ref)
+			}
+			var value interface{}
+			if err := schema.Decode(&value); err != nil {
+				return err
+			}
+			referenced[name] = value
+			if err := referenceSchemas(schema, schemas, referenced); err != nil {
+				return err
+			}
+		}
+	}
+	for _, child := range node.Content {
+		if err := referenceSchemas(child, schemas, referenced); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// jsonNode returns the compact JSON of a YAML node.
+func jsonNode(node *yaml.Node) ([]byte, error) {
+	var value interface{}
+	if err := node.Decode(&value); err != nil {
+		return nil, err
+	}
+	return json.Marshal(value)
+}
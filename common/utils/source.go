@@ -0,0 +1,184 @@
+/*
+ * Copyright 2024 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package utils
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/cloudwego/thriftgo/parser"
+)
+
+// ThriftSources finds the positions of the declarations of thrift files, which the thrift AST does not record,
+// in the syntax trees of the parser of thriftgo.
+type ThriftSources struct {
+	files map[string]*thriftSource
+}
+
+func NewThriftSources() *ThriftSources {
+	return &ThriftSources{files: make(map[string]*thriftSource)}
+}
+
+// Location returns the `path:line:column` of a declaration like a service or a struct,
+// or of a member of it like a method or a field. It returns the path if the declaration is not found.
+func (s *ThriftSources) Location(path, name, member string) string {
+	source, ok := s.files[path]
+	if !ok {
+		if b, err := os.ReadFile(path); err == nil {
+			source = parseThriftSource(string(b))
+		}
+		s.files[path] = source
+	}
+	if source == nil {
+		return path
+	}
+	declaration, ok := source.declarations[name]
+	if !ok {
+		return path
+	}
+	offset := declaration.offset
+	if m, ok := declaration.members[member]; ok {
+		offset = m
+	}
+	line, column := source.position(offset)
+	return fmt.Sprintf("%s:%d:%d", path, line, column)
+}
+
+// thriftSource is a parsed thrift file, with the offsets of its declarations by name.
+type thriftSource struct {
+	content      []rune
+	declarations map[string]*thriftDeclaration
+}
+
+// thriftDeclaration is the offset of the keyword of a declaration, and the offsets of the names of its fields
+// or functions.
+type thriftDeclaration struct {
+	offset  int
+	members map[string]int
+}
+
+// thriftToken is a node of the syntax tree of a thrift file, between two offsets in runes.
+type thriftToken struct {
+	rule       string
+	begin, end int
+}
+
+// parseThriftSource returns the declarations of a thrift file, or nil if it cannot be parsed.
+func parseThriftSource(content string) *thriftSource {
+	idl := &parser.ThriftIDL{Buffer: content}
+	if err := idl.Init(); err != nil {
+		return nil
+	}
+	if err := idl.Parse(); err != nil {
+		return nil
+	}
+	// The tokens of the parser print their rules and offsets only.
+	tokens := make([]thriftToken, 0, len(idl.Tokens()))
+	for _, t := range idl.Tokens() {
+		var token thriftToken
+		if _, err := fmt.Sscanf(strings.NewReplacer("\x1B[34m", "", "\x1B[m", "").Replace(t.String()), "%s %d %d", &token.rule, &token.begin, &token.end); err != nil {
+			return nil
+		}
+		tokens = append(tokens, token)
+	}
+	// The tokens are listed as their nodes end, after the nodes they contain. Each node is sorted before them instead.
+	for i, j := 0, len(tokens)-1; i < j; i, j = i+1, j-1 {
+		tokens[i], tokens[j] = tokens[j], tokens[i]
+	}
+	sort.SliceStable(tokens, func(i, j int) bool {
+		if tokens[i].begin != tokens[j].begin {
+			return tokens[i].begin < tokens[j].begin
+		}
+		return tokens[i].end > tokens[j].end
+	})
+
+	source := &thriftSource{content: []rune(content), declarations: make(map[string]*thriftDeclaration)}
+	for i, t := range tokens {
+		switch t.rule {
+		case "Struct", "Union", "Exception", "Service", "Enum":
+		default:
+			continue
+		}
+		contained := containedTokens(tokens[i+1:], t)
+		name := source.name(contained)
+		if name == "" {
+			continue
+		}
+		declaration := &thriftDeclaration{offset: t.begin, members: make(map[string]int)}
+		for j, member := range contained {
+			if member.rule != "Field" && member.rule != "Function" {
+				continue
+			}
+			// The name of a member is its first identifier outside of its type.
+			memberTokens := containedTokens(contained[j+1:], member)
+			for k := 0; k < len(memberTokens); k++ {
+				switch memberTokens[k].rule {
+				case "FieldType", "FunctionType":
+					k += len(containedTokens(memberTokens[k+1:], memberTokens[k]))
+					continue
+				case "Identifier":
+					if _, ok := declaration.members[source.text(memberTokens[k])]; !ok {
+						declaration.members[source.text(memberTokens[k])] = memberTokens[k].begin
+					}
+				default:
+					continue
+				}
+				break
+			}
+		}
+		source.declarations[name] = declaration
+	}
+	return source
+}
+
+// containedTokens returns the tokens contained by a token, which follow it.
+func containedTokens(tokens []thriftToken, parent thriftToken) []thriftToken {
+	n := 0
+	for n < len(tokens) && tokens[n].begin >= parent.begin && tokens[n].end <= parent.end {
+		n++
+	}
+	return tokens[:n]
+}
+
+// name returns the name of a declaration, its first identifier.
+func (s *thriftSource) name(tokens []thriftToken) string {
+	for _, t := range tokens {
+		if t.rule == "Identifier" {
+			return s.text(t)
+		}
+	}
+	return ""
+}
+
+func (s *thriftSource) text(t thriftToken) string {
+	return strings.TrimSpace(string(s.content[t.begin:t.end]))
+}
+
+// position returns the line and the column of an offset, from 1.
+func (s *thriftSource) position(offset int) (int, int) {
+	line, column := 1, 1
+	for _, r := range s.content[:offset] {
+		if r == '\n' {
+			line, column = line+1, 1
+		} else {
+			column++
+		}
+	}
+	return line, column
+}
@@ -0,0 +1,67 @@
+/*
+ * Copyright 2024 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package utils
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestThriftSourcesLocation(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "pet.thrift")
+	content := `namespace go pet
+
+// struct Fake is a comment.
+struct Pet {
+  1: required types.ID id (api.query = "id")
+  2: list<Pet> friends,
+  3: string name = "id"
+} (api.name = "Pet")
+
+service PetService {
+  Pet GetPet(1: Pet req) throws (1: NotFound err)
+  oneway void Ping(1: Pet req)
+}
+`
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name, member, want string
+	}{
+		{"Pet", "", ":4:1"},
+		{"Pet", "id", ":5:24"},
+		{"Pet", "friends", ":6:16"},
+		{"Pet", "name", ":7:13"},
+		{"PetService", "", ":10:1"},
+		{"PetService", "GetPet", ":11:7"},
+		{"PetService", "Ping", ":12:15"},
+		// The members that are not found are located at their declaration, the declarations at their file.
+		{"PetService", "Unknown", ":10:1"},
+		{"Fake", "", ""},
+	}
+	s := NewThriftSources()
+	for _, tt := range tests {
+		if got := s.Location(path, tt.name, tt.member); got != path+tt.want {
+			t.Errorf("Location(%s, %s) = %s, want %s", tt.name, tt.member, got, path+tt.want)
+		}
+	}
+	if got := s.Location(filepath.Join(filepath.Dir(path), "missing.thrift"), "Pet", ""); got != filepath.Join(filepath.Dir(path), "missing.thrift") {
+		t.Errorf("Location(missing.thrift) = %s, want the path", got)
+	}
+}
@@ -21,8 +21,12 @@ import (
 	"fmt"
 	"os"
 	"reflect"
+	"regexp"
 	"strconv"
 	"strings"
+	"unicode"
+
+	"gopkg.in/yaml.v3"
 )
 
 // Contains returns true if an array Contains a specified string.
@@ -35,11 +39,15 @@ func Contains(s []string, e string) bool {
 	return false
 }
 
+// UnpackArgs sets the fields of the struct pointed by c from the `key=value` arguments, whose keys are the
+// snake_case names of the fields, e.g. `service_prefix` for ServicePrefix. The names of the fields themselves
+// are still accepted as deprecated aliases, see DeprecatedArgs.
 func UnpackArgs(args []string, c interface{}) error {
 	m, err := MapForm(args)
 	if err != nil {
 		return fmt.Errorf("unmarshal args failed, err: %v", err.Error())
 	}
+	m = normalizeArgs(m)
 
 	t := reflect.TypeOf(c).Elem()
 	v := reflect.ValueOf(c).Elem()
@@ -49,8 +57,11 @@ func UnpackArgs(args []string, c interface{}) error {
 
 	for i := 0; i < t.NumField(); i++ {
 		f := t.Field(i)
+		if f.PkgPath != "" {
+			continue
+		}
 		x := v.Field(i)
-		n := f.Name
+		n := ArgName(f.Name)
 		values, ok := m[n]
 		if !ok || len(values) == 0 || values[0] == "" {
 			continue
@@ -66,6 +77,15 @@ func UnpackArgs(args []string, c interface{}) error {
 				return fmt.Errorf("field %s can't be assigned multi values: %v", n, values)
 			}
 			x.SetString(values[0])
+		case reflect.Int:
+			if len(values) != 1 {
+				return fmt.Errorf("field %s can't be assigned multi values: %v", n, values)
+			}
+			val, err := strconv.ParseInt(values[0], 10, 64)
+			if err != nil {
+				return fmt.Errorf("field %s is not an integer: %v", n, values[0])
+			}
+			x.SetInt(val)
 		case reflect.Slice:
 			if len(values) != 1 {
 				return fmt.Errorf("field %s can't be assigned multi values: %v", n, values)
@@ -123,32 +143,44 @@ func MapForm(input []string) (map[string][]string, error) {
 	return out, nil
 }
 
-// MergeStructs merges non-zero fields from src into dst.
-func MergeStructs(dst, src interface{}) error {
-	dstVal := reflect.ValueOf(dst)
-	srcVal := reflect.ValueOf(src)
-
-	// Ensure both dst and src are pointers to structs.
-	if dstVal.Kind() != reflect.Ptr || srcVal.Kind() != reflect.Ptr {
-		return errors.New("both dst and src must be pointers")
-	}
-	if dstVal.Elem().Kind() != reflect.Struct || srcVal.Elem().Kind() != reflect.Struct {
-		return errors.New("both dst and src must be pointers to structs")
+// ArgName returns the snake_case name of the argument of a field, e.g. `service_prefix` for ServicePrefix
+// and `rpc_timeout` for RPCTimeout. The snake_case names are returned as they are.
+func ArgName(field string) string {
+	runes := []rune(field)
+	var b strings.Builder
+	for i, r := range runes {
+		if unicode.IsUpper(r) {
+			if i > 0 && (!unicode.IsUpper(runes[i-1]) || i+1 < len(runes) && unicode.IsLower(runes[i+1])) && runes[i-1] != '_' {
+				b.WriteByte('_')
+			}
+			r = unicode.ToLower(r)
+		}
+		b.WriteRune(r)
 	}
+	return b.String()
+}
 
-	dstVal = dstVal.Elem()
-	srcVal = srcVal.Elem()
-
-	for i := 0; i < dstVal.NumField(); i++ {
-		field := dstVal.Field(i)
-		srcField := srcVal.Field(i)
-
-		if !srcField.IsZero() {
-			field.Set(srcField)
+// DeprecatedArgs returns the keys of the arguments spelled like the fields instead of in snake_case,
+// e.g. `ServicePrefix`, in their order.
+func DeprecatedArgs(args []string) []string {
+	var keys []string
+	for _, arg := range args {
+		key, _, _ := strings.Cut(arg, "=")
+		if key != ArgName(key) {
+			keys = AppendUnique(keys, key)
 		}
 	}
+	return keys
+}
 
-	return nil
+// normalizeArgs merges the values of the arguments by their snake_case names.
+func normalizeArgs(m map[string][]string) map[string][]string {
+	out := make(map[string][]string, len(m))
+	for key, values := range m {
+		n := ArgName(key)
+		out[n] = append(out[n], values...)
+	}
+	return out
 }
 
 func AppendUnique(s []string, e string) []string {
@@ -158,6 +190,39 @@ func AppendUnique(s []string, e string) []string {
 	return s
 }
 
+// OperationKey identifies the route of an operation by its HTTP method and path template.
+// Path parameter names are ignored because `/user/{id}` and `/user/{name}` match the same requests.
+func OperationKey(method, path string) string {
+	return strings.ToUpper(method) + " " + pathParamPattern.ReplaceAllString(path, "{}")
+}
+
+var pathParamPattern = regexp.MustCompile(`\{[^}]*\}`)
+
+// ParseDeprecation reports whether the values of a deprecation annotation mark an element as deprecated.
+// Any value other than `true` or `false` is taken as the reason of the deprecation.
+func ParseDeprecation(values []string) (bool, string) {
+	if len(values) == 0 {
+		return false, ""
+	}
+	value := strings.TrimSpace(values[0])
+	switch strings.ToLower(value) {
+	case "false":
+		return false, ""
+	case "", "true":
+		return true, ""
+	}
+	return true, value
+}
+
+// YAMLValue encodes v as YAML, for values of extensions and examples.
+func YAMLValue(v interface{}) string {
+	bytes, err := yaml.Marshal(v)
+	if err != nil {
+		return ""
+	}
+	return string(bytes)
+}
+
 func FileExists(filePath string) bool {
 	_, err := os.Stat(filePath)
 	return err == nil
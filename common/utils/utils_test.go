@@ -0,0 +1,59 @@
+/*
+ * Copyright 2024 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package utils
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestUnpackArgs(t *testing.T) {
+	for field, want := range map[string]string{
+		"ServicePrefix": "service_prefix",
+		"RPCTimeout":    "rpc_timeout",
+		"BaseFieldID":   "base_field_id",
+		"IdlName":       "idl_name",
+		"Lint":          "lint",
+		"lint_disable":  "lint_disable",
+	} {
+		if got := ArgName(field); got != want {
+			t.Errorf("ArgName(%s) = %s, want %s", field, got, want)
+		}
+	}
+
+	var a struct {
+		Lint          bool
+		LintDisable   []string
+		ServicePrefix bool
+		RPCTimeout    string
+		BaseFieldID   int
+		deprecated    []string
+	}
+	args := []string{"lint=true", "lint_disable=a;b", "ServicePrefix=true", "rpc_timeout=3s", "BaseFieldID=254"}
+	if err := UnpackArgs(args, &a); err != nil {
+		t.Fatalf("UnpackArgs() error = %s", err)
+	}
+	if !a.Lint || !reflect.DeepEqual(a.LintDisable, []string{"a", "b"}) || !a.ServicePrefix || a.RPCTimeout != "3s" || a.BaseFieldID != 254 {
+		t.Errorf("UnpackArgs() = %+v", a)
+	}
+	if got, want := DeprecatedArgs(args), []string{"ServicePrefix", "BaseFieldID"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("DeprecatedArgs() = %v, want %v", got, want)
+	}
+	if err := UnpackArgs([]string{"lint=true", "Lint=false"}, &a); err == nil {
+		t.Errorf("UnpackArgs() error = nil, want an error for an option given by both spellings")
+	}
+}
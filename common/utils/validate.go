@@ -0,0 +1,259 @@
+/*
+ * Copyright 2024 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package utils
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ValidationError is a violation of the OpenAPI 3.0 rules in a document.
+type ValidationError struct {
+	// Pointer is the JSON pointer of the invalid element, e.g. `#/paths/~1pets~1{id}/get`.
+	Pointer string
+	Message string
+}
+
+func (e *ValidationError) Error() string {
+	return e.Pointer + ": " + e.Message
+}
+
+// ValidateDocument checks the structural rules of OpenAPI 3.0 on a document node:
+// required fields are present, local `$ref`s resolve, operations have responses and unique IDs,
+// path templates are valid, parameter names are unique and path parameters are declared and required.
+func ValidateDocument(document *yaml.Node) []*ValidationError {
+	if document != nil && document.Kind == yaml.DocumentNode && len(document.Content) == 1 {
+		document = document.Content[0]
+	}
+	v := &validator{root: document, operationIDs: make(map[string]string)}
+	v.validateRequired(document, "#", "openapi", "info", "paths")
+	v.validateRequired(mappingValue(document, "info"), "#/info", "title", "version")
+	v.validateRefs(document, "#")
+	forEachMapping(mappingValue(document, "paths"), func(path string, pathItem *yaml.Node) {
+		v.validatePath(path, pathItem)
+	})
+	return v.errors
+}
+
+type validator struct {
+	root   *yaml.Node
+	errors []*ValidationError
+	// operationIDs are the pointers of the operations by ID.
+	operationIDs map[string]string
+}
+
+func (v *validator) errorf(pointer, format string, a ...interface{}) {
+	v.errors = append(v.errors, &ValidationError{Pointer: pointer, Message: fmt.Sprintf(format, a...)})
+}
+
+// validateRequired checks that a map has the required keys, if it is present.
+func (v *validator) validateRequired(node *yaml.Node, pointer string, keys ...string) {
+	if node == nil || node.Kind != yaml.MappingNode {
+		return
+	}
+	for _, key := range keys {
+		if mappingValue(node, key) == nil {
+			v.errorf(pointer, "missing required field %s", key)
+		}
+	}
+}
+
+// validateRefs checks that the local `$ref`s of a node resolve in the document.
+func (v *validator) validateRefs(node *yaml.Node, pointer string) {
+	if node == nil {
+		return
+	}
+	switch node.Kind {
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key, value := node.Content[i].Value, node.Content[i+1]
+			if key == "$ref" && value.Kind == yaml.ScalarNode {
+				if strings.HasPrefix(value.Value, "#") && resolvePointer(v.root, value.Value) == nil {
+					v.errorf(pointer, "unresolved $ref %s", value.Value)
+				}
+				continue
+			}
+			v.validateRefs(value, pointer+"/"+escapePointer(key))
+		}
+	case yaml.SequenceNode:
+		for i, item := range node.Content {
+			v.validateRefs(item, fmt.Sprintf("%s/%d", pointer, i))
+		}
+	}
+}
+
+// validatePath checks the template of a path and its operations.
+func (v *validator) validatePath(path string, pathItem *yaml.Node) {
+	pointer := "#/paths/" + escapePointer(path)
+	templates, ok := pathTemplates(path)
+	if !ok {
+		v.errorf(pointer, "invalid path template %s", path)
+	}
+
+	pathParameters := v.parameters(mappingValue(pathItem, "parameters"), pointer+"/parameters")
+	forEachMapping(pathItem, func(method string, operation *yaml.Node) {
+		if !isOperationKey(method) {
+			return
+		}
+		operationPointer := pointer + "/" + method
+		if id := mappingValue(operation, "operationId"); id != nil {
+			if first, ok := v.operationIDs[id.Value]; ok {
+				v.errorf(operationPointer, "duplicate operationId %s, already used by %s", id.Value, first)
+			} else {
+				v.operationIDs[id.Value] = operationPointer
+			}
+		}
+		responses := mappingValue(operation, "responses")
+		if responses == nil || len(responses.Content) == 0 {
+			v.errorf(operationPointer, "missing responses")
+		}
+		forEachMapping(responses, func(code string, response *yaml.Node) {
+			if mappingValue(response, "$ref") == nil {
+				v.validateRequired(response, operationPointer+"/responses/"+escapePointer(code), "description")
+			}
+		})
+
+		// The parameters of the operation override the ones of the path with the same name and location.
+		parameters := make(map[string]*yaml.Node)
+		for key, parameter := range pathParameters {
+			parameters[key] = parameter
+		}
+		operationParameters := v.parameters(mappingValue(operation, "parameters"), operationPointer+"/parameters")
+		for key, parameter := range operationParameters {
+			parameters[key] = parameter
+		}
+		for _, key := range sortedKeys(operationParameters) {
+			v.validatePathParameter(key, templates, operationPointer+"/parameters")
+		}
+		for _, name := range templates {
+			if parameters["path:"+name] == nil {
+				v.errorf(operationPointer, "missing path parameter %s", name)
+			}
+		}
+	})
+
+	for _, key := range sortedKeys(pathParameters) {
+		v.validatePathParameter(key, templates, pointer+"/parameters")
+	}
+}
+
+// validatePathParameter checks that a path parameter is in the path template.
+func (v *validator) validatePathParameter(key string, templates []string, pointer string) {
+	if name := strings.TrimPrefix(key, "path:"); name != key && !Contains(templates, name) {
+		v.errorf(pointer, "path parameter %s is not in the path template", name)
+	}
+}
+
+// parameters checks a list of parameters and returns them by location and name.
+func (v *validator) parameters(list *yaml.Node, pointer string) map[string]*yaml.Node {
+	parameters := make(map[string]*yaml.Node)
+	if list == nil || list.Kind != yaml.SequenceNode {
+		return parameters
+	}
+	for i, parameter := range list.Content {
+		parameterPointer := fmt.Sprintf("%s/%d", pointer, i)
+		if ref := mappingValue(parameter, "$ref"); ref != nil {
+			parameter = resolvePointer(v.root, ref.Value)
+		}
+		name, in := mappingValue(parameter, "name"), mappingValue(parameter, "in")
+		if name == nil || in == nil {
+			v.validateRequired(parameter, parameterPointer, "name", "in")
+			continue
+		}
+		key := in.Value + ":" + name.Value
+		if parameters[key] != nil {
+			v.errorf(parameterPointer, "duplicate %s parameter %s", in.Value, name.Value)
+		}
+		parameters[key] = parameter
+		if required := mappingValue(parameter, "required"); in.Value == "path" && (required == nil || required.Value != "true") {
+			v.errorf(parameterPointer, "path parameter %s must be required", name.Value)
+		}
+	}
+	return parameters
+}
+
+// pathTemplates returns the names of the templates of a path, and whether the path is valid.
+func pathTemplates(path string) ([]string, bool) {
+	if !strings.HasPrefix(path, "/") {
+		return nil, false
+	}
+	var names []string
+	for rest := path; ; {
+		start := strings.IndexAny(rest, "{}")
+		if start < 0 {
+			return names, true
+		}
+		if rest[start] == '}' {
+			return names, false
+		}
+		end := strings.IndexAny(rest[start+1:], "{}")
+		if end <= 0 || rest[start+1+end] != '}' {
+			return names, false
+		}
+		name := rest[start+1 : start+1+end]
+		if Contains(names, name) {
+			return names, false
+		}
+		names = append(names, name)
+		rest = rest[start+end+2:]
+	}
+}
+
+// resolvePointer returns the node of a local JSON pointer like `#/components/schemas/Pet`, or nil.
+func resolvePointer(root *yaml.Node, pointer string) *yaml.Node {
+	if !strings.HasPrefix(pointer, "#") {
+		return nil
+	}
+	node := root
+	for _, token := range strings.Split(strings.TrimPrefix(pointer, "#"), "/")[1:] {
+		token = strings.NewReplacer("~1", "/", "~0", "~").Replace(token)
+		switch node.Kind {
+		case yaml.MappingNode:
+			node = mappingValue(node, token)
+		case yaml.SequenceNode:
+			var index int
+			if _, err := fmt.Sscanf(token, "%d", &index); err != nil || index < 0 || index >= len(node.Content) {
+				return nil
+			}
+			node = node.Content[index]
+		default:
+			return nil
+		}
+		if node == nil {
+			return nil
+		}
+	}
+	return node
+}
+
+// sortedKeys returns the keys of a map in order.
+func sortedKeys(m map[string]*yaml.Node) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// escapePointer escapes a key for a JSON pointer.
+func escapePointer(key string) string {
+	return strings.NewReplacer("~", "~0", "/", "~1").Replace(key)
+}
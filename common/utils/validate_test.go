@@ -0,0 +1,150 @@
+/*
+ * Copyright 2024 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package utils
+
+import (
+	"reflect"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+// validDocument is prepended to the paths of the documents of the tests.
+const validDocument = `openapi: 3.0.3
+info: {title: pets, version: 1.0.0}
+components:
+  schemas:
+    Pet: {type: object}
+  parameters:
+    id: {name: id, in: path, required: true}
+`
+
+func TestValidateDocument(t *testing.T) {
+	tests := []struct {
+		name     string
+		document string
+		want     []string
+	}{
+		{
+			name: "valid",
+			document: validDocument + `paths:
+  /pets/{id}:
+    parameters: [{$ref: '#/components/parameters/id'}]
+    get:
+      operationId: GetPet
+      responses:
+        '200':
+          description: ok
+          content: {application/json: {schema: {$ref: '#/components/schemas/Pet'}}}
+`,
+		},
+		{
+			name:     "missing required fields",
+			document: "openapi: 3.0.3\ninfo: {title: pets}\n",
+			want:     []string{"#: missing required field paths", "#/info: missing required field version"},
+		},
+		{
+			name: "unresolved references",
+			document: validDocument + `paths:
+  /pets:
+    get:
+      parameters: [{$ref: '#/components/parameters/limit'}]
+      responses:
+        '200':
+          description: ok
+          content: {application/json: {schema: {$ref: '#/components/schemas/Pets'}}}
+        default: {$ref: 'errors.yaml#/Error'}
+`,
+			want: []string{
+				"#/paths/~1pets/get/parameters/0: unresolved $ref #/components/parameters/limit",
+				"#/paths/~1pets/get/responses/200/content/application~1json/schema: unresolved $ref #/components/schemas/Pets",
+			},
+		},
+		{
+			name: "invalid operations",
+			document: validDocument + `paths:
+  /pets:
+    get: {operationId: ListPets}
+    post:
+      operationId: ListPets
+      parameters: [{name: limit}]
+      responses: {'200': {content: {}}}
+`,
+			want: []string{
+				"#/paths/~1pets/get: missing responses",
+				"#/paths/~1pets/post: duplicate operationId ListPets, already used by #/paths/~1pets/get",
+				"#/paths/~1pets/post/responses/200: missing required field description",
+				"#/paths/~1pets/post/parameters/0: missing required field in",
+			},
+		},
+		{
+			name: "invalid path templates",
+			document: validDocument + `paths:
+  pets: {}
+  /pets/{id: {}
+  /pets/{id}/{id}: {}
+  /pets}: {}
+`,
+			want: []string{
+				"#/paths/pets: invalid path template pets",
+				"#/paths/~1pets~1{id: invalid path template /pets/{id",
+				"#/paths/~1pets~1{id}~1{id}: invalid path template /pets/{id}/{id}",
+				"#/paths/~1pets}: invalid path template /pets}",
+			},
+		},
+		{
+			name: "invalid parameters",
+			document: validDocument + `paths:
+  /pets/{id}:
+    parameters: [{name: id, in: path}]
+    get:
+      parameters: [{name: limit, in: query}, {name: limit, in: query}, {name: limit, in: header}, {name: name, in: path, required: true}]
+      responses: {'200': {description: ok}}
+    put:
+      parameters: [{$ref: '#/components/parameters/id'}]
+      responses: {'200': {description: ok}}
+  /pets:
+    get:
+      responses: {'200': {description: ok}}
+  /pets/{name}/tags:
+    get:
+      responses: {'200': {description: ok}}
+`,
+			want: []string{
+				"#/paths/~1pets~1{id}/parameters/0: path parameter id must be required",
+				"#/paths/~1pets~1{id}/get/parameters/1: duplicate query parameter limit",
+				"#/paths/~1pets~1{id}/get/parameters: path parameter name is not in the path template",
+				"#/paths/~1pets~1{name}~1tags/get: missing path parameter name",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var document yaml.Node
+			if err := yaml.Unmarshal([]byte(tt.document), &document); err != nil {
+				t.Fatalf("failed to parse document: %s", err)
+			}
+			var got []string
+			for _, err := range ValidateDocument(&document) {
+				got = append(got, err.Error())
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ValidateDocument() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -76,6 +76,38 @@ var file_annotations_proto_extTypes = []protoimpl.ExtensionInfo{
 		Tag:           "bytes,1143,opt,name=property",
 		Filename:      "annotations.proto",
 	},
+	{
+		ExtendedType:  (*descriptorpb.FieldOptions)(nil),
+		ExtensionType: (*string)(nil),
+		Field:         1145,
+		Name:          "openapi.v3.component",
+		Tag:           "bytes,1145,opt,name=component",
+		Filename:      "annotations.proto",
+	},
+	{
+		ExtendedType:  (*descriptorpb.ServiceOptions)(nil),
+		ExtensionType: (*string)(nil),
+		Field:         1146,
+		Name:          "openapi.v3.service_visibility",
+		Tag:           "bytes,1146,opt,name=service_visibility",
+		Filename:      "annotations.proto",
+	},
+	{
+		ExtendedType:  (*descriptorpb.MethodOptions)(nil),
+		ExtensionType: (*string)(nil),
+		Field:         1146,
+		Name:          "openapi.v3.method_visibility",
+		Tag:           "bytes,1146,opt,name=method_visibility",
+		Filename:      "annotations.proto",
+	},
+	{
+		ExtendedType:  (*descriptorpb.FieldOptions)(nil),
+		ExtensionType: (*string)(nil),
+		Field:         1146,
+		Name:          "openapi.v3.field_visibility",
+		Tag:           "bytes,1146,opt,name=field_visibility",
+		Filename:      "annotations.proto",
+	},
 }
 
 // Extension fields to descriptorpb.FileOptions.
@@ -88,6 +120,8 @@ var (
 var (
 	// optional openapi.v3.Operation operation = 1143;
 	E_Operation = &file_annotations_proto_extTypes[1]
+	// optional string method_visibility = 1146;
+	E_MethodVisibility = &file_annotations_proto_extTypes[7]
 )
 
 // Extension fields to descriptorpb.MessageOptions.
@@ -102,6 +136,16 @@ var (
 	E_Parameter = &file_annotations_proto_extTypes[3]
 	// optional openapi.v3.Schema property = 1143;
 	E_Property = &file_annotations_proto_extTypes[4]
+	// optional string component = 1145;
+	E_Component = &file_annotations_proto_extTypes[5]
+	// optional string field_visibility = 1146;
+	E_FieldVisibility = &file_annotations_proto_extTypes[8]
+)
+
+// Extension fields to descriptorpb.ServiceOptions.
+var (
+	// optional string service_visibility = 1146;
+	E_ServiceVisibility = &file_annotations_proto_extTypes[6]
 )
 
 var File_annotations_proto protoreflect.FileDescriptor
@@ -138,13 +182,32 @@ var file_annotations_proto_rawDesc = []byte{
 	0x6f, 0x62, 0x75, 0x66, 0x2e, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e,
 	0x73, 0x18, 0xf7, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x6f, 0x70, 0x65, 0x6e, 0x61,
 	0x70, 0x69, 0x2e, 0x76, 0x33, 0x2e, 0x53, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x52, 0x08, 0x70, 0x72,
-	0x6f, 0x70, 0x65, 0x72, 0x74, 0x79, 0x42, 0x5a, 0x0a, 0x0e, 0x6f, 0x72, 0x67, 0x2e, 0x6f, 0x70,
-	0x65, 0x6e, 0x61, 0x70, 0x69, 0x5f, 0x76, 0x33, 0x42, 0x10, 0x41, 0x6e, 0x6e, 0x6f, 0x74, 0x61,
-	0x74, 0x69, 0x6f, 0x6e, 0x73, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x50, 0x01, 0x5a, 0x2e, 0x67, 0x69,
-	0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f,
-	0x67, 0x6e, 0x6f, 0x73, 0x74, 0x69, 0x63, 0x2f, 0x6f, 0x70, 0x65, 0x6e, 0x61, 0x70, 0x69, 0x76,
-	0x33, 0x3b, 0x6f, 0x70, 0x65, 0x6e, 0x61, 0x70, 0x69, 0x5f, 0x76, 0x33, 0xa2, 0x02, 0x03, 0x4f,
-	0x41, 0x53, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x6f, 0x70, 0x65, 0x72, 0x74, 0x79, 0x3a, 0x3c, 0x0a, 0x09, 0x63, 0x6f, 0x6d, 0x70, 0x6f, 0x6e,
+	0x65, 0x6e, 0x74, 0x12, 0x1d, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x4f, 0x70, 0x74, 0x69, 0x6f,
+	0x6e, 0x73, 0x18, 0xf9, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x63, 0x6f, 0x6d, 0x70, 0x6f,
+	0x6e, 0x65, 0x6e, 0x74, 0x3a, 0x4f, 0x0a, 0x12, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x5f,
+	0x76, 0x69, 0x73, 0x69, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x12, 0x1f, 0x2e, 0x67, 0x6f, 0x6f,
+	0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x53, 0x65, 0x72,
+	0x76, 0x69, 0x63, 0x65, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0xfa, 0x08, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x11, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x56, 0x69, 0x73, 0x69, 0x62,
+	0x69, 0x6c, 0x69, 0x74, 0x79, 0x3a, 0x4c, 0x0a, 0x11, 0x6d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x5f,
+	0x76, 0x69, 0x73, 0x69, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x12, 0x1e, 0x2e, 0x67, 0x6f, 0x6f,
+	0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x4d, 0x65, 0x74,
+	0x68, 0x6f, 0x64, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0xfa, 0x08, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x10, 0x6d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x56, 0x69, 0x73, 0x69, 0x62, 0x69, 0x6c,
+	0x69, 0x74, 0x79, 0x3a, 0x49, 0x0a, 0x10, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x5f, 0x76, 0x69, 0x73,
+	0x69, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x12, 0x1d, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x4f,
+	0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0xfa, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0f, 0x66,
+	0x69, 0x65, 0x6c, 0x64, 0x56, 0x69, 0x73, 0x69, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x42, 0x5a,
+	0x0a, 0x0e, 0x6f, 0x72, 0x67, 0x2e, 0x6f, 0x70, 0x65, 0x6e, 0x61, 0x70, 0x69, 0x5f, 0x76, 0x33,
+	0x42, 0x10, 0x41, 0x6e, 0x6e, 0x6f, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x50, 0x72, 0x6f,
+	0x74, 0x6f, 0x50, 0x01, 0x5a, 0x2e, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d,
+	0x2f, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x67, 0x6e, 0x6f, 0x73, 0x74, 0x69, 0x63, 0x2f,
+	0x6f, 0x70, 0x65, 0x6e, 0x61, 0x70, 0x69, 0x76, 0x33, 0x3b, 0x6f, 0x70, 0x65, 0x6e, 0x61, 0x70,
+	0x69, 0x5f, 0x76, 0x33, 0xa2, 0x02, 0x03, 0x4f, 0x41, 0x53, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x33,
 }
 
 var file_annotations_proto_goTypes = []interface{}{
@@ -152,10 +215,11 @@ var file_annotations_proto_goTypes = []interface{}{
 	(*descriptorpb.MethodOptions)(nil),  // 1: google.protobuf.MethodOptions
 	(*descriptorpb.MessageOptions)(nil), // 2: google.protobuf.MessageOptions
 	(*descriptorpb.FieldOptions)(nil),   // 3: google.protobuf.FieldOptions
-	(*Document)(nil),                    // 4: openapi.v3.Document
-	(*Operation)(nil),                   // 5: openapi.v3.Operation
-	(*Schema)(nil),                      // 6: openapi.v3.Schema
-	(*Parameter)(nil),                   // 7: openapi.v3.Parameter
+	(*descriptorpb.ServiceOptions)(nil), // 4: google.protobuf.ServiceOptions
+	(*Document)(nil),                    // 5: openapi.v3.Document
+	(*Operation)(nil),                   // 6: openapi.v3.Operation
+	(*Schema)(nil),                      // 7: openapi.v3.Schema
+	(*Parameter)(nil),                   // 8: openapi.v3.Parameter
 }
 var file_annotations_proto_depIdxs = []int32{
 	0,  // 0: openapi.v3.document:extendee -> google.protobuf.FileOptions
@@ -163,15 +227,19 @@ var file_annotations_proto_depIdxs = []int32{
 	2,  // 2: openapi.v3.schema:extendee -> google.protobuf.MessageOptions
 	3,  // 3: openapi.v3.parameter:extendee -> google.protobuf.FieldOptions
 	3,  // 4: openapi.v3.property:extendee -> google.protobuf.FieldOptions
-	4,  // 5: openapi.v3.document:type_name -> openapi.v3.Document
-	5,  // 6: openapi.v3.operation:type_name -> openapi.v3.Operation
-	6,  // 7: openapi.v3.schema:type_name -> openapi.v3.Schema
-	7,  // 8: openapi.v3.parameter:type_name -> openapi.v3.Parameter
-	6,  // 9: openapi.v3.property:type_name -> openapi.v3.Schema
-	10, // [10:10] is the sub-list for method output_type
-	10, // [10:10] is the sub-list for method input_type
-	5,  // [5:10] is the sub-list for extension type_name
-	0,  // [0:5] is the sub-list for extension extendee
+	3,  // 5: openapi.v3.component:extendee -> google.protobuf.FieldOptions
+	4,  // 6: openapi.v3.service_visibility:extendee -> google.protobuf.ServiceOptions
+	1,  // 7: openapi.v3.method_visibility:extendee -> google.protobuf.MethodOptions
+	3,  // 8: openapi.v3.field_visibility:extendee -> google.protobuf.FieldOptions
+	5,  // 9: openapi.v3.document:type_name -> openapi.v3.Document
+	6,  // 10: openapi.v3.operation:type_name -> openapi.v3.Operation
+	7,  // 11: openapi.v3.schema:type_name -> openapi.v3.Schema
+	8,  // 12: openapi.v3.parameter:type_name -> openapi.v3.Parameter
+	7,  // 13: openapi.v3.property:type_name -> openapi.v3.Schema
+	14, // [14:14] is the sub-list for method output_type
+	14, // [14:14] is the sub-list for method input_type
+	9,  // [9:14] is the sub-list for extension type_name
+	0,  // [0:9] is the sub-list for extension extendee
 	0,  // [0:0] is the sub-list for field type_name
 }
 
@@ -187,7 +255,7 @@ func file_annotations_proto_init() {
 			RawDescriptor: file_annotations_proto_rawDesc,
 			NumEnums:      0,
 			NumMessages:   0,
-			NumExtensions: 5,
+			NumExtensions: 9,
 			NumServices:   0,
 		},
 		GoTypes:           file_annotations_proto_goTypes,
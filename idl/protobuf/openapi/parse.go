@@ -0,0 +1,229 @@
+/*
+ * Copyright 2024 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package openapi
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+
+	common "github.com/hertz-contrib/swagger-generate/common/utils"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"gopkg.in/yaml.v3"
+)
+
+// ParseDocument reads an OpenAPI v3 document from its YAML or JSON representation.
+func ParseDocument(b []byte) (*Document, error) {
+	var node yaml.Node
+	if err := yaml.Unmarshal(b, &node); err != nil {
+		return nil, fmt.Errorf("failed to parse document: %s", err)
+	}
+	return ParseDocumentNode(&node)
+}
+
+// ParseDocumentNode reads an OpenAPI v3 document from a YAML node, the reverse of ToRawInfo.
+func ParseDocumentNode(node *yaml.Node) (*Document, error) {
+	if node.Kind == yaml.DocumentNode && len(node.Content) == 1 {
+		node = node.Content[0]
+	}
+	d := &Document{}
+	if err := readMessage(node, d.ProtoReflect()); err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+// Validate checks the document against the structural rules of OpenAPI 3.0.
+func (m *Document) Validate() []*common.ValidationError {
+	return common.ValidateDocument(m.ToRawInfo())
+}
+
+// readMessage reads a message from a node, following the shapes written by ToRawInfo:
+// `Any` holds any node, the messages made of a oneof are one of their fields,
+// the `additional_properties`, `path` and `response_or_reference` fields are the entries
+// of a map, and the other fields are the camel case keys of a map.
+func readMessage(node *yaml.Node, m protoreflect.Message) error {
+	desc := m.Descriptor()
+	fields := desc.Fields()
+	switch {
+	case desc.Name() == "Any":
+		value, err := yaml.Marshal(node)
+		if err != nil {
+			return nodeError(node, "%s", err)
+		}
+		m.Set(fields.ByName("yaml"), protoreflect.ValueOfString(string(value)))
+		return nil
+	case desc.Oneofs().Len() == 1 && desc.Oneofs().Get(0).Fields().Len() == fields.Len():
+		return readOneof(node, m)
+	case desc.Name() == "ItemsItem" && node.Kind != yaml.SequenceNode:
+		return readValue(&yaml.Node{Kind: yaml.SequenceNode, Content: []*yaml.Node{node}}, m, fields.ByName("schema_or_reference"))
+	case desc.Name() == "StringArray":
+		return readValue(node, m, fields.ByName("value"))
+	}
+
+	if node.Kind != yaml.MappingNode {
+		return nodeError(node, "expected a map for %s", desc.Name())
+	}
+	var entries protoreflect.FieldDescriptor
+	for _, name := range []protoreflect.Name{"additional_properties", "path", "response_or_reference"} {
+		if fd := fields.ByName(name); fd != nil && fd.IsList() {
+			entries = fd
+		}
+	}
+	extensions := fields.ByName("specification_extension")
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key, value := node.Content[i], node.Content[i+1]
+		fd := fields.ByName(protoreflect.Name(fieldName(key.Value)))
+		switch {
+		case extensions != nil && strings.HasPrefix(key.Value, "x-"):
+			fd = extensions
+		case fd == nil && entries != nil:
+			fd = entries
+		case fd == nil || fd == extensions || fd == entries:
+			return nodeError(key, "unknown key %s in %s", key.Value, desc.Name())
+		default:
+			if err := readValue(value, m, fd); err != nil {
+				return err
+			}
+			continue
+		}
+		// The entries of maps are named values.
+		list := m.Mutable(fd).List()
+		entry := list.NewElement()
+		entryFields := entry.Message().Descriptor().Fields()
+		entry.Message().Set(entryFields.ByName("name"), protoreflect.ValueOfString(key.Value))
+		if err := readValue(value, entry.Message(), entryFields.ByName("value")); err != nil {
+			return err
+		}
+		list.Append(entry)
+	}
+	return nil
+}
+
+// readOneof reads a message made of a oneof, which is a reference if the node has a `$ref`.
+func readOneof(node *yaml.Node, m protoreflect.Message) error {
+	fields := m.Descriptor().Fields()
+	if reference := fields.ByName("reference"); reference != nil && node.Kind == yaml.MappingNode {
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			if node.Content[i].Value == "$ref" {
+				return readValue(node, m, reference)
+			}
+		}
+	}
+	for i := 0; i < fields.Len(); i++ {
+		fd := fields.Get(i)
+		matched := false
+		switch fd.Kind() {
+		case protoreflect.MessageKind:
+			matched = fd.Name() != "reference" && (node.Kind == yaml.MappingNode || fd.Message().Name() == "Any")
+		case protoreflect.BoolKind:
+			matched = node.Kind == yaml.ScalarNode && node.Tag == "!!bool"
+		case protoreflect.DoubleKind:
+			matched = node.Kind == yaml.ScalarNode && (node.Tag == "!!int" || node.Tag == "!!float")
+		case protoreflect.StringKind:
+			matched = node.Kind == yaml.ScalarNode
+		}
+		if matched {
+			return readValue(node, m, fd)
+		}
+	}
+	return nodeError(node, "invalid value for %s", m.Descriptor().Name())
+}
+
+// readValue reads the value of a field from a node.
+func readValue(node *yaml.Node, m protoreflect.Message, fd protoreflect.FieldDescriptor) error {
+	if !fd.IsList() {
+		value, err := readSingular(node, m, fd)
+		if err != nil {
+			return err
+		}
+		m.Set(fd, value)
+		return nil
+	}
+	if node.Kind != yaml.SequenceNode {
+		return nodeError(node, "expected a list for %s", fd.Name())
+	}
+	list := m.Mutable(fd).List()
+	for _, item := range node.Content {
+		value, err := readSingular(item, m, fd)
+		if err != nil {
+			return err
+		}
+		list.Append(value)
+	}
+	return nil
+}
+
+// readSingular reads a single value of a field from a node.
+func readSingular(node *yaml.Node, m protoreflect.Message, fd protoreflect.FieldDescriptor) (protoreflect.Value, error) {
+	if fd.Kind() == protoreflect.MessageKind {
+		var value protoreflect.Value
+		if fd.IsList() {
+			value = m.Mutable(fd).List().NewElement()
+		} else {
+			value = m.NewField(fd)
+		}
+		return value, readMessage(node, value.Message())
+	}
+	if node.Kind != yaml.ScalarNode {
+		return protoreflect.Value{}, nodeError(node, "expected a scalar for %s", fd.Name())
+	}
+	switch fd.Kind() {
+	case protoreflect.StringKind:
+		return protoreflect.ValueOfString(node.Value), nil
+	case protoreflect.BoolKind:
+		v, err := strconv.ParseBool(node.Value)
+		if err != nil {
+			return protoreflect.Value{}, nodeError(node, "expected a boolean for %s", fd.Name())
+		}
+		return protoreflect.ValueOfBool(v), nil
+	case protoreflect.DoubleKind:
+		v, err := strconv.ParseFloat(node.Value, 64)
+		if err != nil {
+			return protoreflect.Value{}, nodeError(node, "expected a number for %s", fd.Name())
+		}
+		return protoreflect.ValueOfFloat64(v), nil
+	case protoreflect.Int64Kind:
+		v, err := strconv.ParseInt(node.Value, 10, 64)
+		if err != nil {
+			return protoreflect.Value{}, nodeError(node, "expected an integer for %s", fd.Name())
+		}
+		return protoreflect.ValueOfInt64(v), nil
+	}
+	return protoreflect.Value{}, nodeError(node, "unsupported field %s", fd.Name())
+}
+
+// fieldName returns the field name of a key, e.g. `operation_id` for `operationId` and `_ref` for `$ref`.
+func fieldName(key string) string {
+	if key == "$ref" {
+		return "_ref"
+	}
+	var b strings.Builder
+	for _, r := range key {
+		if unicode.IsUpper(r) {
+			b.WriteByte('_')
+			r = unicode.ToLower(r)
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+func nodeError(node *yaml.Node, format string, a ...interface{}) error {
+	return fmt.Errorf("line %d: %s", node.Line, fmt.Sprintf(format, a...))
+}
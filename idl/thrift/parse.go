@@ -0,0 +1,272 @@
+/*
+ * Copyright 2024 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package openapi
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"unicode"
+
+	common "github.com/hertz-contrib/swagger-generate/common/utils"
+	"gopkg.in/yaml.v3"
+)
+
+// oneofStructs are the structs of the oneofs of the OpenAPI messages, ToRawInfo writes one of their fields.
+var oneofStructs = map[reflect.Type]bool{
+	reflect.TypeOf(AdditionalPropertiesItem{}):  true,
+	reflect.TypeOf(AnyOrExpression{}):           true,
+	reflect.TypeOf(CallbackOrReference{}):       true,
+	reflect.TypeOf(DefaultType{}):               true,
+	reflect.TypeOf(ExampleOrReference{}):        true,
+	reflect.TypeOf(HeaderOrReference{}):         true,
+	reflect.TypeOf(LinkOrReference{}):           true,
+	reflect.TypeOf(ParameterOrReference{}):      true,
+	reflect.TypeOf(RequestBodyOrReference{}):    true,
+	reflect.TypeOf(ResponseOrReference{}):       true,
+	reflect.TypeOf(SchemaOrReference{}):         true,
+	reflect.TypeOf(SecuritySchemeOrReference{}): true,
+	reflect.TypeOf(SpecificationExtension{}):    true,
+}
+
+// ParseDocument reads an OpenAPI v3 document from its YAML or JSON representation.
+func ParseDocument(b []byte) (*Document, error) {
+	var node yaml.Node
+	if err := yaml.Unmarshal(b, &node); err != nil {
+		return nil, fmt.Errorf("failed to parse document: %s", err)
+	}
+	return ParseDocumentNode(&node)
+}
+
+// ParseDocumentNode reads an OpenAPI v3 document from a YAML node, the reverse of ToRawInfo.
+func ParseDocumentNode(node *yaml.Node) (*Document, error) {
+	if node.Kind == yaml.DocumentNode && len(node.Content) == 1 {
+		node = node.Content[0]
+	}
+	d := &Document{}
+	if err := readStruct(node, reflect.ValueOf(d).Elem()); err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+// Validate checks the document against the structural rules of OpenAPI 3.0.
+func (m *Document) Validate() []*common.ValidationError {
+	return common.ValidateDocument(m.ToRawInfo())
+}
+
+// readStruct reads a struct from a node, following the shapes written by ToRawInfo:
+// `Any` holds any node, the structs of oneofs are one of their fields,
+// the `additional_properties`, `path` and `response_or_reference` fields are the entries
+// of a map, and the other fields are the camel case keys of a map.
+func readStruct(node *yaml.Node, v reflect.Value) error {
+	t := v.Type()
+	switch {
+	case t == reflect.TypeOf(Any{}):
+		value, err := yaml.Marshal(node)
+		if err != nil {
+			return nodeError(node, "%s", err)
+		}
+		v.FieldByName("Yaml").SetString(string(value))
+		return nil
+	case oneofStructs[t]:
+		return readOneof(node, v)
+	case t == reflect.TypeOf(ItemsItem{}) && node.Kind != yaml.SequenceNode:
+		return readValue(&yaml.Node{Kind: yaml.SequenceNode, Content: []*yaml.Node{node}}, v.FieldByName("SchemaOrReference"))
+	case t == reflect.TypeOf(StringArray{}):
+		return readValue(node, v.FieldByName("Values"))
+	}
+
+	if node.Kind != yaml.MappingNode {
+		return nodeError(node, "expected a map for %s", t.Name())
+	}
+	fields := make(map[string]int, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		// The fields named after thrift keywords have a leading underscore.
+		name := strings.Split(t.Field(i).Tag.Get("thrift"), ",")[0]
+		fields[strings.TrimPrefix(name, "_")] = i
+	}
+	entries, extensions := -1, -1
+	for _, name := range []string{"additional_properties", "path", "response_or_reference"} {
+		if i, ok := fields[name]; ok && t.Field(i).Type.Kind() == reflect.Slice {
+			entries = i
+		}
+	}
+	if i, ok := fields["specification_extension"]; ok {
+		extensions = i
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key, value := node.Content[i], node.Content[i+1]
+		field, ok := fields[fieldName(key.Value)]
+		switch {
+		case extensions >= 0 && strings.HasPrefix(key.Value, "x-"):
+			field = extensions
+		case !ok && entries >= 0:
+			field = entries
+		case !ok || field == extensions || field == entries:
+			return nodeError(key, "unknown key %s in %s", key.Value, t.Name())
+		case !v.Field(field).CanSet():
+			if err := setKeywordField(v, key, value); err != nil {
+				return err
+			}
+			continue
+		default:
+			if err := readValue(value, v.Field(field)); err != nil {
+				return err
+			}
+			continue
+		}
+		// The entries of maps are named values.
+		list := v.Field(field)
+		entry := reflect.New(list.Type().Elem().Elem())
+		entry.Elem().FieldByName("Name").SetString(key.Value)
+		if err := readValue(value, entry.Elem().FieldByName("Value")); err != nil {
+			return err
+		}
+		list.Set(reflect.Append(list, entry))
+	}
+	return nil
+}
+
+// readOneof reads a struct of a oneof, which is a reference if the node has a `$ref`.
+func readOneof(node *yaml.Node, v reflect.Value) error {
+	t := v.Type()
+	if reference := v.FieldByName("Reference"); reference.IsValid() && node.Kind == yaml.MappingNode {
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			if node.Content[i].Value == "$ref" {
+				return readValue(node, reference)
+			}
+		}
+	}
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		matched := false
+		switch field.Type.Kind() {
+		case reflect.Ptr:
+			matched = field.Name != "Reference" && (node.Kind == yaml.MappingNode || field.Type.Elem() == reflect.TypeOf(Any{}))
+		case reflect.Bool:
+			matched = node.Kind == yaml.ScalarNode && node.Tag == "!!bool"
+		case reflect.Float64:
+			matched = node.Kind == yaml.ScalarNode && (node.Tag == "!!int" || node.Tag == "!!float")
+		case reflect.String:
+			matched = node.Kind == yaml.ScalarNode
+		}
+		if matched {
+			return readValue(node, v.Field(i))
+		}
+	}
+	return nodeError(node, "invalid value for %s", t.Name())
+}
+
+// readValue reads the value of a field from a node.
+func readValue(node *yaml.Node, field reflect.Value) error {
+	if field.Kind() != reflect.Slice {
+		return readSingular(node, field)
+	}
+	if node.Kind != yaml.SequenceNode {
+		return nodeError(node, "expected a list for %s", field.Type())
+	}
+	list := reflect.MakeSlice(field.Type(), len(node.Content), len(node.Content))
+	for i, item := range node.Content {
+		if err := readSingular(item, list.Index(i)); err != nil {
+			return err
+		}
+	}
+	field.Set(list)
+	return nil
+}
+
+// readSingular reads a single value of a field from a node.
+func readSingular(node *yaml.Node, field reflect.Value) error {
+	if field.Kind() == reflect.Ptr {
+		field.Set(reflect.New(field.Type().Elem()))
+		return readStruct(node, field.Elem())
+	}
+	if node.Kind != yaml.ScalarNode {
+		return nodeError(node, "expected a scalar for %s", field.Type())
+	}
+	switch field.Kind() {
+	case reflect.String:
+		field.SetString(node.Value)
+	case reflect.Bool:
+		v, err := strconv.ParseBool(node.Value)
+		if err != nil {
+			return nodeError(node, "expected a boolean")
+		}
+		field.SetBool(v)
+	case reflect.Float64:
+		v, err := strconv.ParseFloat(node.Value, 64)
+		if err != nil {
+			return nodeError(node, "expected a number")
+		}
+		field.SetFloat(v)
+	case reflect.Int64:
+		v, err := strconv.ParseInt(node.Value, 10, 64)
+		if err != nil {
+			return nodeError(node, "expected an integer")
+		}
+		field.SetInt(v)
+	default:
+		return nodeError(node, "unsupported field of type %s", field.Type())
+	}
+	return nil
+}
+
+// setKeywordField sets the fields named after thrift keywords, which are unexported with a leading underscore.
+func setKeywordField(v reflect.Value, key, value *yaml.Node) error {
+	if value.Kind != yaml.ScalarNode {
+		return nodeError(value, "expected a scalar for %s", key.Value)
+	}
+	switch d := v.Addr().Interface().(type) {
+	case *SecurityScheme:
+		switch key.Value {
+		case "type":
+			d._Type = value.Value
+			return nil
+		case "in":
+			d._In = value.Value
+			return nil
+		}
+	case *ServerVariable:
+		if key.Value == "default" {
+			d._Default = value.Value
+			return nil
+		}
+	}
+	return nodeError(key, "unknown key %s in %s", key.Value, v.Type().Name())
+}
+
+// fieldName returns the thrift name of the field of a key, e.g. `operation_id` for `operationId` and `xref` for `$ref`.
+func fieldName(key string) string {
+	if key == "$ref" {
+		return "xref"
+	}
+	var b strings.Builder
+	for _, r := range key {
+		if unicode.IsUpper(r) {
+			b.WriteByte('_')
+			r = unicode.ToLower(r)
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+func nodeError(node *yaml.Node, format string, a ...interface{}) error {
+	return fmt.Errorf("line %d: %s", node.Line, fmt.Sprintf(format, a...))
+}
@@ -0,0 +1,127 @@
+/*
+ * Copyright 2024 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package openapi
+
+import (
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+// parseDocument is written in the order of the fields of the model, so that it round-trips through ToRawInfo.
+const parseDocument = `openapi: 3.0.3
+info:
+    title: pets
+    version: 1.0.0
+servers:
+    - url: https://{host}
+      variables:
+        host:
+            default: example.com
+paths:
+    /pets/{id}:
+        get:
+            operationId: GetPet
+            parameters:
+                - name: id
+                  in: path
+                  required: true
+                  schema:
+                    type: string
+                    default: cat
+            responses:
+                default:
+                    $ref: '#/components/responses/Error'
+                "200":
+                    description: ok
+                    content:
+                        application/json:
+                            schema:
+                                $ref: '#/components/schemas/Pet'
+            x-internal: true
+components:
+    schemas:
+        Pet:
+            example:
+                tags: [cat]
+            type: object
+            properties:
+                tags:
+                    type: array
+                    items:
+                        type: string
+                owner:
+                    $ref: '#/components/schemas/Pet'
+            additionalProperties:
+                type: string
+    responses:
+        Error:
+            description: error
+    securitySchemes:
+        token:
+            type: apiKey
+            name: token
+            in: header
+`
+
+func TestParseDocument(t *testing.T) {
+	d, err := ParseDocument([]byte(parseDocument))
+	if err != nil {
+		t.Fatalf("ParseDocument() error = %s", err)
+	}
+	scheme := d.Components.SecuritySchemes.AdditionalProperties[0].Value.SecurityScheme
+	if scheme.Get_Type() != "apiKey" || scheme.Get_In() != "header" || d.Servers[0].Variables.AdditionalProperties[0].Value.Get_Default() != "example.com" {
+		t.Errorf("ParseDocument() did not read the fields named after keywords")
+	}
+
+	var want yaml.Node
+	if err = yaml.Unmarshal([]byte(parseDocument), &want); err != nil {
+		t.Fatal(err)
+	}
+	got, err := yaml.Marshal(d.ToRawInfo())
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantBytes, err := yaml.Marshal(want.Content[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != string(wantBytes) {
+		t.Errorf("ToRawInfo() of the parsed document =\n%s\nwant\n%s", got, wantBytes)
+	}
+}
+
+func TestParseDocumentErrors(t *testing.T) {
+	tests := []struct {
+		document string
+		err      string
+	}{
+		{"info: []", "line 1: expected a map for Info"},
+		{"info:\n  title: pets\n  owner: me", "line 3: unknown key owner in Info"},
+		{"paths:\n  /pets:\n    get:\n      deprecated: maybe", "line 4: expected a boolean"},
+		{"tags: {name: a}", "line 1: expected a list"},
+		{"components:\n  securitySchemes:\n    token:\n      type: [apiKey]", "line 4: expected a scalar for type"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.err, func(t *testing.T) {
+			if _, err := ParseDocument([]byte(tt.document)); err == nil || !strings.HasPrefix(err.Error(), tt.err) {
+				t.Errorf("ParseDocument() error = %v, want %q", err, tt.err)
+			}
+		})
+	}
+}
@@ -0,0 +1,148 @@
+//go:build noswagger
+
+/*
+ * Copyright 2024 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Code generated by protoc-gen-http-swagger.
+package swagger
+
+import (
+	"io/fs"
+
+	"github.com/cloudwego/hertz/pkg/app/server"
+	"github.com/hertz-contrib/cors"
+)
+
+// AccessOptions control the access to the swagger endpoints, and to the proxy of the RPC servers.
+type AccessOptions struct {
+	// Enabled serves the endpoints, true by default.
+	Enabled bool
+	// Username and Password require the basic authentication of the requests when Username is set.
+	Username string
+	Password string
+	// Token requires the requests to carry it as a bearer token when it is set. Either credential is accepted
+	// when both are required.
+	Token string
+	// AllowedIPs are the IPs and the CIDRs of the clients allowed, all of them if empty. The address of
+	// the connection is checked, not the forwarding headers.
+	AllowedIPs []string
+	// CORS is the CORS configuration of the endpoints, allowing all the origins by default.
+	CORS cors.Config
+}
+
+// UI is a UI of the documents served by the swagger endpoints.
+type UI string
+
+const (
+	SwaggerUI UI = "swagger-ui"
+	Redoc     UI = "redoc"
+	RapiDoc   UI = "rapidoc"
+	Scalar    UI = "scalar"
+)
+
+// Document is an OpenAPI document served by the swagger endpoints besides the generated ones, e.g. the document
+// of another package, listed by its name in the document selector of the UI.
+type Document struct {
+	Name string
+	// YAML is the content of the document.
+	YAML []byte
+}
+
+// SwaggerOptions are the options of the swagger endpoints.
+type SwaggerOptions struct {
+	// Prefix is the prefix of the routes of the endpoints, e.g. /docs for /docs/swagger/index.html
+	// and /docs/openapi.yaml.
+	Prefix string
+	// UI is the UI served at {Prefix}/swagger/index.html, Swagger UI by default.
+	UI UI
+	// Documents are the documents served besides the generated ones, at {Prefix}/{Name}/openapi.yaml.
+	Documents []Document
+	// RewriteServers replaces the servers of the documents by the URL their requests are sent to, from their
+	// Host, X-Forwarded-Proto, X-Forwarded-Host and X-Forwarded-Prefix headers, followed by BasePath.
+	RewriteServers bool
+	// BasePath is the path of the routes of the API in the rewritten servers, e.g. /api.
+	BasePath string
+	// TrustedProxies are the IPs and CIDRs of the proxies whose X-Forwarded-* headers rewrite the servers,
+	// checked against the connection. The headers of every client are trusted when it is empty.
+	TrustedProxies []string
+	// Assets are the assets the script of Redoc, RapiDoc or Scalar is served from, redoc.standalone.js,
+	// rapidoc-min.js or scalar.standalone.js, the scripts embedded in github.com/hertz-contrib/swagger-generate/ui-assets
+	// by default. Swagger UI is served from the assets of github.com/swaggo/files.
+	Assets fs.FS
+	// CDN loads the script of the UI from its CDN when it is missing from Assets, instead of failing to bind.
+	CDN bool
+}
+
+// SwaggerOption changes the SwaggerOptions of BindSwagger.
+type SwaggerOption func(o *SwaggerOptions)
+
+// WithPrefix sets the prefix of the routes of the swagger endpoints.
+func WithPrefix(prefix string) SwaggerOption {
+	return func(o *SwaggerOptions) {
+		o.Prefix = prefix
+	}
+}
+
+// WithUI sets the UI of the documents.
+func WithUI(ui UI) SwaggerOption {
+	return func(o *SwaggerOptions) {
+		o.UI = ui
+	}
+}
+
+// WithDocument serves a document besides the generated one, listed by its name in the document selector.
+func WithDocument(name string, document []byte) SwaggerOption {
+	return func(o *SwaggerOptions) {
+		o.Documents = append(append([]Document{}, o.Documents...), Document{Name: name, YAML: document})
+	}
+}
+
+// WithServerRewrite rewrites the servers of the documents by the URL of their requests, followed by a base path.
+func WithServerRewrite(basePath string) SwaggerOption {
+	return func(o *SwaggerOptions) {
+		o.RewriteServers, o.BasePath = true, basePath
+	}
+}
+
+// WithTrustedProxies trusts the X-Forwarded-* headers rewriting the servers only from the proxies of these IPs and CIDRs.
+func WithTrustedProxies(proxies ...string) SwaggerOption {
+	return func(o *SwaggerOptions) {
+		o.TrustedProxies = proxies
+	}
+}
+
+// WithAssets sets the assets the script of the UI is served from.
+func WithAssets(assets fs.FS) SwaggerOption {
+	return func(o *SwaggerOptions) {
+		o.Assets = assets
+	}
+}
+
+// WithCDN loads the script of the UI from its CDN when it is missing from the assets.
+func WithCDN() SwaggerOption {
+	return func(o *SwaggerOptions) {
+		o.CDN = true
+	}
+}
+
+// Access and Swagger are the options of the swagger endpoints, which are not served in the noswagger builds.
+var (
+	Access  AccessOptions
+	Swagger SwaggerOptions
+)
+
+// BindSwagger binds no endpoint in the noswagger builds.
+func BindSwagger(h *server.Hertz, opts ...SwaggerOption) {}
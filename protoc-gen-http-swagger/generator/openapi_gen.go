@@ -39,7 +39,6 @@ import (
 	"sort"
 	"strings"
 
-	"github.com/cloudwego/hertz/cmd/hz/util/logs"
 	"github.com/hertz-contrib/swagger-generate/common/consts"
 	common "github.com/hertz-contrib/swagger-generate/common/utils"
 	"github.com/hertz-contrib/swagger-generate/idl/protobuf/api"
@@ -48,18 +47,33 @@ import (
 	"google.golang.org/genproto/googleapis/api/annotations"
 	"google.golang.org/protobuf/compiler/protogen"
 	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
 	"google.golang.org/protobuf/runtime/protoimpl"
 	any_pb "google.golang.org/protobuf/types/known/anypb"
 )
 
 type Configuration struct {
-	Version        *string
-	Title          *string
-	Description    *string
-	Naming         *string
-	FQSchemaNaming *bool
-	EnumType       *string
-	OutputMode     *string
+	Version           *string
+	Title             *string
+	Description       *string
+	Naming            *string
+	FQSchemaNaming    *bool
+	EnumType          *string
+	OutputMode        *string
+	Strict            *bool
+	WarningsAsErrors  *bool
+	ExcludeDeprecated *bool
+	TrailingComments  *bool
+	DetachedComments  *bool
+	ExamplesFile      *string
+	DisableExamples   *bool
+	HoistComponents   *bool
+	Overlay           *string
+	Lint              *bool
+	LintDisable       *string
+	Include           *string
+	Exclude           *string
+	Visibility        *string
 }
 
 // In order to dynamically add google.rpc.Status responses we need
@@ -71,28 +85,83 @@ var (
 
 // OpenAPIGenerator holds internal state needed to generate an OpenAPIv3 document for a transcoded Protocol Buffer service.
 type OpenAPIGenerator struct {
-	conf             Configuration
-	plugin           *protogen.Plugin
-	inputFiles       []*protogen.File
-	reflect          *OpenAPIReflector
-	generatedSchemas []string // Names of schemas that have already been generated.
+	conf               Configuration
+	plugin             *protogen.Plugin
+	inputFiles         []*protogen.File
+	reflect            *OpenAPIReflector
+	generatedSchemas   []string             // Names of schemas that have already been generated.
+	operationLocations map[string]string    // IDL locations of the operations already added, keyed by route.
+	lintLocations      common.LintLocations // IDL locations of the elements of the document, keyed by JSON pointer.
+	diagnostics        []*common.Diagnostic
+	filter             *common.Filter
 }
 
 // NewOpenAPIGenerator creates a new generator for a protoc plugin invocation.
 func NewOpenAPIGenerator(plugin *protogen.Plugin, conf Configuration, inputFiles []*protogen.File) *OpenAPIGenerator {
 	return &OpenAPIGenerator{
-		conf:             conf,
-		plugin:           plugin,
-		inputFiles:       inputFiles,
-		reflect:          NewOpenAPIReflector(conf),
-		generatedSchemas: make([]string, 0),
+		conf:               conf,
+		plugin:             plugin,
+		inputFiles:         inputFiles,
+		reflect:            NewOpenAPIReflector(conf),
+		generatedSchemas:   make([]string, 0),
+		operationLocations: make(map[string]string),
+		lintLocations:      make(common.LintLocations),
 	}
 }
 
 // Run runs the generator.
 func (g *OpenAPIGenerator) Run(outputFile *protogen.GeneratedFile) error {
+	var lintDisable []string
+	if *g.conf.LintDisable != "" {
+		lintDisable = strings.Split(*g.conf.LintDisable, ";")
+	}
+	if err := common.CheckLintRules(lintDisable); err != nil {
+		return err
+	}
+	var include, exclude []string
+	if *g.conf.Include != "" {
+		include = strings.Split(*g.conf.Include, ";")
+	}
+	if *g.conf.Exclude != "" {
+		exclude = strings.Split(*g.conf.Exclude, ";")
+	}
+	var err error
+	if g.filter, err = common.NewFilter(include, exclude, *g.conf.Visibility); err != nil {
+		return err
+	}
+
 	d := g.buildDocument()
-	bytes, err := d.YAMLValue("Generated with " + consts.PluginNameProtocHttpSwagger + "\n" + consts.InfoURL + consts.PluginNameProtocHttpSwagger)
+	rawInfo := d.ToRawInfo()
+	if g.filter.Active() {
+		common.PruneSchemas(rawInfo)
+	}
+	var examples map[string]*common.OperationExamples
+	if *g.conf.ExamplesFile != "" {
+		examples, err = common.LoadOperationExamples(*g.conf.ExamplesFile)
+		if err != nil {
+			return err
+		}
+	}
+	if err := common.AddExamples(rawInfo, examples, !*g.conf.DisableExamples); err != nil {
+		g.warnf(g.defaultLocation(), common.DiagnosticInvalidExample, "%s", err)
+	}
+	if err := common.HoistComponents(rawInfo, *g.conf.HoistComponents); err != nil {
+		g.warnf(g.defaultLocation(), common.DiagnosticConflictingComponent, "%s", err)
+	}
+	if *g.conf.Overlay != "" {
+		g.diagnostics = append(g.diagnostics, common.ApplyOverlay(rawInfo, *g.conf.Overlay)...)
+	}
+	if *g.conf.Lint {
+		for _, issue := range common.LintDocument(rawInfo, lintDisable) {
+			g.warnf(g.lintLocations.Lookup(issue.Pointer, g.defaultLocation()), issue.Rule, "%s", issue.Message)
+		}
+	}
+
+	if err := common.CheckDiagnostics(g.Diagnostics(), *g.conf.Strict, *g.conf.WarningsAsErrors); err != nil {
+		return err
+	}
+
+	bytes, err := common.MarshalDocument(rawInfo, "Generated with "+consts.PluginNameProtocHttpSwagger+"\n"+consts.InfoURL+consts.PluginNameProtocHttpSwagger)
 	if err != nil {
 		return fmt.Errorf("failed to marshal yaml: %s", err.Error())
 	}
@@ -129,9 +198,9 @@ func (g *OpenAPIGenerator) buildDocument() *openapi.Document {
 			extDocument := proto.GetExtension(file.Desc.Options(), openapi.E_Document)
 			if extDocument != nil {
 				if doc, ok := extDocument.(*openapi.Document); ok {
-					proto.Merge(d, doc)
+					common.MergeMessages(d, doc)
 				} else {
-					logs.Errorf("unexpected type for Document: %T", extDocument)
+					g.errorf(descriptorLocation(file.Desc), common.DiagnosticInvalidOption, "unexpected type for Document: %T", extDocument)
 				}
 			}
 			g.addPathsToDocument(d, file.Services)
@@ -207,12 +276,24 @@ func (g *OpenAPIGenerator) buildDocument() *openapi.Document {
 		}
 	}
 
-	// Set all servers on API level
+	// Set all servers on API level, merged with the servers of the document annotation
 	if len(allServers) > 0 {
-		d.Servers = []*openapi.Server{}
+		servers := []*openapi.Server{}
 		for _, server := range allServers {
-			d.Servers = append(d.Servers, &openapi.Server{Url: server})
+			s := &openapi.Server{Url: server}
+			for _, annotated := range d.Servers {
+				if annotated.Url == server {
+					s = annotated
+				}
+			}
+			servers = append(servers, s)
+		}
+		for _, annotated := range d.Servers {
+			if !common.Contains(allServers, annotated.Url) {
+				servers = append(servers, annotated)
+			}
 		}
+		d.Servers = servers
 	}
 
 	// If there is only 1 server, we can safely remove all path level servers
@@ -249,13 +330,24 @@ func (g *OpenAPIGenerator) buildDocument() *openapi.Document {
 	return d
 }
 
-// filterCommentString removes linter rules from comments.
-func (g *OpenAPIGenerator) filterCommentString(c protogen.Comments) string {
-	comment := regexp.MustCompile(consts.LinterRulePatternRegexp).ReplaceAllString(string(c), "")
-	return strings.TrimSpace(comment)
+// parseComment parses the comments of an element, with the detached and trailing comments when enabled.
+func (g *OpenAPIGenerator) parseComment(comments protogen.CommentSet) *common.Comment {
+	var texts []string
+	if *g.conf.DetachedComments {
+		for _, c := range comments.LeadingDetached {
+			texts = append(texts, string(c))
+		}
+	}
+	texts = append(texts, string(comments.Leading))
+	if *g.conf.TrailingComments {
+		texts = append(texts, string(comments.Trailing))
+	}
+	return common.ParseComment(texts...)
 }
 
 func (g *OpenAPIGenerator) getSchemaByOption(inputMessage *protogen.Message, bodyType *protoimpl.ExtensionInfo) *openapi.Schema {
+	// The schema is added to the components by the callers, with a name suffixed by the option.
+	schemaName := g.reflect.formatMessageName(inputMessage.Desc) + optionSchemaSuffixes[bodyType]
 	// Build an array holding the fields of the message.
 	definitionProperties := &openapi.Properties{
 		AdditionalProperties: make([]*openapi.NamedSchemaOrReference, 0),
@@ -277,8 +369,12 @@ func (g *OpenAPIGenerator) getSchemaByOption(inputMessage *protogen.Message, bod
 				required = append(required, ext.(string))
 			}
 
+			fieldComment := g.parseComment(field.Comments)
+			if fieldComment.Internal || !g.visibleField(field.Desc) {
+				continue
+			}
 			// Get the field description from the comments.
-			description := g.filterCommentString(field.Comments.Leading)
+			description := fieldComment.Text
 			// Check the field annotations to see if this is a readonly or writeonly field.
 			inputOnly := false
 			outputOnly := false
@@ -297,7 +393,7 @@ func (g *OpenAPIGenerator) getSchemaByOption(inputMessage *protogen.Message, bod
 						}
 					}
 				default:
-					logs.Error("unsupported extension type %T", extension)
+					g.errorf(descriptorLocation(field.Desc), common.DiagnosticInvalidOption, "unsupported extension type %T", extension)
 				}
 			}
 
@@ -306,9 +402,10 @@ func (g *OpenAPIGenerator) getSchemaByOption(inputMessage *protogen.Message, bod
 			if fieldSchema == nil {
 				continue
 			}
+			deprecated, reason := g.getDeprecation(field.Desc, field.Comments)
 
 			// If this field has siblings and is a $ref now, create a new schema use `allOf` to wrap it
-			wrapperNeeded := inputOnly || outputOnly || description != ""
+			wrapperNeeded := inputOnly || outputOnly || description != "" || deprecated || len(fieldComment.Examples) > 0 || len(fieldComment.See) > 0
 			if wrapperNeeded {
 				if _, ok := fieldSchema.Oneof.(*openapi.SchemaOrReference_Reference); ok {
 					fieldSchema = &openapi.SchemaOrReference{Oneof: &openapi.SchemaOrReference_Schema{Schema: &openapi.Schema{
@@ -319,13 +416,19 @@ func (g *OpenAPIGenerator) getSchemaByOption(inputMessage *protogen.Message, bod
 
 			if schema, ok := fieldSchema.Oneof.(*openapi.SchemaOrReference_Schema); ok {
 				schema.Schema.Description = description
+				schema.Schema.Example = example(fieldComment)
+				schema.Schema.ExternalDocs = externalDocs(fieldComment)
 				schema.Schema.ReadOnly = outputOnly
 				schema.Schema.WriteOnly = inputOnly
 
 				// Merge any `Property` annotations with the current
 				extProperty := proto.GetExtension(field.Desc.Options(), openapi.E_Property)
 				if extProperty != nil {
-					proto.Merge(schema.Schema, extProperty.(*openapi.Schema))
+					common.MergeMessages(schema.Schema, extProperty.(*openapi.Schema))
+				}
+				if deprecated {
+					schema.Schema.Deprecated = true
+					schema.Schema.SpecificationExtension = append(schema.Schema.SpecificationExtension, deprecatedReasonExtension(reason)...)
 				}
 			}
 			extName := proto.GetExtension(field.Desc.Options(), bodyType).(string)
@@ -339,8 +442,10 @@ func (g *OpenAPIGenerator) getSchemaByOption(inputMessage *protogen.Message, bod
 					Value: fieldSchema,
 				},
 			)
+			g.lintLocations[common.SchemaPointer(schemaName, extName)] = descriptorLocation(field.Desc)
 		}
 	}
+	g.lintLocations[common.SchemaPointer(schemaName)] = descriptorLocation(inputMessage.Desc)
 
 	schema := &openapi.Schema{
 		Type:       consts.SchemaObjectType,
@@ -350,7 +455,12 @@ func (g *OpenAPIGenerator) getSchemaByOption(inputMessage *protogen.Message, bod
 	// Merge any `Schema` annotations with the current
 	extSchema = proto.GetExtension(inputMessage.Desc.Options(), openapi.E_Schema)
 	if extSchema != nil {
-		proto.Merge(schema, extSchema.(*openapi.Schema))
+		common.MergeMessages(schema, extSchema.(*openapi.Schema))
+	}
+
+	if deprecated, reason := g.getDeprecation(inputMessage.Desc, inputMessage.Comments); deprecated {
+		schema.Deprecated = true
+		schema.SpecificationExtension = append(schema.SpecificationExtension, deprecatedReasonExtension(reason)...)
 	}
 
 	schema.Required = required
@@ -373,6 +483,10 @@ func (g *OpenAPIGenerator) buildOperation(
 
 	// Iterate through each field in the input message
 	for _, field := range inputMessage.Fields {
+		fieldComment := g.parseComment(field.Comments)
+		if fieldComment.Internal || !g.visibleField(field.Desc) {
+			continue
+		}
 		var paramName, paramIn, paramDesc string
 		var fieldSchema *openapi.SchemaOrReference
 		required := false
@@ -381,29 +495,29 @@ func (g *OpenAPIGenerator) buildOperation(
 		if ext = proto.GetExtension(field.Desc.Options(), api.E_Query); ext != "" {
 			paramName = proto.GetExtension(field.Desc.Options(), api.E_Query).(string)
 			paramIn = consts.ParameterInQuery
-			paramDesc = g.filterCommentString(field.Comments.Leading)
+			paramDesc = fieldComment.Text
 			fieldSchema = g.reflect.schemaOrReferenceForField(field.Desc)
 			if schema, ok := fieldSchema.Oneof.(*openapi.SchemaOrReference_Schema); ok {
 				// Merge any `Property` annotations with the current
 				extProperty := proto.GetExtension(field.Desc.Options(), openapi.E_Property)
 				if extProperty != nil {
 					if property, ok := extProperty.(*openapi.Schema); ok {
-						proto.Merge(schema.Schema, property)
+						common.MergeMessages(schema.Schema, property)
 					} else {
-						logs.Errorf("unexpected type for Property: %T", extProperty)
+						g.errorf(descriptorLocation(field.Desc), common.DiagnosticInvalidOption, "unexpected type for Property: %T", extProperty)
 					}
 				}
 			}
 		} else if ext = proto.GetExtension(field.Desc.Options(), api.E_Path); ext != "" {
 			paramName = proto.GetExtension(field.Desc.Options(), api.E_Path).(string)
 			paramIn = consts.ParameterInPath
-			paramDesc = g.filterCommentString(field.Comments.Leading)
+			paramDesc = fieldComment.Text
 			fieldSchema = g.reflect.schemaOrReferenceForField(field.Desc)
 			if schema, ok := fieldSchema.Oneof.(*openapi.SchemaOrReference_Schema); ok {
 				// Merge any `Property` annotations with the current
 				extProperty := proto.GetExtension(field.Desc.Options(), openapi.E_Property)
 				if extProperty != nil {
-					proto.Merge(schema.Schema, extProperty.(*openapi.Schema))
+					common.MergeMessages(schema.Schema, extProperty.(*openapi.Schema))
 				}
 			}
 			// According to the OpenAPI specification, if a path parameter exists, it must be required.
@@ -411,25 +525,25 @@ func (g *OpenAPIGenerator) buildOperation(
 		} else if ext = proto.GetExtension(field.Desc.Options(), api.E_Cookie); ext != "" {
 			paramName = proto.GetExtension(field.Desc.Options(), api.E_Cookie).(string)
 			paramIn = consts.ParameterInCookie
-			paramDesc = g.filterCommentString(field.Comments.Leading)
+			paramDesc = fieldComment.Text
 			fieldSchema = g.reflect.schemaOrReferenceForField(field.Desc)
 			if schema, ok := fieldSchema.Oneof.(*openapi.SchemaOrReference_Schema); ok {
 				// Merge any `Property` annotations with the current
 				extProperty := proto.GetExtension(field.Desc.Options(), openapi.E_Property)
 				if extProperty != nil {
-					proto.Merge(schema.Schema, extProperty.(*openapi.Schema))
+					common.MergeMessages(schema.Schema, extProperty.(*openapi.Schema))
 				}
 			}
 		} else if ext = proto.GetExtension(field.Desc.Options(), api.E_Header); ext != "" {
 			paramName = proto.GetExtension(field.Desc.Options(), api.E_Header).(string)
 			paramIn = consts.ParameterInHeader
-			paramDesc = g.filterCommentString(field.Comments.Leading)
+			paramDesc = fieldComment.Text
 			fieldSchema = g.reflect.schemaOrReferenceForField(field.Desc)
 			if schema, ok := fieldSchema.Oneof.(*openapi.SchemaOrReference_Schema); ok {
 				// Merge any `Property` annotations with the current
 				extProperty := proto.GetExtension(field.Desc.Options(), openapi.E_Property)
 				if extProperty != nil {
-					proto.Merge(schema.Schema, extProperty.(*openapi.Schema))
+					common.MergeMessages(schema.Schema, extProperty.(*openapi.Schema))
 				}
 			}
 		}
@@ -439,15 +553,21 @@ func (g *OpenAPIGenerator) buildOperation(
 			Description: paramDesc,
 			Required:    required,
 			Schema:      fieldSchema,
+			Example:     example(fieldComment),
 		}
 		extParameter := proto.GetExtension(field.Desc.Options(), openapi.E_Parameter)
 		if extParameter != nil {
 			if parameterExt, ok := extParameter.(*openapi.Parameter); ok {
-				proto.Merge(parameter, parameterExt)
+				common.MergeMessages(parameter, parameterExt)
 			} else {
-				logs.Errorf("unexpected type for Parameter: %T", extParameter)
+				g.errorf(descriptorLocation(field.Desc), common.DiagnosticInvalidOption, "unexpected type for Parameter: %T", extParameter)
 			}
 		}
+		if deprecated, reason := g.getDeprecation(field.Desc, field.Comments); deprecated {
+			parameter.Deprecated = true
+			parameter.SpecificationExtension = append(parameter.SpecificationExtension, deprecatedReasonExtension(reason)...)
+		}
+		parameter.SpecificationExtension = append(parameter.SpecificationExtension, componentExtension(field.Desc)...)
 
 		// Append the parameter to the parameters array if it was set
 		if paramName != "" && paramIn != "" {
@@ -551,7 +671,7 @@ func (g *OpenAPIGenerator) buildOperation(
 			RequestBody = &openapi.RequestBodyOrReference{
 				Oneof: &openapi.RequestBodyOrReference_RequestBody{
 					RequestBody: &openapi.RequestBody{
-						Description: g.filterCommentString(inputMessage.Comments.Leading),
+						Description: g.parseComment(inputMessage.Comments).Text,
 						Content: &openapi.MediaTypes{
 							AdditionalProperties: additionalProperties,
 						},
@@ -563,7 +683,7 @@ func (g *OpenAPIGenerator) buildOperation(
 
 	name, header, content := g.getResponseForMessage(d, outputMessage)
 
-	desc := g.filterCommentString(outputMessage.Comments.Leading)
+	desc := g.parseComment(outputMessage.Comments).Text
 	if desc == "" {
 		desc = consts.DefaultResponseDesc
 	}
@@ -623,10 +743,20 @@ func (g *OpenAPIGenerator) getResponseForMessage(d *openapi.Document, message *p
 	for _, field := range message.Fields {
 		if ext := proto.GetExtension(field.Desc.Options(), api.E_Header); ext != "" {
 			headerName := proto.GetExtension(field.Desc.Options(), api.E_Header).(string)
+			headerComment := g.parseComment(field.Comments)
+			if headerComment.Internal || !g.visibleField(field.Desc) {
+				continue
+			}
 			header := &openapi.Header{
-				Description: g.filterCommentString(field.Comments.Leading),
+				Description: headerComment.Text,
 				Schema:      g.reflect.schemaOrReferenceForField(field.Desc),
+				Example:     example(headerComment),
+			}
+			if deprecated, reason := g.getDeprecation(field.Desc, field.Comments); deprecated {
+				header.Deprecated = true
+				header.SpecificationExtension = deprecatedReasonExtension(reason)
 			}
+			header.SpecificationExtension = append(header.SpecificationExtension, componentExtension(field.Desc)...)
 			headers.AdditionalProperties = append(headers.AdditionalProperties, &openapi.NamedHeaderOrReference{
 				Name: headerName,
 				Value: &openapi.HeaderOrReference{
@@ -689,8 +819,62 @@ func (g *OpenAPIGenerator) getResponseForMessage(d *openapi.Document, message *p
 	return consts.StatusOK, headers, content
 }
 
+// methodLocation describes where a method is declared in the IDL, used in diagnostics.
+func (g *OpenAPIGenerator) methodLocation(method *protogen.Method) string {
+	return fmt.Sprintf("%s (%s)", descriptorLocation(method.Desc), method.Desc.FullName())
+}
+
+// warnf reports a problem that leaves the document usable.
+func (g *OpenAPIGenerator) warnf(location, code, format string, a ...interface{}) {
+	g.diagnostics = append(g.diagnostics, &common.Diagnostic{Severity: common.SeverityWarning, Code: code, Location: location, Message: fmt.Sprintf(format, a...)})
+}
+
+// errorf reports a problem that leaves an element out of the document, or documents it partially.
+func (g *OpenAPIGenerator) errorf(location, code, format string, a ...interface{}) {
+	g.diagnostics = append(g.diagnostics, &common.Diagnostic{Severity: common.SeverityError, Code: code, Location: location, Message: fmt.Sprintf(format, a...)})
+}
+
+// Diagnostics returns the problems found while building the document.
+func (g *OpenAPIGenerator) Diagnostics() []*common.Diagnostic {
+	return append(g.diagnostics, g.reflect.diagnostics...)
+}
+
+// descriptorLocation returns the file, line and column where an element is declared in the IDL, from the
+// source code info of the file, or the file alone when protoc does not pass it.
+func descriptorLocation(desc protoreflect.Descriptor) string {
+	file := desc.ParentFile()
+	loc := file.SourceLocations().ByDescriptor(desc)
+	if loc.Path == nil {
+		return file.Path()
+	}
+	return fmt.Sprintf("%s:%d:%d", file.Path(), loc.StartLine+1, loc.StartColumn+1)
+}
+
+// defaultLocation is the location of the issues of the linter on elements that are not declared in the IDL.
+func (g *OpenAPIGenerator) defaultLocation() string {
+	var files []string
+	for _, file := range g.inputFiles {
+		if file.Generate {
+			files = append(files, file.Desc.Path())
+		}
+	}
+	return strings.Join(files, ", ")
+}
+
 // addOperationToDocument adds an operation to the specified path/method.
-func (g *OpenAPIGenerator) addOperationToDocument(d *openapi.Document, op *openapi.Operation, path, methodName string) {
+// If another method already declared the same route, the operation is dropped and the conflict is reported.
+func (g *OpenAPIGenerator) addOperationToDocument(d *openapi.Document, op *openapi.Operation, path, methodName, location string) {
+	key := common.OperationKey(methodName, path)
+	if existing, ok := g.operationLocations[key]; ok {
+		g.errorf(location, common.DiagnosticConflictingOperation, "duplicate route %s %s, already declared by %s, only the first one is documented", methodName, path, existing)
+		return
+	}
+	g.operationLocations[key] = location
+	g.lintLocations[common.OperationPointer(methodName, path)] = location
+	if _, ok := g.lintLocations[common.PathPointer(path)]; !ok {
+		g.lintLocations[common.PathPointer(path)] = location
+	}
+
 	var selectedPathItem *openapi.NamedPathItem
 	for _, namedPathItem := range d.Paths.Path {
 		if namedPathItem.Name == path {
@@ -724,13 +908,25 @@ func (g *OpenAPIGenerator) addOperationToDocument(d *openapi.Document, op *opena
 
 func (g *OpenAPIGenerator) addPathsToDocument(d *openapi.Document, services []*protogen.Service) {
 	for _, service := range services {
+		if !g.visible(service.Desc, proto.GetExtension(service.Desc.Options(), openapi.E_ServiceVisibility).(string)) {
+			continue
+		}
 		annotationsCount := 0
 
 		for _, method := range service.Methods {
-			comment := g.filterCommentString(method.Comments.Leading)
+			methodComment := g.parseComment(method.Comments)
+			if methodComment.Internal || !g.filter.IncludesMethod(string(service.Desc.Name()), string(method.Desc.Name())) ||
+				!g.visible(method.Desc, proto.GetExtension(method.Desc.Options(), openapi.E_MethodVisibility).(string)) {
+				continue
+			}
 			inputMessage := method.Input
 			outputMessage := method.Output
 			operationID := service.GoName + "_" + method.GoName
+			deprecated, reason := g.getDeprecation(method.Desc, method.Comments)
+			extOperation := proto.GetExtension(method.Desc.Options(), openapi.E_Operation)
+			if *g.conf.ExcludeDeprecated && (deprecated || extOperation.(*openapi.Operation).GetDeprecated()) {
+				continue
+			}
 			rs := api.GetAllOptions(api.HttpMethodOptions, method.Desc.Options())
 			for methodName, path := range rs {
 				if methodName != "" {
@@ -741,24 +937,94 @@ func (g *OpenAPIGenerator) addPathsToDocument(d *openapi.Document, services []*p
 					if host == "" {
 						host = proto.GetExtension(service.Desc.Options(), api.E_BaseDomain).(string)
 					}
-					op, path2 := g.buildOperation(d, methodName, operationID, service.GoName, comment, host, path.(string), inputMessage, outputMessage)
+					op, path2 := g.buildOperation(d, methodName, operationID, service.GoName, methodComment.Description, host, path.(string), inputMessage, outputMessage)
+					op.Summary = methodComment.Summary
+					op.ExternalDocs = externalDocs(methodComment)
 					// Merge any `Operation` annotations with the current
-					extOperation := proto.GetExtension(method.Desc.Options(), openapi.E_Operation)
-
 					if extOperation != nil {
-						proto.Merge(op, extOperation.(*openapi.Operation))
+						common.MergeMessages(op, extOperation.(*openapi.Operation))
+					}
+					if deprecated {
+						op.Deprecated = true
+						op.SpecificationExtension = append(op.SpecificationExtension, deprecatedReasonExtension(reason)...)
 					}
-					g.addOperationToDocument(d, op, path2, methodName)
+					g.addOperationToDocument(d, op, path2, methodName, g.methodLocation(method))
 				}
 			}
 		}
 		if annotationsCount > 0 {
-			comment := g.filterCommentString(service.Comments.Leading)
-			d.Tags = append(d.Tags, &openapi.Tag{Name: service.GoName, Description: comment})
+			serviceComment := g.parseComment(service.Comments)
+			d.Tags = append(d.Tags, &openapi.Tag{Name: service.GoName, Description: serviceComment.Text, ExternalDocs: externalDocs(serviceComment)})
 		}
 	}
 }
 
+// getDeprecation reports whether a descriptor is marked `deprecated = true` or has a `Deprecated:` line
+// in its comments, and returns the reason of the deprecation.
+func (g *OpenAPIGenerator) getDeprecation(desc protoreflect.Descriptor, comments protogen.CommentSet) (bool, string) {
+	comment := g.parseComment(comments)
+	deprecated, reason := comment.Deprecated, comment.DeprecatedReason
+	if options, ok := desc.Options().(interface{ GetDeprecated() bool }); ok && options.GetDeprecated() {
+		deprecated = true
+	}
+	return deprecated, reason
+}
+
+// visible reports whether an element is documented according to its visibility option,
+// and reports the invalid visibilities.
+func (g *OpenAPIGenerator) visible(desc protoreflect.Descriptor, visibility string) bool {
+	visible, err := g.filter.Visible(visibility)
+	if err != nil {
+		g.errorf(descriptorLocation(desc), common.DiagnosticInvalidOption, "%s", err)
+	}
+	return visible
+}
+
+// visibleField reports whether a field is documented according to its visibility.
+func (g *OpenAPIGenerator) visibleField(field protoreflect.FieldDescriptor) bool {
+	return g.visible(field, proto.GetExtension(field.Options(), openapi.E_FieldVisibility).(string))
+}
+
+// externalDocs returns the externalDocs of the first `@see` tag of a comment.
+func externalDocs(comment *common.Comment) *openapi.ExternalDocs {
+	if len(comment.See) == 0 {
+		return nil
+	}
+	return &openapi.ExternalDocs{Url: comment.See[0].URL, Description: comment.See[0].Description}
+}
+
+// example returns the value of the first `@example` tag of a comment.
+func example(comment *common.Comment) *openapi.Any {
+	if len(comment.Examples) == 0 {
+		return nil
+	}
+	return &openapi.Any{Yaml: comment.Examples[0]}
+}
+
+// deprecatedReasonExtension returns the x-deprecated-reason extension, or nothing when no reason is given.
+func deprecatedReasonExtension(reason string) []*openapi.NamedAny {
+	if reason == "" {
+		return nil
+	}
+	return []*openapi.NamedAny{{
+		Name:  consts.ExtensionDeprecatedReason,
+		Value: &openapi.Any{Yaml: common.YAMLValue(reason)},
+	}}
+}
+
+// componentExtension returns the x-component extension naming the component of a parameter or header
+// after the `openapi.component` option, or nothing when it is not set.
+func componentExtension(field protoreflect.FieldDescriptor) []*openapi.NamedAny {
+	name := proto.GetExtension(field.Options(), openapi.E_Component).(string)
+	if name == "" {
+		return nil
+	}
+	return []*openapi.NamedAny{{
+		Name:  consts.ExtensionComponent,
+		Value: &openapi.Any{Yaml: common.YAMLValue(name)},
+	}}
+}
+
 // addSchemaToDocument adds the schema to the document if required
 func (g *OpenAPIGenerator) addSchemaToDocument(d *openapi.Document, schema *openapi.NamedSchemaOrReference) {
 	if common.Contains(g.generatedSchemas, schema.Name) {
@@ -785,7 +1051,7 @@ func (g *OpenAPIGenerator) addSchemasForMessagesToDocument(d *openapi.Document,
 		}
 
 		typeName := g.reflect.fullMessageTypeName(message.Desc)
-		messageDescription := g.filterCommentString(message.Comments.Leading)
+		messageComment := g.parseComment(message.Comments)
 
 		// `google.protobuf.Value` and `google.protobuf.Any` have special JSON transcoding
 		// so we can't just reflect on the message descriptor.
@@ -809,8 +1075,12 @@ func (g *OpenAPIGenerator) addSchemasForMessagesToDocument(d *openapi.Document,
 
 		var required []string
 		for _, field := range message.Fields {
+			fieldComment := g.parseComment(field.Comments)
+			if fieldComment.Internal || !g.visibleField(field.Desc) {
+				continue
+			}
 			// Get the field description from the comments.
-			description := g.filterCommentString(field.Comments.Leading)
+			description := fieldComment.Text
 			// Check the field annotations to see if this is a readonly or writeonly field.
 			inputOnly := false
 			outputOnly := false
@@ -829,7 +1099,7 @@ func (g *OpenAPIGenerator) addSchemasForMessagesToDocument(d *openapi.Document,
 						}
 					}
 				default:
-					logs.Error("unsupported extension type %T", extension)
+					g.errorf(descriptorLocation(field.Desc), common.DiagnosticInvalidOption, "unsupported extension type %T", extension)
 				}
 			}
 
@@ -838,9 +1108,10 @@ func (g *OpenAPIGenerator) addSchemasForMessagesToDocument(d *openapi.Document,
 			if fieldSchema == nil {
 				continue
 			}
+			deprecated, reason := g.getDeprecation(field.Desc, field.Comments)
 
 			// If this field has siblings and is a $ref now, create a new schema use `allOf` to wrap it
-			wrapperNeeded := inputOnly || outputOnly || description != ""
+			wrapperNeeded := inputOnly || outputOnly || description != "" || deprecated || len(fieldComment.Examples) > 0 || len(fieldComment.See) > 0
 			if wrapperNeeded {
 				if _, ok := fieldSchema.Oneof.(*openapi.SchemaOrReference_Reference); ok {
 					fieldSchema = &openapi.SchemaOrReference{Oneof: &openapi.SchemaOrReference_Schema{Schema: &openapi.Schema{
@@ -851,13 +1122,19 @@ func (g *OpenAPIGenerator) addSchemasForMessagesToDocument(d *openapi.Document,
 
 			if schema, ok := fieldSchema.Oneof.(*openapi.SchemaOrReference_Schema); ok {
 				schema.Schema.Description = description
+				schema.Schema.Example = example(fieldComment)
+				schema.Schema.ExternalDocs = externalDocs(fieldComment)
 				schema.Schema.ReadOnly = outputOnly
 				schema.Schema.WriteOnly = inputOnly
 
 				// Merge any `Property` annotations with the current
 				extProperty := proto.GetExtension(field.Desc.Options(), openapi.E_Property)
 				if extProperty != nil {
-					proto.Merge(schema.Schema, extProperty.(*openapi.Schema))
+					common.MergeMessages(schema.Schema, extProperty.(*openapi.Schema))
+				}
+				if deprecated {
+					schema.Schema.Deprecated = true
+					schema.Schema.SpecificationExtension = append(schema.Schema.SpecificationExtension, deprecatedReasonExtension(reason)...)
 				}
 			}
 			var name string
@@ -883,22 +1160,31 @@ func (g *OpenAPIGenerator) addSchemasForMessagesToDocument(d *openapi.Document,
 					Value: fieldSchema,
 				},
 			)
+			g.lintLocations[common.SchemaPointer(schemaName, name)] = descriptorLocation(field.Desc)
 		}
 
 		schema := &openapi.Schema{
-			Type:        consts.SchemaObjectType,
-			Description: messageDescription,
-			Properties:  definitionProperties,
-			Required:    required,
+			Type:         consts.SchemaObjectType,
+			Description:  messageComment.Text,
+			Example:      example(messageComment),
+			ExternalDocs: externalDocs(messageComment),
+			Properties:   definitionProperties,
+			Required:     required,
 		}
 
 		// Merge any `Schema` annotations with the current
 		extSchema := proto.GetExtension(message.Desc.Options(), openapi.E_Schema)
 		if extSchema != nil {
-			proto.Merge(schema, extSchema.(*openapi.Schema))
+			common.MergeMessages(schema, extSchema.(*openapi.Schema))
+		}
+
+		if deprecated, reason := g.getDeprecation(message.Desc, message.Comments); deprecated {
+			schema.Deprecated = true
+			schema.SpecificationExtension = append(schema.SpecificationExtension, deprecatedReasonExtension(reason)...)
 		}
 
 		// Add the schema to the components.schema list.
+		g.lintLocations[common.SchemaPointer(schemaName)] = descriptorLocation(message.Desc)
 		g.addSchemaToDocument(d, &openapi.NamedSchemaOrReference{
 			Name: schemaName,
 			Value: &openapi.SchemaOrReference{
@@ -909,3 +1195,10 @@ func (g *OpenAPIGenerator) addSchemasForMessagesToDocument(d *openapi.Document,
 		})
 	}
 }
+
+// optionSchemaSuffixes are the suffixes of the names of the schemas made of the fields with an option.
+var optionSchemaSuffixes = map[*protoimpl.ExtensionInfo]string{
+	api.E_Body:    consts.ComponentSchemaSuffixBody,
+	api.E_Form:    consts.ComponentSchemaSuffixForm,
+	api.E_RawBody: consts.ComponentSchemaSuffixRawBody,
+}
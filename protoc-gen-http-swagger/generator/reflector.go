@@ -34,7 +34,7 @@
 package generator
 
 import (
-	"log"
+	"fmt"
 	"strings"
 
 	"github.com/hertz-contrib/swagger-generate/common/consts"
@@ -48,6 +48,7 @@ import (
 type OpenAPIReflector struct {
 	conf            Configuration
 	requiredSchemas []string // Names of schemas which are used through references.
+	diagnostics     []*common.Diagnostic
 }
 
 // NewOpenAPIReflector creates a new reflector.
@@ -228,7 +229,12 @@ func (r *OpenAPIReflector) schemaOrReferenceForField(field protoreflect.FieldDes
 		kindSchema = wk.NewBytesSchema()
 
 	default:
-		log.Printf("(TODO) Unsupported field type: %+v", r.fullMessageTypeName(field.Message()))
+		r.diagnostics = append(r.diagnostics, &common.Diagnostic{
+			Severity: common.SeverityError,
+			Code:     common.DiagnosticUnsupportedType,
+			Location: descriptorLocation(field),
+			Message:  fmt.Sprintf("unsupported field type %s", field.Kind()),
+		})
 	}
 
 	if field.IsList() {
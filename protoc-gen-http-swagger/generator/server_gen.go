@@ -60,7 +60,7 @@ func (g *ServerGenerator) Generate(outputFile *protogen.GeneratedFile) error {
 		return errors.New("swagger.go file already exists")
 	}
 
-	tmpl, err := template.New("server").Delims("{{", "}}").Parse(consts.CodeGenerationCommentPbHttp + "\n" + tpl.ServerTemplateHttp)
+	tmpl, err := template.New("server").Delims("{{", "}}").Parse(consts.BuildConstraintSwagger + "\n\n" + consts.CodeGenerationCommentPbHttp + "\n" + tpl.ServerTemplateHttp)
 	if err != nil {
 		return fmt.Errorf("failed to parse template: %w", err)
 	}
@@ -76,3 +76,11 @@ func (g *ServerGenerator) Generate(outputFile *protogen.GeneratedFile) error {
 	}
 	return nil
 }
+
+// GenerateNoopFile generates the swagger file compiled instead of the swagger server with the noswagger build tag.
+func (g *ServerGenerator) GenerateNoopFile(outputFile *protogen.GeneratedFile) error {
+	if _, err := outputFile.Write([]byte(consts.BuildConstraintNoop + "\n\n" + consts.CodeGenerationCommentPbHttp + "\n" + tpl.NoopTemplateHttp)); err != nil {
+		return fmt.Errorf("failed to write output file: %v", err)
+	}
+	return nil
+}
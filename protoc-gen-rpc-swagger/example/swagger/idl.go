@@ -0,0 +1,1318 @@
+//go:build !noswagger
+
+/*
+ * Copyright 2024 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Code generated by protoc-gen-rpc-swagger. DO NOT EDIT.
+
+package swagger
+
+import (
+	_ "embed"
+
+	"github.com/hertz-contrib/swagger-generate/swagger-runtime/kitexproxy"
+)
+
+//go:embed openapi.yaml
+var document []byte
+
+func init() {
+	proxy.Register(&kitexproxy.IDL{
+		Name:          "",
+		Document:      document,
+		ServicePrefix: false,
+		Services: []*kitexproxy.Service{
+			{
+				Name:    "HelloService1",
+				Package: "hello",
+				File:    "hello.proto",
+				Methods: []*kitexproxy.Method{
+					{Name: "QueryMethod1", Safe: true, Schema: "{\"$ref\":\"#/components/schemas/QueryReq\"}"},
+					{Name: "FormMethod", Schema: "{\"$ref\":\"#/components/schemas/FormReq\"}"},
+					{Name: "PathMethod", Schema: "{\"$ref\":\"#/components/schemas/PathReq\"}"},
+					{Name: "BodyMethod", Schema: "{\"$ref\":\"#/components/schemas/BodyReq\"}"},
+				},
+			},
+			{
+				Name:    "HelloService2",
+				Package: "hello",
+				File:    "hello.proto",
+				Methods: []*kitexproxy.Method{
+					{Name: "QueryMethod2", Schema: "{\"$ref\":\"#/components/schemas/QueryReq\"}"},
+				},
+			},
+		},
+		// Files are the contents of the IDL and of its includes, from which the generic clients are built.
+		Files: map[string]string{
+			"api.proto": "" +
+				"// idl/api.proto; 注解拓展\n" +
+				"syntax = \"proto2\";\n" +
+				"\n" +
+				"package api;\n" +
+				"\n" +
+				"import \"google/protobuf/descriptor.proto\";\n" +
+				"\n" +
+				"option go_package = \"/api\";\n" +
+				"\n" +
+				"extend google.protobuf.FieldOptions {\n" +
+				"  optional string raw_body = 50101;\n" +
+				"\n" +
+				"  optional string query = 50102;\n" +
+				"\n" +
+				"  optional string header = 50103;\n" +
+				"\n" +
+				"  optional string cookie = 50104;\n" +
+				"\n" +
+				"  optional string body = 50105;\n" +
+				"\n" +
+				"  optional string path = 50106;\n" +
+				"\n" +
+				"  optional string vd = 50107;\n" +
+				"\n" +
+				"  optional string form = 50108;\n" +
+				"\n" +
+				"  optional string js_conv = 50109;\n" +
+				"\n" +
+				"  optional string file_name = 50110;\n" +
+				"\n" +
+				"  optional string none = 50111;\n" +
+				"\n" +
+				"  // 50131~50160 used to extend field option by hz\n" +
+				"  optional string form_compatible = 50131;\n" +
+				"\n" +
+				"  optional string js_conv_compatible = 50132;\n" +
+				"\n" +
+				"  optional string file_name_compatible = 50133;\n" +
+				"\n" +
+				"  optional string none_compatible = 50134;\n" +
+				"  // 50135 is reserved to vt_compatible\n" +
+				"  // optional FieldRules vt_compatible = 50135;\n" +
+				"\n" +
+				"  optional string go_tag = 51001;\n" +
+				"}\n" +
+				"\n" +
+				"extend google.protobuf.MethodOptions {\n" +
+				"  optional string get = 50201;\n" +
+				"\n" +
+				"  optional string post = 50202;\n" +
+				"\n" +
+				"  optional string put = 50203;\n" +
+				"\n" +
+				"  optional string delete = 50204;\n" +
+				"\n" +
+				"  optional string patch = 50205;\n" +
+				"\n" +
+				"  optional string options = 50206;\n" +
+				"\n" +
+				"  optional string head = 50207;\n" +
+				"\n" +
+				"  optional string any = 50208;\n" +
+				"\n" +
+				"  optional string gen_path = 50301; // The path specified by the user when the client code is generated, with a higher priority than api_version\n" +
+				"\n" +
+				"  optional string api_version = 50302; // Specify the value of the :version variable in path when the client code is generated\n" +
+				"\n" +
+				"  optional string tag = 50303; // rpc tag, can be multiple, separated by commas\n" +
+				"\n" +
+				"  optional string name = 50304; // Name of rpc\n" +
+				"\n" +
+				"  optional string api_level = 50305; // Interface Level\n" +
+				"\n" +
+				"  optional string serializer = 50306; // Serialization method\n" +
+				"\n" +
+				"  optional string param = 50307; // Whether client requests take public parameters\n" +
+				"\n" +
+				"  optional string baseurl = 50308; // Baseurl used in ttnet routing\n" +
+				"\n" +
+				"  optional string handler_path = 50309; // handler_path specifies the path to generate the method\n" +
+				"\n" +
+				"  // 50331~50360 used to extend method option by hz\n" +
+				"  optional string handler_path_compatible = 50331; // handler_path specifies the path to generate the method\n" +
+				"}\n" +
+				"\n" +
+				"extend google.protobuf.EnumValueOptions {\n" +
+				"  optional int32 http_code = 50401;\n" +
+				"}\n" +
+				"\n" +
+				"extend google.protobuf.ServiceOptions {\n" +
+				"  optional string base_domain = 50402;\n" +
+				"\n" +
+				"  // 50731~50760 used to extend service option by hz\n" +
+				"  optional string base_domain_compatible = 50731;\n" +
+				"}\n" +
+				"\n" +
+				"extend google.protobuf.MessageOptions {\n" +
+				"  // optional FieldRules msg_vt = 50111;\n" +
+				"\n" +
+				"  optional string reserve = 50830;\n" +
+				"  // 550831 is reserved to msg_vt_compatible\n" +
+				"  // optional FieldRules msg_vt_compatible = 50831;\n" +
+				"}\n",
+			"hello.proto": "" +
+				"syntax = \"proto3\";\n" +
+				"\n" +
+				"package hello;\n" +
+				"\n" +
+				"option go_package = \"/example\";\n" +
+				"\n" +
+				"import \"api.proto\";\n" +
+				"\n" +
+				"import \"openapi/annotations.proto\";\n" +
+				"\n" +
+				"option (openapi.document) = {\n" +
+				"  info:<title:\"example swagger doc\" version:\"Version from annotation\">\n" +
+				"};\n" +
+				"\n" +
+				"message FormReq {\n" +
+				"  option (openapi.schema) = {\n" +
+				"    title:\"Hello - request\" required:\"form1\" description:\"Hello - request\"\n" +
+				"  };\n" +
+				"\n" +
+				"  string FormValue = 1 [\n" +
+				"    (openapi.property) = {\n" +
+				"      title:\"this is an override field schema title\" max_length:255\n" +
+				"    }\n" +
+				"  ];\n" +
+				"\n" +
+				"  //内嵌message描述\n" +
+				"  message InnerForm {\n" +
+				"    string InnerFormValue = 1;\n" +
+				"  }\n" +
+				"\n" +
+				"  InnerForm FormValue1 = 2;\n" +
+				"}\n" +
+				"\n" +
+				"message QueryReq {\n" +
+				"  map<string, string> strings_map = 7;\n" +
+				"\n" +
+				"  repeated string items = 6;\n" +
+				"\n" +
+				"  //QueryValue描述\n" +
+				"  string QueryValue = 1 [\n" +
+				"    (openapi.parameter) = { required:true },\n" +
+				"    (openapi.property) = {\n" +
+				"      title:\"Name\" max_length:50 min_length:1 type:\"string\" description:\"Name\"\n" +
+				"    }\n" +
+				"  ];\n" +
+				"}\n" +
+				"\n" +
+				"message PathReq {\n" +
+				"  //field: path描述\n" +
+				"  string PathValue = 1;\n" +
+				"}\n" +
+				"\n" +
+				"message BodyReq {\n" +
+				"  //field: body描述\n" +
+				"  string BodyValue = 1;\n" +
+				"\n" +
+				"  //field: query描述\n" +
+				"  string QueryValue = 2;\n" +
+				"\n" +
+				"  //field: body1描述\n" +
+				"  string Body1Value = 3;\n" +
+				"}\n" +
+				"\n" +
+				"message HelloReq {\n" +
+				"  string Name = 1 [\n" +
+				"    (openapi.property) = {\n" +
+				"      title:\"Name\" max_length:50 min_length:1 type:\"string\" description:\"Name\"\n" +
+				"    }\n" +
+				"  ];\n" +
+				"}\n" +
+				"\n" +
+				"// HelloResp描述\n" +
+				"message HelloResp {\n" +
+				"  option (openapi.schema) = {\n" +
+				"    title:\"Hello - response\" required:\"RespBody\" description:\"Hello - response\"\n" +
+				"  };\n" +
+				"\n" +
+				"  //RespBody描述\n" +
+				"  string RespBody = 1 [\n" +
+				"    (openapi.property) = {\n" +
+				"      title:\"response content\" max_length:80 min_length:1 type:\"string\" description:\"response content\"\n" +
+				"    }\n" +
+				"  ];\n" +
+				"\n" +
+				"  string token = 2 [\n" +
+				"    (openapi.property) = { title:\"token\" type:\"string\" description:\"token\" }\n" +
+				"  ];\n" +
+				"}\n" +
+				"\n" +
+				"//HelloService1描述\n" +
+				"service HelloService1 {\n" +
+				"  option (api.base_domain) = \"http://127.0.0.1:8080\";\n" +
+				"\n" +
+				"  rpc QueryMethod1 ( QueryReq ) returns ( HelloResp ) {\n" +
+				"    option idempotency_level = NO_SIDE_EFFECTS;\n" +
+				"  }\n" +
+				"\n" +
+				"  rpc FormMethod ( FormReq ) returns ( HelloResp );\n" +
+				"\n" +
+				"  rpc PathMethod ( PathReq ) returns ( HelloResp );\n" +
+				"\n" +
+				"  rpc BodyMethod ( BodyReq ) returns ( HelloResp );\n" +
+				"}\n" +
+				"\n" +
+				"service HelloService2 {\n" +
+				"  rpc QueryMethod2 ( QueryReq ) returns ( HelloResp ) {\n" +
+				"    option (api.baseurl) = \"http://127.0.0.1:8080\";\n" +
+				"\n" +
+				"    option (openapi.operation) = { summary:\"Hello - Get\" description:\"Hello - Get\" };\n" +
+				"  }\n" +
+				"}\n",
+			"openapi/annotations.proto": "" +
+				"// Copyright 2022 Google LLC. All Rights Reserved.\n" +
+				"//\n" +
+				"// Licensed under the Apache License, Version 2.0 (the \"License\");\n" +
+				"// you may not use this file except in compliance with the License.\n" +
+				"// You may obtain a copy of the License at\n" +
+				"//\n" +
+				"//    http://www.apache.org/licenses/LICENSE-2.0\n" +
+				"//\n" +
+				"// Unless required by applicable law or agreed to in writing, software\n" +
+				"// distributed under the License is distributed on an \"AS IS\" BASIS,\n" +
+				"// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.\n" +
+				"// See the License for the specific language governing permissions and\n" +
+				"// limitations under the License.\n" +
+				"\n" +
+				"// Copyright 2024 CloudWeGo Authors\n" +
+				"//\n" +
+				"// Licensed under the Apache License, Version 2.0 (the \"License\");\n" +
+				"// you may not use this file except in compliance with the License.\n" +
+				"// You may obtain a copy of the License at\n" +
+				"//\n" +
+				"//     http://www.apache.org/licenses/LICENSE-2.0\n" +
+				"//\n" +
+				"// Unless required by applicable law or agreed to in writing, software\n" +
+				"// distributed under the License is distributed on an \"AS IS\" BASIS,\n" +
+				"// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.\n" +
+				"// See the License for the specific language governing permissions and\n" +
+				"// limitations under the License.\n" +
+				"\n" +
+				"syntax = \"proto3\";\n" +
+				"\n" +
+				"package openapi;\n" +
+				"\n" +
+				"import \"openapi/openapi.proto\";\n" +
+				"\n" +
+				"import \"google/protobuf/descriptor.proto\";\n" +
+				"\n" +
+				"// This option lets the proto compiler generate Java code inside the package\n" +
+				"// name (see below) instead of inside an outer class. It creates a simpler\n" +
+				"// developer experience by reducing one-level of name nesting and be\n" +
+				"// consistent with most programming languages that don't support outer classes.\n" +
+				"option java_multiple_files = true;\n" +
+				"\n" +
+				"// The Java outer classname should be the filename in UpperCamelCase. This\n" +
+				"// class is only used to hold proto descriptor, so developers don't need to\n" +
+				"// work with it directly.\n" +
+				"option java_outer_classname = \"AnnotationsProto\";\n" +
+				"\n" +
+				"// The Java package name must be proto package name with proper prefix.\n" +
+				"option java_package = \"org.openapi_v3\";\n" +
+				"\n" +
+				"// A reasonable prefix for the Objective-C symbols generated from the package.\n" +
+				"// It should at a minimum be 3 characters long, all uppercase, and convention\n" +
+				"// is to use an abbreviation of the package name. Something short, but\n" +
+				"// hopefully unique enough to not conflict with things that may come along in\n" +
+				"// the future. 'GPB' is reserved for the protocol buffer implementation itself.\n" +
+				"option objc_class_prefix = \"OAS\";\n" +
+				"\n" +
+				"// The Go package name.\n" +
+				"option go_package = \"/openapi\";\n" +
+				"\n" +
+				"extend google.protobuf.FileOptions {\n" +
+				"  Document document = 1143;\n" +
+				"}\n" +
+				"\n" +
+				"extend google.protobuf.MethodOptions {\n" +
+				"  Operation operation = 1143;\n" +
+				"}\n" +
+				"\n" +
+				"extend google.protobuf.MessageOptions {\n" +
+				"  Schema schema = 1143;\n" +
+				"}\n" +
+				"\n" +
+				"extend google.protobuf.FieldOptions {\n" +
+				"  Parameter parameter = 1144;\n" +
+				"}\n" +
+				"\n" +
+				"extend google.protobuf.FieldOptions {\n" +
+				"  Schema property = 1143;\n" +
+				"}\n" +
+				"\n" +
+				"extend google.protobuf.FieldOptions {\n" +
+				"  string component = 1145;\n" +
+				"}\n" +
+				"\n" +
+				"extend google.protobuf.ServiceOptions {\n" +
+				"  string service_visibility = 1146;\n" +
+				"}\n" +
+				"\n" +
+				"extend google.protobuf.MethodOptions {\n" +
+				"  string method_visibility = 1146;\n" +
+				"}\n" +
+				"\n" +
+				"extend google.protobuf.FieldOptions {\n" +
+				"  string field_visibility = 1146;\n" +
+				"}\n",
+			"openapi/openapi.proto": "" +
+				"// Copyright 2020 Google LLC. All Rights Reserved.\n" +
+				"//\n" +
+				"// Licensed under the Apache License, Version 2.0 (the \"License\");\n" +
+				"// you may not use this file except in compliance with the License.\n" +
+				"// You may obtain a copy of the License at\n" +
+				"//\n" +
+				"//    http://www.apache.org/licenses/LICENSE-2.0\n" +
+				"//\n" +
+				"// Unless required by applicable law or agreed to in writing, software\n" +
+				"// distributed under the License is distributed on an \"AS IS\" BASIS,\n" +
+				"// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.\n" +
+				"// See the License for the specific language governing permissions and\n" +
+				"// limitations under the License.\n" +
+				"\n" +
+				"// THIS FILE IS AUTOMATICALLY GENERATED.\n" +
+				"\n" +
+				"syntax = \"proto3\";\n" +
+				"\n" +
+				"package openapi;\n" +
+				"\n" +
+				"// The Go package name.\n" +
+				"option go_package = \"/openapi\";\n" +
+				"\n" +
+				"message AdditionalPropertiesItem {\n" +
+				"  oneof oneof {\n" +
+				"    SchemaOrReference schema_or_reference = 1;\n" +
+				"\n" +
+				"    bool boolean = 2;\n" +
+				"  }\n" +
+				"}\n" +
+				"\n" +
+				"message _Any {\n" +
+				"  string type_url = 1;\n" +
+				"\n" +
+				"  bytes value = 2;\n" +
+				"}\n" +
+				"\n" +
+				"message Any {\n" +
+				"  _Any value = 1;\n" +
+				"\n" +
+				"  string yaml = 2;\n" +
+				"}\n" +
+				"\n" +
+				"message AnyOrExpression {\n" +
+				"  oneof oneof {\n" +
+				"    Any any = 1;\n" +
+				"\n" +
+				"    Expression expression = 2;\n" +
+				"  }\n" +
+				"}\n" +
+				"\n" +
+				"// A map of possible out-of band callbacks related to the parent operation. Each value in the map is a Path Item Object that describes a set of requests that may be initiated by the API provider and the expected responses. The key value used to identify the callback object is an expression, evaluated at runtime, that identifies a URL to use for the callback operation.\n" +
+				"message Callback {\n" +
+				"  repeated NamedPathItem path = 1;\n" +
+				"\n" +
+				"  repeated NamedAny specification_extension = 2;\n" +
+				"}\n" +
+				"\n" +
+				"message CallbackOrReference {\n" +
+				"  oneof oneof {\n" +
+				"    Callback callback = 1;\n" +
+				"\n" +
+				"    Reference reference = 2;\n" +
+				"  }\n" +
+				"}\n" +
+				"\n" +
+				"message CallbacksOrReferences {\n" +
+				"  repeated NamedCallbackOrReference additional_properties = 1;\n" +
+				"}\n" +
+				"\n" +
+				"// Holds a set of reusable objects for different aspects of the OAS. All objects defined within the components object will have no effect on the API unless they are explicitly referenced from properties outside the components object.\n" +
+				"message Components {\n" +
+				"  SchemasOrReferences schemas = 1;\n" +
+				"\n" +
+				"  ResponsesOrReferences responses = 2;\n" +
+				"\n" +
+				"  ParametersOrReferences parameters = 3;\n" +
+				"\n" +
+				"  ExamplesOrReferences examples = 4;\n" +
+				"\n" +
+				"  RequestBodiesOrReferences request_bodies = 5;\n" +
+				"\n" +
+				"  HeadersOrReferences headers = 6;\n" +
+				"\n" +
+				"  SecuritySchemesOrReferences security_schemes = 7;\n" +
+				"\n" +
+				"  LinksOrReferences links = 8;\n" +
+				"\n" +
+				"  CallbacksOrReferences callbacks = 9;\n" +
+				"\n" +
+				"  repeated NamedAny specification_extension = 10;\n" +
+				"}\n" +
+				"\n" +
+				"// Contact information for the exposed API.\n" +
+				"message Contact {\n" +
+				"  string name = 1;\n" +
+				"\n" +
+				"  string url = 2;\n" +
+				"\n" +
+				"  string email = 3;\n" +
+				"\n" +
+				"  repeated NamedAny specification_extension = 4;\n" +
+				"}\n" +
+				"\n" +
+				"message DefaultType {\n" +
+				"  oneof oneof {\n" +
+				"    double number = 1;\n" +
+				"\n" +
+				"    bool boolean = 2;\n" +
+				"\n" +
+				"    string string = 3;\n" +
+				"  }\n" +
+				"}\n" +
+				"\n" +
+				"// When request bodies or response payloads may be one of a number of different schemas, a `discriminator` object can be used to aid in serialization, deserialization, and validation.  The discriminator is a specific object in a schema which is used to inform the consumer of the specification of an alternative schema based on the value associated with it.  When using the discriminator, _inline_ schemas will not be considered.\n" +
+				"message Discriminator {\n" +
+				"  string property_name = 1;\n" +
+				"\n" +
+				"  Strings mapping = 2;\n" +
+				"\n" +
+				"  repeated NamedAny specification_extension = 3;\n" +
+				"}\n" +
+				"\n" +
+				"message Document {\n" +
+				"  string openapi = 1;\n" +
+				"\n" +
+				"  Info info = 2;\n" +
+				"\n" +
+				"  repeated Server servers = 3;\n" +
+				"\n" +
+				"  Paths paths = 4;\n" +
+				"\n" +
+				"  Components components = 5;\n" +
+				"\n" +
+				"  repeated SecurityRequirement security = 6;\n" +
+				"\n" +
+				"  repeated Tag tags = 7;\n" +
+				"\n" +
+				"  ExternalDocs external_docs = 8;\n" +
+				"\n" +
+				"  repeated NamedAny specification_extension = 9;\n" +
+				"}\n" +
+				"\n" +
+				"// A single encoding definition applied to a single schema property.\n" +
+				"message Encoding {\n" +
+				"  string content_type = 1;\n" +
+				"\n" +
+				"  HeadersOrReferences headers = 2;\n" +
+				"\n" +
+				"  string style = 3;\n" +
+				"\n" +
+				"  bool explode = 4;\n" +
+				"\n" +
+				"  bool allow_reserved = 5;\n" +
+				"\n" +
+				"  repeated NamedAny specification_extension = 6;\n" +
+				"}\n" +
+				"\n" +
+				"message Encodings {\n" +
+				"  repeated NamedEncoding additional_properties = 1;\n" +
+				"}\n" +
+				"\n" +
+				"message Example {\n" +
+				"  string summary = 1;\n" +
+				"\n" +
+				"  string description = 2;\n" +
+				"\n" +
+				"  Any value = 3;\n" +
+				"\n" +
+				"  string external_value = 4;\n" +
+				"\n" +
+				"  repeated NamedAny specification_extension = 5;\n" +
+				"}\n" +
+				"\n" +
+				"message ExampleOrReference {\n" +
+				"  oneof oneof {\n" +
+				"    Example example = 1;\n" +
+				"\n" +
+				"    Reference reference = 2;\n" +
+				"  }\n" +
+				"}\n" +
+				"\n" +
+				"message ExamplesOrReferences {\n" +
+				"  repeated NamedExampleOrReference additional_properties = 1;\n" +
+				"}\n" +
+				"\n" +
+				"message Expression {\n" +
+				"  repeated NamedAny additional_properties = 1;\n" +
+				"}\n" +
+				"\n" +
+				"// Allows referencing an external resource for extended documentation.\n" +
+				"message ExternalDocs {\n" +
+				"  string description = 1;\n" +
+				"\n" +
+				"  string url = 2;\n" +
+				"\n" +
+				"  repeated NamedAny specification_extension = 3;\n" +
+				"}\n" +
+				"\n" +
+				"// The Header Object follows the structure of the Parameter Object with the following changes:  1. `name` MUST NOT be specified, it is given in the corresponding `headers` map. 1. `in` MUST NOT be specified, it is implicitly in `header`. 1. All traits that are affected by the location MUST be applicable to a location of `header` (for example, `style`).\n" +
+				"message Header {\n" +
+				"  string description = 1;\n" +
+				"\n" +
+				"  bool required = 2;\n" +
+				"\n" +
+				"  bool deprecated = 3;\n" +
+				"\n" +
+				"  bool allow_empty_value = 4;\n" +
+				"\n" +
+				"  string style = 5;\n" +
+				"\n" +
+				"  bool explode = 6;\n" +
+				"\n" +
+				"  bool allow_reserved = 7;\n" +
+				"\n" +
+				"  SchemaOrReference schema = 8;\n" +
+				"\n" +
+				"  Any example = 9;\n" +
+				"\n" +
+				"  ExamplesOrReferences examples = 10;\n" +
+				"\n" +
+				"  MediaTypes content = 11;\n" +
+				"\n" +
+				"  repeated NamedAny specification_extension = 12;\n" +
+				"}\n" +
+				"\n" +
+				"message HeaderOrReference {\n" +
+				"  oneof oneof {\n" +
+				"    Header header = 1;\n" +
+				"\n" +
+				"    Reference reference = 2;\n" +
+				"  }\n" +
+				"}\n" +
+				"\n" +
+				"message HeadersOrReferences {\n" +
+				"  repeated NamedHeaderOrReference additional_properties = 1;\n" +
+				"}\n" +
+				"\n" +
+				"// The object provides metadata about the API. The metadata MAY be used by the clients if needed, and MAY be presented in editing or documentation generation tools for convenience.\n" +
+				"message Info {\n" +
+				"  string title = 1;\n" +
+				"\n" +
+				"  string description = 2;\n" +
+				"\n" +
+				"  string terms_of_service = 3;\n" +
+				"\n" +
+				"  Contact contact = 4;\n" +
+				"\n" +
+				"  License license = 5;\n" +
+				"\n" +
+				"  string version = 6;\n" +
+				"\n" +
+				"  repeated NamedAny specification_extension = 7;\n" +
+				"\n" +
+				"  string summary = 8;\n" +
+				"}\n" +
+				"\n" +
+				"message ItemsItem {\n" +
+				"  repeated SchemaOrReference schema_or_reference = 1;\n" +
+				"}\n" +
+				"\n" +
+				"// License information for the exposed API.\n" +
+				"message License {\n" +
+				"  string name = 1;\n" +
+				"\n" +
+				"  string url = 2;\n" +
+				"\n" +
+				"  repeated NamedAny specification_extension = 3;\n" +
+				"}\n" +
+				"\n" +
+				"// The `Link object` represents a possible design-time link for a response. The presence of a link does not guarantee the caller's ability to successfully invoke it, rather it provides a known relationship and traversal mechanism between responses and other operations.  Unlike _dynamic_ links (i.e. links provided **in** the response payload), the OAS linking mechanism does not require link information in the runtime response.  For computing links, and providing instructions to execute them, a runtime expression is used for accessing values in an operation and using them as parameters while invoking the linked operation.\n" +
+				"message Link {\n" +
+				"  string operation_ref = 1;\n" +
+				"\n" +
+				"  string operation_id = 2;\n" +
+				"\n" +
+				"  AnyOrExpression parameters = 3;\n" +
+				"\n" +
+				"  AnyOrExpression request_body = 4;\n" +
+				"\n" +
+				"  string description = 5;\n" +
+				"\n" +
+				"  Server server = 6;\n" +
+				"\n" +
+				"  repeated NamedAny specification_extension = 7;\n" +
+				"}\n" +
+				"\n" +
+				"message LinkOrReference {\n" +
+				"  oneof oneof {\n" +
+				"    Link link = 1;\n" +
+				"\n" +
+				"    Reference reference = 2;\n" +
+				"  }\n" +
+				"}\n" +
+				"\n" +
+				"message LinksOrReferences {\n" +
+				"  repeated NamedLinkOrReference additional_properties = 1;\n" +
+				"}\n" +
+				"\n" +
+				"// Each Media Type Object provides schema and examples for the media type identified by its key.\n" +
+				"message MediaType {\n" +
+				"  SchemaOrReference schema = 1;\n" +
+				"\n" +
+				"  Any example = 2;\n" +
+				"\n" +
+				"  ExamplesOrReferences examples = 3;\n" +
+				"\n" +
+				"  Encodings encoding = 4;\n" +
+				"\n" +
+				"  repeated NamedAny specification_extension = 5;\n" +
+				"}\n" +
+				"\n" +
+				"message MediaTypes {\n" +
+				"  repeated NamedMediaType additional_properties = 1;\n" +
+				"}\n" +
+				"\n" +
+				"// Automatically-generated message used to represent maps of Any as ordered (name,value) pairs.\n" +
+				"message NamedAny {\n" +
+				"  // Map key\n" +
+				"  string name = 1;\n" +
+				"\n" +
+				"  // Mapped value\n" +
+				"  Any value = 2;\n" +
+				"}\n" +
+				"\n" +
+				"// Automatically-generated message used to represent maps of CallbackOrReference as ordered (name,value) pairs.\n" +
+				"message NamedCallbackOrReference {\n" +
+				"  // Map key\n" +
+				"  string name = 1;\n" +
+				"\n" +
+				"  // Mapped value\n" +
+				"  CallbackOrReference value = 2;\n" +
+				"}\n" +
+				"\n" +
+				"// Automatically-generated message used to represent maps of Encoding as ordered (name,value) pairs.\n" +
+				"message NamedEncoding {\n" +
+				"  // Map key\n" +
+				"  string name = 1;\n" +
+				"\n" +
+				"  // Mapped value\n" +
+				"  Encoding value = 2;\n" +
+				"}\n" +
+				"\n" +
+				"// Automatically-generated message used to represent maps of ExampleOrReference as ordered (name,value) pairs.\n" +
+				"message NamedExampleOrReference {\n" +
+				"  // Map key\n" +
+				"  string name = 1;\n" +
+				"\n" +
+				"  // Mapped value\n" +
+				"  ExampleOrReference value = 2;\n" +
+				"}\n" +
+				"\n" +
+				"// Automatically-generated message used to represent maps of HeaderOrReference as ordered (name,value) pairs.\n" +
+				"message NamedHeaderOrReference {\n" +
+				"  // Map key\n" +
+				"  string name = 1;\n" +
+				"\n" +
+				"  // Mapped value\n" +
+				"  HeaderOrReference value = 2;\n" +
+				"}\n" +
+				"\n" +
+				"// Automatically-generated message used to represent maps of LinkOrReference as ordered (name,value) pairs.\n" +
+				"message NamedLinkOrReference {\n" +
+				"  // Map key\n" +
+				"  string name = 1;\n" +
+				"\n" +
+				"  // Mapped value\n" +
+				"  LinkOrReference value = 2;\n" +
+				"}\n" +
+				"\n" +
+				"// Automatically-generated message used to represent maps of MediaType as ordered (name,value) pairs.\n" +
+				"message NamedMediaType {\n" +
+				"  // Map key\n" +
+				"  string name = 1;\n" +
+				"\n" +
+				"  // Mapped value\n" +
+				"  MediaType value = 2;\n" +
+				"}\n" +
+				"\n" +
+				"// Automatically-generated message used to represent maps of ParameterOrReference as ordered (name,value) pairs.\n" +
+				"message NamedParameterOrReference {\n" +
+				"  // Map key\n" +
+				"  string name = 1;\n" +
+				"\n" +
+				"  // Mapped value\n" +
+				"  ParameterOrReference value = 2;\n" +
+				"}\n" +
+				"\n" +
+				"// Automatically-generated message used to represent maps of PathItem as ordered (name,value) pairs.\n" +
+				"message NamedPathItem {\n" +
+				"  // Map key\n" +
+				"  string name = 1;\n" +
+				"\n" +
+				"  // Mapped value\n" +
+				"  PathItem value = 2;\n" +
+				"}\n" +
+				"\n" +
+				"// Automatically-generated message used to represent maps of RequestBodyOrReference as ordered (name,value) pairs.\n" +
+				"message NamedRequestBodyOrReference {\n" +
+				"  // Map key\n" +
+				"  string name = 1;\n" +
+				"\n" +
+				"  // Mapped value\n" +
+				"  RequestBodyOrReference value = 2;\n" +
+				"}\n" +
+				"\n" +
+				"// Automatically-generated message used to represent maps of ResponseOrReference as ordered (name,value) pairs.\n" +
+				"message NamedResponseOrReference {\n" +
+				"  // Map key\n" +
+				"  string name = 1;\n" +
+				"\n" +
+				"  // Mapped value\n" +
+				"  ResponseOrReference value = 2;\n" +
+				"}\n" +
+				"\n" +
+				"// Automatically-generated message used to represent maps of SchemaOrReference as ordered (name,value) pairs.\n" +
+				"message NamedSchemaOrReference {\n" +
+				"  // Map key\n" +
+				"  string name = 1;\n" +
+				"\n" +
+				"  // Mapped value\n" +
+				"  SchemaOrReference value = 2;\n" +
+				"}\n" +
+				"\n" +
+				"// Automatically-generated message used to represent maps of SecuritySchemeOrReference as ordered (name,value) pairs.\n" +
+				"message NamedSecuritySchemeOrReference {\n" +
+				"  // Map key\n" +
+				"  string name = 1;\n" +
+				"\n" +
+				"  // Mapped value\n" +
+				"  SecuritySchemeOrReference value = 2;\n" +
+				"}\n" +
+				"\n" +
+				"// Automatically-generated message used to represent maps of ServerVariable as ordered (name,value) pairs.\n" +
+				"message NamedServerVariable {\n" +
+				"  // Map key\n" +
+				"  string name = 1;\n" +
+				"\n" +
+				"  // Mapped value\n" +
+				"  ServerVariable value = 2;\n" +
+				"}\n" +
+				"\n" +
+				"// Automatically-generated message used to represent maps of string as ordered (name,value) pairs.\n" +
+				"message NamedString {\n" +
+				"  // Map key\n" +
+				"  string name = 1;\n" +
+				"\n" +
+				"  // Mapped value\n" +
+				"  string value = 2;\n" +
+				"}\n" +
+				"\n" +
+				"// Automatically-generated message used to represent maps of StringArray as ordered (name,value) pairs.\n" +
+				"message NamedStringArray {\n" +
+				"  // Map key\n" +
+				"  string name = 1;\n" +
+				"\n" +
+				"  // Mapped value\n" +
+				"  StringArray value = 2;\n" +
+				"}\n" +
+				"\n" +
+				"// Configuration details for a supported OAuth Flow\n" +
+				"message OauthFlow {\n" +
+				"  string authorization_url = 1;\n" +
+				"\n" +
+				"  string token_url = 2;\n" +
+				"\n" +
+				"  string refresh_url = 3;\n" +
+				"\n" +
+				"  Strings scopes = 4;\n" +
+				"\n" +
+				"  repeated NamedAny specification_extension = 5;\n" +
+				"}\n" +
+				"\n" +
+				"// Allows configuration of the supported OAuth Flows.\n" +
+				"message OauthFlows {\n" +
+				"  OauthFlow implicit = 1;\n" +
+				"\n" +
+				"  OauthFlow password = 2;\n" +
+				"\n" +
+				"  OauthFlow client_credentials = 3;\n" +
+				"\n" +
+				"  OauthFlow authorization_code = 4;\n" +
+				"\n" +
+				"  repeated NamedAny specification_extension = 5;\n" +
+				"}\n" +
+				"\n" +
+				"message Object {\n" +
+				"  repeated NamedAny additional_properties = 1;\n" +
+				"}\n" +
+				"\n" +
+				"// Describes a single API operation on a path.\n" +
+				"message Operation {\n" +
+				"  repeated string tags = 1;\n" +
+				"\n" +
+				"  string summary = 2;\n" +
+				"\n" +
+				"  string description = 3;\n" +
+				"\n" +
+				"  ExternalDocs external_docs = 4;\n" +
+				"\n" +
+				"  string operation_id = 5;\n" +
+				"\n" +
+				"  repeated ParameterOrReference parameters = 6;\n" +
+				"\n" +
+				"  RequestBodyOrReference request_body = 7;\n" +
+				"\n" +
+				"  Responses responses = 8;\n" +
+				"\n" +
+				"  CallbacksOrReferences callbacks = 9;\n" +
+				"\n" +
+				"  bool deprecated = 10;\n" +
+				"\n" +
+				"  repeated SecurityRequirement security = 11;\n" +
+				"\n" +
+				"  repeated Server servers = 12;\n" +
+				"\n" +
+				"  repeated NamedAny specification_extension = 13;\n" +
+				"}\n" +
+				"\n" +
+				"// Describes a single operation parameter.  A unique parameter is defined by a combination of a name and location.\n" +
+				"message Parameter {\n" +
+				"  string name = 1;\n" +
+				"\n" +
+				"  string in = 2;\n" +
+				"\n" +
+				"  string description = 3;\n" +
+				"\n" +
+				"  bool required = 4;\n" +
+				"\n" +
+				"  bool deprecated = 5;\n" +
+				"\n" +
+				"  bool allow_empty_value = 6;\n" +
+				"\n" +
+				"  string style = 7;\n" +
+				"\n" +
+				"  bool explode = 8;\n" +
+				"\n" +
+				"  bool allow_reserved = 9;\n" +
+				"\n" +
+				"  SchemaOrReference schema = 10;\n" +
+				"\n" +
+				"  Any example = 11;\n" +
+				"\n" +
+				"  ExamplesOrReferences examples = 12;\n" +
+				"\n" +
+				"  MediaTypes content = 13;\n" +
+				"\n" +
+				"  repeated NamedAny specification_extension = 14;\n" +
+				"}\n" +
+				"\n" +
+				"message ParameterOrReference {\n" +
+				"  oneof oneof {\n" +
+				"    Parameter parameter = 1;\n" +
+				"\n" +
+				"    Reference reference = 2;\n" +
+				"  }\n" +
+				"}\n" +
+				"\n" +
+				"message ParametersOrReferences {\n" +
+				"  repeated NamedParameterOrReference additional_properties = 1;\n" +
+				"}\n" +
+				"\n" +
+				"// Describes the operations available on a single path. A Path Item MAY be empty, due to ACL constraints. The path itself is still exposed to the documentation viewer but they will not know which operations and parameters are available.\n" +
+				"message PathItem {\n" +
+				"  string _ref = 1;\n" +
+				"\n" +
+				"  string summary = 2;\n" +
+				"\n" +
+				"  string description = 3;\n" +
+				"\n" +
+				"  Operation get = 4;\n" +
+				"\n" +
+				"  Operation put = 5;\n" +
+				"\n" +
+				"  Operation post = 6;\n" +
+				"\n" +
+				"  Operation delete = 7;\n" +
+				"\n" +
+				"  Operation options = 8;\n" +
+				"\n" +
+				"  Operation head = 9;\n" +
+				"\n" +
+				"  Operation patch = 10;\n" +
+				"\n" +
+				"  Operation trace = 11;\n" +
+				"\n" +
+				"  repeated Server servers = 12;\n" +
+				"\n" +
+				"  repeated ParameterOrReference parameters = 13;\n" +
+				"\n" +
+				"  repeated NamedAny specification_extension = 14;\n" +
+				"}\n" +
+				"\n" +
+				"// Holds the relative paths to the individual endpoints and their operations. The path is appended to the URL from the `Server Object` in order to construct the full URL.  The Paths MAY be empty, due to ACL constraints.\n" +
+				"message Paths {\n" +
+				"  repeated NamedPathItem path = 1;\n" +
+				"\n" +
+				"  repeated NamedAny specification_extension = 2;\n" +
+				"}\n" +
+				"\n" +
+				"message Properties {\n" +
+				"  repeated NamedSchemaOrReference additional_properties = 1;\n" +
+				"}\n" +
+				"\n" +
+				"// A simple object to allow referencing other components in the specification, internally and externally.  The Reference Object is defined by JSON Reference and follows the same structure, behavior and rules.   For this specification, reference resolution is accomplished as defined by the JSON Reference specification and not by the JSON Schema specification.\n" +
+				"message Reference {\n" +
+				"  string _ref = 1;\n" +
+				"\n" +
+				"  string summary = 2;\n" +
+				"\n" +
+				"  string description = 3;\n" +
+				"}\n" +
+				"\n" +
+				"message RequestBodiesOrReferences {\n" +
+				"  repeated NamedRequestBodyOrReference additional_properties = 1;\n" +
+				"}\n" +
+				"\n" +
+				"// Describes a single request body.\n" +
+				"message RequestBody {\n" +
+				"  string description = 1;\n" +
+				"\n" +
+				"  MediaTypes content = 2;\n" +
+				"\n" +
+				"  bool required = 3;\n" +
+				"\n" +
+				"  repeated NamedAny specification_extension = 4;\n" +
+				"}\n" +
+				"\n" +
+				"message RequestBodyOrReference {\n" +
+				"  oneof oneof {\n" +
+				"    RequestBody request_body = 1;\n" +
+				"\n" +
+				"    Reference reference = 2;\n" +
+				"  }\n" +
+				"}\n" +
+				"\n" +
+				"// Describes a single response from an API Operation, including design-time, static  `links` to operations based on the response.\n" +
+				"message Response {\n" +
+				"  string description = 1;\n" +
+				"\n" +
+				"  HeadersOrReferences headers = 2;\n" +
+				"\n" +
+				"  MediaTypes content = 3;\n" +
+				"\n" +
+				"  LinksOrReferences links = 4;\n" +
+				"\n" +
+				"  repeated NamedAny specification_extension = 5;\n" +
+				"}\n" +
+				"\n" +
+				"message ResponseOrReference {\n" +
+				"  oneof oneof {\n" +
+				"    Response response = 1;\n" +
+				"\n" +
+				"    Reference reference = 2;\n" +
+				"  }\n" +
+				"}\n" +
+				"\n" +
+				"// A container for the expected responses of an operation. The container maps a HTTP response code to the expected response.  The documentation is not necessarily expected to cover all possible HTTP response codes because they may not be known in advance. However, documentation is expected to cover a successful operation response and any known errors.  The `default` MAY be used as a default response object for all HTTP codes  that are not covered individually by the specification.  The `Responses Object` MUST contain at least one response code, and it  SHOULD be the response for a successful operation call.\n" +
+				"message Responses {\n" +
+				"  ResponseOrReference default = 1;\n" +
+				"\n" +
+				"  repeated NamedResponseOrReference response_or_reference = 2;\n" +
+				"\n" +
+				"  repeated NamedAny specification_extension = 3;\n" +
+				"}\n" +
+				"\n" +
+				"message ResponsesOrReferences {\n" +
+				"  repeated NamedResponseOrReference additional_properties = 1;\n" +
+				"}\n" +
+				"\n" +
+				"// The Schema Object allows the definition of input and output data types. These types can be objects, but also primitives and arrays. This object is an extended subset of the JSON Schema Specification Wright Draft 00.  For more information about the properties, see JSON Schema Core and JSON Schema Validation. Unless stated otherwise, the property definitions follow the JSON Schema.\n" +
+				"message Schema {\n" +
+				"  bool nullable = 1;\n" +
+				"\n" +
+				"  Discriminator discriminator = 2;\n" +
+				"\n" +
+				"  bool read_only = 3;\n" +
+				"\n" +
+				"  bool write_only = 4;\n" +
+				"\n" +
+				"  Xml xml = 5;\n" +
+				"\n" +
+				"  ExternalDocs external_docs = 6;\n" +
+				"\n" +
+				"  Any example = 7;\n" +
+				"\n" +
+				"  bool deprecated = 8;\n" +
+				"\n" +
+				"  string title = 9;\n" +
+				"\n" +
+				"  double multiple_of = 10;\n" +
+				"\n" +
+				"  double maximum = 11;\n" +
+				"\n" +
+				"  bool exclusive_maximum = 12;\n" +
+				"\n" +
+				"  double minimum = 13;\n" +
+				"\n" +
+				"  bool exclusive_minimum = 14;\n" +
+				"\n" +
+				"  int64 max_length = 15;\n" +
+				"\n" +
+				"  int64 min_length = 16;\n" +
+				"\n" +
+				"  string pattern = 17;\n" +
+				"\n" +
+				"  int64 max_items = 18;\n" +
+				"\n" +
+				"  int64 min_items = 19;\n" +
+				"\n" +
+				"  bool unique_items = 20;\n" +
+				"\n" +
+				"  int64 max_properties = 21;\n" +
+				"\n" +
+				"  int64 min_properties = 22;\n" +
+				"\n" +
+				"  repeated string required = 23;\n" +
+				"\n" +
+				"  repeated Any enum = 24;\n" +
+				"\n" +
+				"  string type = 25;\n" +
+				"\n" +
+				"  repeated SchemaOrReference all_of = 26;\n" +
+				"\n" +
+				"  repeated SchemaOrReference one_of = 27;\n" +
+				"\n" +
+				"  repeated SchemaOrReference any_of = 28;\n" +
+				"\n" +
+				"  Schema not = 29;\n" +
+				"\n" +
+				"  ItemsItem items = 30;\n" +
+				"\n" +
+				"  Properties properties = 31;\n" +
+				"\n" +
+				"  AdditionalPropertiesItem additional_properties = 32;\n" +
+				"\n" +
+				"  DefaultType default = 33;\n" +
+				"\n" +
+				"  string description = 34;\n" +
+				"\n" +
+				"  string format = 35;\n" +
+				"\n" +
+				"  repeated NamedAny specification_extension = 36;\n" +
+				"}\n" +
+				"\n" +
+				"message SchemaOrReference {\n" +
+				"  oneof oneof {\n" +
+				"    Schema schema = 1;\n" +
+				"\n" +
+				"    Reference reference = 2;\n" +
+				"  }\n" +
+				"}\n" +
+				"\n" +
+				"message SchemasOrReferences {\n" +
+				"  repeated NamedSchemaOrReference additional_properties = 1;\n" +
+				"}\n" +
+				"\n" +
+				"// Lists the required security schemes to execute this operation. The name used for each property MUST correspond to a security scheme declared in the Security Schemes under the Components Object.  Security Requirement Objects that contain multiple schemes require that all schemes MUST be satisfied for a request to be authorized. This enables support for scenarios where multiple query parameters or HTTP headers are required to convey security information.  When a list of Security Requirement Objects is defined on the OpenAPI Object or Operation Object, only one of the Security Requirement Objects in the list needs to be satisfied to authorize the request.\n" +
+				"message SecurityRequirement {\n" +
+				"  repeated NamedStringArray additional_properties = 1;\n" +
+				"}\n" +
+				"\n" +
+				"// Defines a security scheme that can be used by the operations. Supported schemes are HTTP authentication, an API key (either as a header, a cookie parameter or as a query parameter), mutual TLS (use of a client certificate), OAuth2's common flows (implicit, password, application and access code) as defined in RFC6749, and OpenID Connect.   Please note that currently (2019) the implicit flow is about to be deprecated OAuth 2.0 Security Best Current Practice. Recommended for most use case is Authorization Code Grant flow with PKCE.\n" +
+				"message SecurityScheme {\n" +
+				"  string type = 1;\n" +
+				"\n" +
+				"  string description = 2;\n" +
+				"\n" +
+				"  string name = 3;\n" +
+				"\n" +
+				"  string in = 4;\n" +
+				"\n" +
+				"  string scheme = 5;\n" +
+				"\n" +
+				"  string bearer_format = 6;\n" +
+				"\n" +
+				"  OauthFlows flows = 7;\n" +
+				"\n" +
+				"  string open_id_connect_url = 8;\n" +
+				"\n" +
+				"  repeated NamedAny specification_extension = 9;\n" +
+				"}\n" +
+				"\n" +
+				"message SecuritySchemeOrReference {\n" +
+				"  oneof oneof {\n" +
+				"    SecurityScheme security_scheme = 1;\n" +
+				"\n" +
+				"    Reference reference = 2;\n" +
+				"  }\n" +
+				"}\n" +
+				"\n" +
+				"message SecuritySchemesOrReferences {\n" +
+				"  repeated NamedSecuritySchemeOrReference additional_properties = 1;\n" +
+				"}\n" +
+				"\n" +
+				"// An object representing a Server.\n" +
+				"message Server {\n" +
+				"  string url = 1;\n" +
+				"\n" +
+				"  string description = 2;\n" +
+				"\n" +
+				"  ServerVariables variables = 3;\n" +
+				"\n" +
+				"  repeated NamedAny specification_extension = 4;\n" +
+				"}\n" +
+				"\n" +
+				"// An object representing a Server Variable for server URL template substitution.\n" +
+				"message ServerVariable {\n" +
+				"  repeated string enum = 1;\n" +
+				"\n" +
+				"  string default = 2;\n" +
+				"\n" +
+				"  string description = 3;\n" +
+				"\n" +
+				"  repeated NamedAny specification_extension = 4;\n" +
+				"}\n" +
+				"\n" +
+				"message ServerVariables {\n" +
+				"  repeated NamedServerVariable additional_properties = 1;\n" +
+				"}\n" +
+				"\n" +
+				"// Any property starting with x- is valid.\n" +
+				"message SpecificationExtension {\n" +
+				"  oneof oneof {\n" +
+				"    double number = 1;\n" +
+				"\n" +
+				"    bool boolean = 2;\n" +
+				"\n" +
+				"    string string = 3;\n" +
+				"  }\n" +
+				"}\n" +
+				"\n" +
+				"message StringArray {\n" +
+				"  repeated string value = 1;\n" +
+				"}\n" +
+				"\n" +
+				"message Strings {\n" +
+				"  repeated NamedString additional_properties = 1;\n" +
+				"}\n" +
+				"\n" +
+				"// Adds metadata to a single tag that is used by the Operation Object. It is not mandatory to have a Tag Object per tag defined in the Operation Object instances.\n" +
+				"message Tag {\n" +
+				"  string name = 1;\n" +
+				"\n" +
+				"  string description = 2;\n" +
+				"\n" +
+				"  ExternalDocs external_docs = 3;\n" +
+				"\n" +
+				"  repeated NamedAny specification_extension = 4;\n" +
+				"}\n" +
+				"\n" +
+				"// A metadata object that allows for more fine-tuned XML model definitions.  When using arrays, XML element names are *not* inferred (for singular/plural forms) and the `name` property SHOULD be used to add that information. See examples for expected behavior.\n" +
+				"message Xml {\n" +
+				"  string name = 1;\n" +
+				"\n" +
+				"  string namespace = 2;\n" +
+				"\n" +
+				"  string prefix = 3;\n" +
+				"\n" +
+				"  bool attribute = 4;\n" +
+				"\n" +
+				"  bool wrapped = 5;\n" +
+				"\n" +
+				"  repeated NamedAny specification_extension = 6;\n" +
+				"}\n",
+		},
+		// Schemas are the schemas of the components referenced by the request schemas of the methods.
+		Schemas: "" +
+			"{\n" +
+			"  \"BodyReq\": {\n" +
+			"    \"properties\": {\n" +
+			"      \"Body1Value\": {\n" +
+			"        \"description\": \"field: body1描述\",\n" +
+			"        \"type\": \"string\"\n" +
+			"      },\n" +
+			"      \"BodyValue\": {\n" +
+			"        \"description\": \"field: body描述\",\n" +
+			"        \"type\": \"string\"\n" +
+			"      },\n" +
+			"      \"QueryValue\": {\n" +
+			"        \"description\": \"field: query描述\",\n" +
+			"        \"type\": \"string\"\n" +
+			"      }\n" +
+			"    },\n" +
+			"    \"type\": \"object\"\n" +
+			"  },\n" +
+			"  \"FormReq\": {\n" +
+			"    \"description\": \"Hello - request\",\n" +
+			"    \"properties\": {\n" +
+			"      \"FormValue\": {\n" +
+			"        \"maxLength\": 255,\n" +
+			"        \"title\": \"this is an override field schema title\",\n" +
+			"        \"type\": \"string\"\n" +
+			"      },\n" +
+			"      \"FormValue1\": {\n" +
+			"        \"$ref\": \"#/components/schemas/FormReq_InnerForm\"\n" +
+			"      }\n" +
+			"    },\n" +
+			"    \"title\": \"Hello - request\",\n" +
+			"    \"type\": \"object\"\n" +
+			"  },\n" +
+			"  \"FormReq_InnerForm\": {\n" +
+			"    \"description\": \"内嵌message描述\",\n" +
+			"    \"properties\": {\n" +
+			"      \"InnerFormValue\": {\n" +
+			"        \"type\": \"string\"\n" +
+			"      }\n" +
+			"    },\n" +
+			"    \"type\": \"object\"\n" +
+			"  },\n" +
+			"  \"PathReq\": {\n" +
+			"    \"properties\": {\n" +
+			"      \"PathValue\": {\n" +
+			"        \"description\": \"field: path描述\",\n" +
+			"        \"type\": \"string\"\n" +
+			"      }\n" +
+			"    },\n" +
+			"    \"type\": \"object\"\n" +
+			"  },\n" +
+			"  \"QueryReq\": {\n" +
+			"    \"properties\": {\n" +
+			"      \"QueryValue\": {\n" +
+			"        \"description\": \"Name\",\n" +
+			"        \"maxLength\": 50,\n" +
+			"        \"minLength\": 1,\n" +
+			"        \"title\": \"Name\",\n" +
+			"        \"type\": \"string\"\n" +
+			"      },\n" +
+			"      \"items\": {\n" +
+			"        \"items\": {\n" +
+			"          \"type\": \"string\"\n" +
+			"        },\n" +
+			"        \"type\": \"array\"\n" +
+			"      },\n" +
+			"      \"stringsMap\": {\n" +
+			"        \"additionalProperties\": {\n" +
+			"          \"type\": \"string\"\n" +
+			"        },\n" +
+			"        \"type\": \"object\"\n" +
+			"      }\n" +
+			"    },\n" +
+			"    \"type\": \"object\"\n" +
+			"  }\n" +
+			"}\n",
+	})
+}
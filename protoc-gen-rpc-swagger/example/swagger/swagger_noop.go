@@ -0,0 +1,175 @@
+//go:build noswagger
+
+/*
+ * Copyright 2024 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Code generated by protoc-gen-rpc-swagger.
+package swagger
+
+import (
+	"context"
+	"io/fs"
+	"strings"
+	"time"
+
+	"github.com/cloudwego/hertz/pkg/network"
+	"github.com/cloudwego/kitex/client"
+	"github.com/cloudwego/kitex/pkg/remote"
+	"github.com/cloudwego/kitex/pkg/remote/trans/detection"
+	"github.com/cloudwego/kitex/pkg/remote/trans/netpoll"
+	"github.com/cloudwego/kitex/pkg/remote/trans/nphttp2"
+	"github.com/hertz-contrib/cors"
+)
+
+// Options are the options of the proxy and of its generic clients.
+type Options struct {
+	// Transport is the transport protocol of the calls: ttheader, ttheader_framed, framed or grpc.
+	Transport string
+	// RPCTimeout and ConnectTimeout are the timeouts of the calls and of the connections, those of Kitex if zero.
+	RPCTimeout     time.Duration
+	ConnectTimeout time.Duration
+	// MaxRetries is the maximum number of retries of the failed calls, without retry if zero.
+	MaxRetries int
+	// ClientOptions are the options of the application, appended to the options of the generic clients.
+	ClientOptions []client.Option
+	// MaxBodySize is the maximum size in bytes of the bodies of the requests, larger bodies are rejected with 413.
+	MaxBodySize int
+	// ReadOnly rejects with 403 the calls of the methods whose operations are not documented as safe by x-safe.
+	ReadOnly bool
+	// Addr is the address the swagger server listens on, e.g. :8889, instead of serving the HTTP requests
+	// on the port of the Kitex server.
+	Addr string
+	// Detector detects the connections of the Kitex server carrying HTTP requests, DetectHTTP1 by default.
+	Detector ProtocolDetector
+}
+
+// ProtocolDetector reports whether a connection of the Kitex server carries HTTP requests served by the swagger
+// server, peeking at its first bytes without reading them.
+type ProtocolDetector func(conn network.Conn) bool
+
+// AccessOptions control the access to the swagger endpoints, and to the proxy of the RPC servers.
+type AccessOptions struct {
+	// Enabled serves the endpoints, true by default.
+	Enabled bool
+	// Username and Password require the basic authentication of the requests when Username is set.
+	Username string
+	Password string
+	// Token requires the requests to carry it as a bearer token when it is set. Either credential is accepted
+	// when both are required.
+	Token string
+	// AllowedIPs are the IPs and the CIDRs of the clients allowed, all of them if empty. The address of
+	// the connection is checked, not the forwarding headers.
+	AllowedIPs []string
+	// CORS is the CORS configuration of the endpoints, allowing all the origins by default.
+	CORS cors.Config
+}
+
+// UI is a UI of the documents served by the swagger endpoints.
+type UI string
+
+const (
+	SwaggerUI UI = "swagger-ui"
+	Redoc     UI = "redoc"
+	RapiDoc   UI = "rapidoc"
+	Scalar    UI = "scalar"
+)
+
+// Document is an OpenAPI document served by the swagger endpoints besides the generated ones, e.g. the document
+// of another package, listed by its name in the document selector of the UI.
+type Document struct {
+	Name string
+	// YAML is the content of the document.
+	YAML []byte
+}
+
+// SwaggerOptions are the options of the swagger endpoints.
+type SwaggerOptions struct {
+	// Prefix is the prefix of the routes of the endpoints, e.g. /docs for /docs/swagger/index.html
+	// and /docs/openapi.yaml.
+	Prefix string
+	// UI is the UI served at {Prefix}/swagger/index.html, Swagger UI by default.
+	UI UI
+	// Documents are the documents served besides the generated ones, at {Prefix}/{Name}/openapi.yaml.
+	Documents []Document
+	// RewriteServers replaces the servers of the documents by the URL their requests are sent to, from their
+	// Host, X-Forwarded-Proto, X-Forwarded-Host and X-Forwarded-Prefix headers, followed by BasePath.
+	RewriteServers bool
+	// BasePath is the path of the routes of the API in the rewritten servers, e.g. /api.
+	BasePath string
+	// TrustedProxies are the IPs and CIDRs of the proxies whose X-Forwarded-* headers rewrite the servers,
+	// checked against the connection. The headers of every client are trusted when it is empty.
+	TrustedProxies []string
+	// Assets are the assets the script of Redoc, RapiDoc or Scalar is served from, redoc.standalone.js,
+	// rapidoc-min.js or scalar.standalone.js, the scripts embedded in github.com/hertz-contrib/swagger-generate/ui-assets
+	// by default. Swagger UI is served from the assets of github.com/swaggo/files.
+	Assets fs.FS
+	// CDN loads the script of the UI from its CDN when it is missing from Assets, instead of failing to bind.
+	CDN bool
+}
+
+// ProxyOptions, Access, Swagger and IdlDir are not used in the noswagger builds.
+var (
+	ProxyOptions Options
+	Access       AccessOptions
+	Swagger      SwaggerOptions
+	IdlDir       string
+)
+
+// MixTransHandlerFactory creates the transport handlers of the Kitex server alone in the noswagger builds.
+type MixTransHandlerFactory struct {
+	OriginFactory remote.ServerTransHandlerFactory
+}
+
+func (m MixTransHandlerFactory) NewTransHandler(opt *remote.ServerOption) (remote.ServerTransHandler, error) {
+	if m.OriginFactory != nil {
+		return m.OriginFactory.NewTransHandler(opt)
+	}
+	return detection.NewSvrTransHandlerFactory(netpoll.NewSvrTransHandlerFactory(), nphttp2.NewSvrTransHandlerFactory()).NewTransHandler(opt)
+}
+
+// StartServer starts no server in the noswagger builds.
+func StartServer() {}
+
+// StopServer stops no server in the noswagger builds.
+func StopServer(ctx context.Context) error {
+	return nil
+}
+
+// httpMethods are the methods of the HTTP/1.x requests detected by DetectHTTP1.
+var httpMethods = []string{"GET", "HEAD", "POST", "PUT", "DELETE", "CONNECT", "OPTIONS", "TRACE", "PATCH"}
+
+// DetectHTTP1 detects the HTTP/1.x requests by their method followed by a space, peeking at one more byte
+// while they may still match. The other protocols, like the HTTP/2 connection preface or TLS, are served by Kitex.
+func DetectHTTP1(conn network.Conn) bool {
+	for n := 1; ; n++ {
+		b, err := conn.Peek(n)
+		if err != nil {
+			return false
+		}
+		prefix := false
+		for _, method := range httpMethods {
+			if request := method + " "; strings.HasPrefix(request, string(b)) {
+				if len(b) == len(request) {
+					return true
+				}
+				prefix = true
+			}
+		}
+		if !prefix {
+			return false
+		}
+	}
+}
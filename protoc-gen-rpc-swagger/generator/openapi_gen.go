@@ -39,7 +39,6 @@ import (
 	"sort"
 	"strings"
 
-	"github.com/cloudwego/hertz/cmd/hz/util/logs"
 	"github.com/hertz-contrib/swagger-generate/common/consts"
 	common "github.com/hertz-contrib/swagger-generate/common/utils"
 	"github.com/hertz-contrib/swagger-generate/idl/protobuf/api"
@@ -48,17 +47,39 @@ import (
 	"google.golang.org/genproto/googleapis/api/annotations"
 	"google.golang.org/protobuf/compiler/protogen"
 	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
 	any_pb "google.golang.org/protobuf/types/known/anypb"
+	"gopkg.in/yaml.v3"
 )
 
 type Configuration struct {
-	Version        *string
-	Title          *string
-	Description    *string
-	Naming         *string
-	FQSchemaNaming *bool
-	EnumType       *string
-	OutputMode     *string
+	Version           *string
+	Title             *string
+	Description       *string
+	Naming            *string
+	FQSchemaNaming    *bool
+	EnumType          *string
+	OutputMode        *string
+	Strict            *bool
+	WarningsAsErrors  *bool
+	ServicePrefix     *bool
+	IdlName           *string
+	ExcludeDeprecated *bool
+	TrailingComments  *bool
+	DetachedComments  *bool
+	ExamplesFile      *string
+	DisableExamples   *bool
+	HoistComponents   *bool
+	Overlay           *string
+	Lint              *bool
+	LintDisable       *string
+	Include           *string
+	Exclude           *string
+	Visibility        *string
+	MetaKeys          *string
+	PersistentKeys    *string
+	BackwardKeys      *string
 }
 
 // In order to dynamically add google.rpc.Status responses we need
@@ -70,30 +91,91 @@ var (
 
 // OpenAPIGenerator holds internal state needed to generate an OpenAPIv3 document for a transcoded Protocol Buffer service.
 type OpenAPIGenerator struct {
-	conf              Configuration
-	plugin            *protogen.Plugin
-	inputFiles        []*protogen.File
-	reflect           *OpenAPIReflector
-	generatedSchemas  []string // Names of schemas that have already been generated.
-	linterRulePattern *regexp.Regexp
+	conf               Configuration
+	plugin             *protogen.Plugin
+	inputFiles         []*protogen.File
+	reflect            *OpenAPIReflector
+	generatedSchemas   []string // Names of schemas that have already been generated.
+	linterRulePattern  *regexp.Regexp
+	operationLocations map[string]string    // IDL locations of the operations already added, keyed by route.
+	lintLocations      common.LintLocations // IDL locations of the elements of the document, keyed by JSON pointer.
+	diagnostics        []*common.Diagnostic
+	filter             *common.Filter
+	metaKeys           *common.MetaKeys
+	document           *yaml.Node // Final document, from which the routes of the proxy are built.
 }
 
 // NewOpenAPIGenerator creates a new generator for a protoc plugin invocation.
 func NewOpenAPIGenerator(plugin *protogen.Plugin, conf Configuration, inputFiles []*protogen.File) *OpenAPIGenerator {
 	return &OpenAPIGenerator{
-		conf:              conf,
-		plugin:            plugin,
-		inputFiles:        inputFiles,
-		reflect:           NewOpenAPIReflector(conf),
-		generatedSchemas:  make([]string, 0),
-		linterRulePattern: regexp.MustCompile(`\(-- .* --\)`),
+		conf:               conf,
+		plugin:             plugin,
+		inputFiles:         inputFiles,
+		reflect:            NewOpenAPIReflector(conf),
+		generatedSchemas:   make([]string, 0),
+		linterRulePattern:  regexp.MustCompile(`\(-- .* --\)`),
+		operationLocations: make(map[string]string),
+		lintLocations:      make(common.LintLocations),
 	}
 }
 
 // Run runs the generator.
 func (g *OpenAPIGenerator) Run(outputFile *protogen.GeneratedFile) error {
+	var lintDisable []string
+	if *g.conf.LintDisable != "" {
+		lintDisable = strings.Split(*g.conf.LintDisable, ";")
+	}
+	if err := common.CheckLintRules(lintDisable); err != nil {
+		return err
+	}
+	var include, exclude []string
+	if *g.conf.Include != "" {
+		include = strings.Split(*g.conf.Include, ";")
+	}
+	if *g.conf.Exclude != "" {
+		exclude = strings.Split(*g.conf.Exclude, ";")
+	}
+	var err error
+	if g.filter, err = common.NewFilter(include, exclude, *g.conf.Visibility); err != nil {
+		return err
+	}
+	if g.metaKeys, err = common.NewMetaKeys(splitKeys(*g.conf.MetaKeys), splitKeys(*g.conf.PersistentKeys), splitKeys(*g.conf.BackwardKeys)); err != nil {
+		return err
+	}
+
 	d := g.buildDocument()
-	bytes, err := d.YAMLValue("Generated with " + consts.PluginNameProtocRpcSwagger + "\n" + consts.InfoURL + consts.PluginNameProtocRpcSwagger)
+	rawInfo := d.ToRawInfo()
+	if g.filter.Active() {
+		common.PruneSchemas(rawInfo)
+	}
+	var examples map[string]*common.OperationExamples
+	if *g.conf.ExamplesFile != "" {
+		examples, err = common.LoadOperationExamples(*g.conf.ExamplesFile)
+		if err != nil {
+			return err
+		}
+	}
+	if err := common.AddExamples(rawInfo, examples, !*g.conf.DisableExamples); err != nil {
+		g.warnf(g.defaultLocation(), common.DiagnosticInvalidExample, "%s", err)
+	}
+	if err := common.HoistComponents(rawInfo, *g.conf.HoistComponents); err != nil {
+		g.warnf(g.defaultLocation(), common.DiagnosticConflictingComponent, "%s", err)
+	}
+	if *g.conf.Overlay != "" {
+		g.diagnostics = append(g.diagnostics, common.ApplyOverlay(rawInfo, *g.conf.Overlay)...)
+	}
+	if *g.conf.Lint {
+		for _, issue := range common.LintDocument(rawInfo, lintDisable) {
+			g.warnf(g.lintLocations.Lookup(issue.Pointer, g.defaultLocation()), issue.Rule, "%s", issue.Message)
+		}
+	}
+
+	if err := common.CheckDiagnostics(g.Diagnostics(), *g.conf.Strict, *g.conf.WarningsAsErrors); err != nil {
+		return err
+	}
+	g.document = rawInfo
+
+	bytes, err := common.MarshalDocument(rawInfo, "Generated with "+consts.PluginNameProtocRpcSwagger+"\n"+consts.InfoURL+consts.PluginNameProtocRpcSwagger)
 	if err != nil {
 		return fmt.Errorf("failed to marshal yaml: %s", err.Error())
 	}
@@ -130,9 +212,9 @@ func (g *OpenAPIGenerator) buildDocument() *openapi.Document {
 			extDocument := proto.GetExtension(file.Desc.Options(), openapi.E_Document)
 			if extDocument != nil {
 				if doc, ok := extDocument.(*openapi.Document); ok {
-					proto.Merge(d, doc)
+					common.MergeMessages(d, doc)
 				} else {
-					logs.Errorf("unexpected type for Document: %T", extDocument)
+					g.errorf(descriptorLocation(file.Desc), common.DiagnosticInvalidOption, "unexpected type for Document: %T", extDocument)
 				}
 			}
 			g.addPathsToDocument(d, file.Services)
@@ -179,12 +261,24 @@ func (g *OpenAPIGenerator) buildDocument() *openapi.Document {
 		}
 	}
 
-	// Set all servers on API level
+	// Set all servers on API level, merged with the servers of the document annotation
 	if len(allServers) > 0 {
-		d.Servers = []*openapi.Server{}
+		servers := []*openapi.Server{}
 		for _, server := range allServers {
-			d.Servers = append(d.Servers, &openapi.Server{Url: server})
+			s := &openapi.Server{Url: server}
+			for _, annotated := range d.Servers {
+				if annotated.Url == server {
+					s = annotated
+				}
+			}
+			servers = append(servers, s)
+		}
+		for _, annotated := range d.Servers {
+			if !common.Contains(allServers, annotated.Url) {
+				servers = append(servers, annotated)
+			}
 		}
+		d.Servers = servers
 	}
 
 	// If there is only 1 server, we can safely remove all path level servers
@@ -195,7 +289,7 @@ func (g *OpenAPIGenerator) buildDocument() *openapi.Document {
 	}
 
 	// If there are no servers, add a default one
-	if len(allServers) == 0 {
+	if len(d.Servers) == 0 {
 		d.Servers = []*openapi.Server{
 			{Url: consts.DefaultServerURL},
 		}
@@ -228,13 +322,24 @@ func (g *OpenAPIGenerator) buildDocument() *openapi.Document {
 	return d
 }
 
-// filterCommentString removes linter rules from comments.
-func (g *OpenAPIGenerator) filterCommentString(c protogen.Comments) string {
-	comment := g.linterRulePattern.ReplaceAllString(string(c), "")
-	return strings.TrimSpace(comment)
+// parseComment parses the comments of an element, with the detached and trailing comments when enabled.
+func (g *OpenAPIGenerator) parseComment(comments protogen.CommentSet) *common.Comment {
+	var texts []string
+	if *g.conf.DetachedComments {
+		for _, c := range comments.LeadingDetached {
+			texts = append(texts, string(c))
+		}
+	}
+	texts = append(texts, string(comments.Leading))
+	if *g.conf.TrailingComments {
+		texts = append(texts, string(comments.Trailing))
+	}
+	return common.ParseComment(texts...)
 }
 
 func (g *OpenAPIGenerator) getSchemaByOption(inputMessage *protogen.Message) *openapi.Schema {
+	// The schema is added to the components by the callers, named after the message.
+	schemaName := g.reflect.formatMessageName(inputMessage.Desc)
 	// Build an array holding the fields of the message.
 	definitionProperties := &openapi.Properties{
 		AdditionalProperties: make([]*openapi.NamedSchemaOrReference, 0),
@@ -255,8 +360,12 @@ func (g *OpenAPIGenerator) getSchemaByOption(inputMessage *protogen.Message) *op
 		if common.Contains(allRequired, extName) {
 			required = append(required, extName)
 		}
+		fieldComment := g.parseComment(field.Comments)
+		if fieldComment.Internal || !g.visibleField(field.Desc) {
+			continue
+		}
 		// Get the field description from the comments.
-		description := g.filterCommentString(field.Comments.Leading)
+		description := fieldComment.Text
 		// Check the field annotations to see if this is a readonly or writeonly field.
 		inputOnly := false
 		outputOnly := false
@@ -275,7 +384,7 @@ func (g *OpenAPIGenerator) getSchemaByOption(inputMessage *protogen.Message) *op
 					}
 				}
 			default:
-				logs.Errorf("unsupported extension type %T", extension)
+				g.errorf(descriptorLocation(field.Desc), common.DiagnosticInvalidOption, "unsupported extension type %T", extension)
 			}
 		}
 
@@ -284,9 +393,10 @@ func (g *OpenAPIGenerator) getSchemaByOption(inputMessage *protogen.Message) *op
 		if fieldSchema == nil {
 			continue
 		}
+		deprecated, reason := g.getDeprecation(field.Desc, field.Comments)
 
 		// If this field has siblings and is a $ref now, create a new schema use `allOf` to wrap it
-		wrapperNeeded := inputOnly || outputOnly || description != ""
+		wrapperNeeded := inputOnly || outputOnly || description != "" || deprecated || len(fieldComment.Examples) > 0 || len(fieldComment.See) > 0
 		if wrapperNeeded {
 			if _, ok := fieldSchema.Oneof.(*openapi.SchemaOrReference_Reference); ok {
 				fieldSchema = &openapi.SchemaOrReference{Oneof: &openapi.SchemaOrReference_Schema{Schema: &openapi.Schema{
@@ -297,13 +407,19 @@ func (g *OpenAPIGenerator) getSchemaByOption(inputMessage *protogen.Message) *op
 
 		if schema, ok := fieldSchema.Oneof.(*openapi.SchemaOrReference_Schema); ok {
 			schema.Schema.Description = description
+			schema.Schema.Example = example(fieldComment)
+			schema.Schema.ExternalDocs = externalDocs(fieldComment)
 			schema.Schema.ReadOnly = outputOnly
 			schema.Schema.WriteOnly = inputOnly
 
 			// Merge any `Property` annotations with the current
 			extProperty := proto.GetExtension(field.Desc.Options(), openapi.E_Property)
 			if extProperty != nil {
-				proto.Merge(schema.Schema, extProperty.(*openapi.Schema))
+				common.MergeMessages(schema.Schema, extProperty.(*openapi.Schema))
+			}
+			if deprecated {
+				schema.Schema.Deprecated = true
+				schema.Schema.SpecificationExtension = append(schema.Schema.SpecificationExtension, deprecatedReasonExtension(reason)...)
 			}
 		}
 
@@ -314,7 +430,9 @@ func (g *OpenAPIGenerator) getSchemaByOption(inputMessage *protogen.Message) *op
 				Value: fieldSchema,
 			},
 		)
+		g.lintLocations[common.SchemaPointer(schemaName, extName)] = descriptorLocation(field.Desc)
 	}
+	g.lintLocations[common.SchemaPointer(schemaName)] = descriptorLocation(inputMessage.Desc)
 
 	schema := &openapi.Schema{
 		Type:       consts.SchemaObjectType,
@@ -324,7 +442,12 @@ func (g *OpenAPIGenerator) getSchemaByOption(inputMessage *protogen.Message) *op
 	// Merge any `Schema` annotations with the current
 	extSchema = proto.GetExtension(inputMessage.Desc.Options(), openapi.E_Schema)
 	if extSchema != nil {
-		proto.Merge(schema, extSchema.(*openapi.Schema))
+		common.MergeMessages(schema, extSchema.(*openapi.Schema))
+	}
+
+	if deprecated, reason := g.getDeprecation(inputMessage.Desc, inputMessage.Comments); deprecated {
+		schema.Deprecated = true
+		schema.SpecificationExtension = append(schema.SpecificationExtension, deprecatedReasonExtension(reason)...)
 	}
 
 	schema.Required = required
@@ -344,27 +467,21 @@ func (g *OpenAPIGenerator) buildOperation(
 	// Parameters array to hold all parameter objects
 	var parameters []*openapi.ParameterOrReference
 
-	fieldSchema := &openapi.SchemaOrReference{
-		Oneof: &openapi.SchemaOrReference_Schema{
-			Schema: &openapi.Schema{
-				Type: consts.SchemaObjectType,
+	for _, header := range g.metaKeys.RequestHeaders() {
+		parameters = append(parameters, &openapi.ParameterOrReference{
+			Oneof: &openapi.ParameterOrReference_Parameter{
+				Parameter: &openapi.Parameter{
+					Name:        header.Name,
+					In:          consts.ParameterInHeader,
+					Description: header.Description,
+					Schema: &openapi.SchemaOrReference{
+						Oneof: &openapi.SchemaOrReference_Schema{Schema: &openapi.Schema{Type: "string"}},
+					},
+				},
 			},
-		},
-	}
-	parameter := &openapi.Parameter{
-		Name:        consts.ParameterNameTTHeader,
-		In:          consts.ParameterInQuery,
-		Description: consts.ParameterDescription,
-		Required:    false,
-		Schema:      fieldSchema,
+		})
 	}
 
-	parameters = append(parameters, &openapi.ParameterOrReference{
-		Oneof: &openapi.ParameterOrReference_Parameter{
-			Parameter: parameter,
-		},
-	})
-
 	var RequestBody *openapi.RequestBodyOrReference
 	var additionalProperties []*openapi.NamedMediaType
 
@@ -396,7 +513,7 @@ func (g *OpenAPIGenerator) buildOperation(
 		RequestBody = &openapi.RequestBodyOrReference{
 			Oneof: &openapi.RequestBodyOrReference_RequestBody{
 				RequestBody: &openapi.RequestBody{
-					Description: g.filterCommentString(inputMessage.Comments.Leading),
+					Description: g.parseComment(inputMessage.Comments).Text,
 					Content: &openapi.MediaTypes{
 						AdditionalProperties: additionalProperties,
 					},
@@ -407,7 +524,7 @@ func (g *OpenAPIGenerator) buildOperation(
 
 	name, content := g.getResponseForMessage(d, outputMessage)
 
-	desc := g.filterCommentString(outputMessage.Comments.Leading)
+	desc := g.parseComment(outputMessage.Comments).Text
 	if desc == "" {
 		desc = consts.DefaultResponseDesc
 	}
@@ -426,6 +543,7 @@ func (g *OpenAPIGenerator) buildOperation(
 						Oneof: &openapi.ResponseOrReference_Response{
 							Response: &openapi.Response{
 								Description: desc,
+								Headers:     g.metaResponseHeaders(),
 								Content:     contentOrEmpty,
 							},
 						},
@@ -487,8 +605,71 @@ func (g *OpenAPIGenerator) getResponseForMessage(d *openapi.Document, message *p
 	return consts.StatusOK, content
 }
 
-// addOperationToDocument adds an operation to the specified path/method.
-func (g *OpenAPIGenerator) addOperationToDocument(d *openapi.Document, op *openapi.Operation, path string) {
+// methodLocation describes where a method is declared in the IDL, used in diagnostics.
+func (g *OpenAPIGenerator) methodLocation(method *protogen.Method) string {
+	return fmt.Sprintf("%s (%s)", descriptorLocation(method.Desc), method.Desc.FullName())
+}
+
+// warnf reports a problem that leaves the document usable.
+func (g *OpenAPIGenerator) warnf(location, code, format string, a ...interface{}) {
+	g.diagnostics = append(g.diagnostics, &common.Diagnostic{Severity: common.SeverityWarning, Code: code, Location: location, Message: fmt.Sprintf(format, a...)})
+}
+
+// errorf reports a problem that leaves an element out of the document, or documents it partially.
+func (g *OpenAPIGenerator) errorf(location, code, format string, a ...interface{}) {
+	g.diagnostics = append(g.diagnostics, &common.Diagnostic{Severity: common.SeverityError, Code: code, Location: location, Message: fmt.Sprintf(format, a...)})
+}
+
+// Diagnostics returns the problems found while building the document.
+func (g *OpenAPIGenerator) Diagnostics() []*common.Diagnostic {
+	return append(g.diagnostics, g.reflect.diagnostics...)
+}
+
+// Document returns the document built by the generator, or nil if it was not built.
+func (g *OpenAPIGenerator) Document() *yaml.Node {
+	return g.document
+}
+
+// descriptorLocation returns the file, line and column where an element is declared in the IDL, from the
+// source code info of the file, or the file alone when protoc does not pass it.
+func descriptorLocation(desc protoreflect.Descriptor) string {
+	file := desc.ParentFile()
+	loc := file.SourceLocations().ByDescriptor(desc)
+	if loc.Path == nil {
+		return file.Path()
+	}
+	return fmt.Sprintf("%s:%d:%d", file.Path(), loc.StartLine+1, loc.StartColumn+1)
+}
+
+// defaultLocation is the location of the issues of the linter on elements that are not declared in the IDL.
+func (g *OpenAPIGenerator) defaultLocation() string {
+	var files []string
+	for _, file := range g.inputFiles {
+		if file.Generate {
+			files = append(files, file.Desc.Path())
+		}
+	}
+	return strings.Join(files, ", ")
+}
+
+// addOperationToDocument adds an operation to the specified path.
+// If another method already declared the same route, the operation is dropped and the conflict is reported.
+func (g *OpenAPIGenerator) addOperationToDocument(d *openapi.Document, op *openapi.Operation, path, location string) {
+	key := common.OperationKey(consts.HttpMethodPost, path)
+	if existing, ok := g.operationLocations[key]; ok {
+		message := fmt.Sprintf("duplicate route %s %s, already declared by %s, only the first one is documented", consts.HttpMethodPost, path, existing)
+		if !*g.conf.ServicePrefix {
+			message += ", consider enabling service_prefix"
+		}
+		g.errorf(location, common.DiagnosticConflictingOperation, "%s", message)
+		return
+	}
+	g.operationLocations[key] = location
+	g.lintLocations[common.OperationPointer(consts.HttpMethodPost, path)] = location
+	if _, ok := g.lintLocations[common.PathPointer(path)]; !ok {
+		g.lintLocations[common.PathPointer(path)] = location
+	}
+
 	var selectedPathItem *openapi.NamedPathItem
 	for _, namedPathItem := range d.Paths.Path {
 		if namedPathItem.Name == path {
@@ -507,14 +688,30 @@ func (g *OpenAPIGenerator) addOperationToDocument(d *openapi.Document, op *opena
 
 func (g *OpenAPIGenerator) addPathsToDocument(d *openapi.Document, services []*protogen.Service) {
 	for _, service := range services {
+		if !g.visible(service.Desc, proto.GetExtension(service.Desc.Options(), openapi.E_ServiceVisibility).(string)) {
+			continue
+		}
 		annotationsCount := 0
 
 		for _, method := range service.Methods {
-			comment := g.filterCommentString(method.Comments.Leading)
+			methodComment := g.parseComment(method.Comments)
+			if methodComment.Internal || !g.filter.IncludesMethod(string(service.Desc.Name()), string(method.Desc.Name())) ||
+				!g.visible(method.Desc, proto.GetExtension(method.Desc.Options(), openapi.E_MethodVisibility).(string)) {
+				continue
+			}
 			inputMessage := method.Input
 			outputMessage := method.Output
 			operationID := string(service.Desc.Name()) + "_" + string(method.Desc.Name())
 			path := "/" + string(method.Desc.Name())
+			if *g.conf.ServicePrefix {
+				path = "/" + string(service.Desc.Name()) + path
+			}
+
+			deprecated, reason := g.getDeprecation(method.Desc, method.Comments)
+			extOperation := proto.GetExtension(method.Desc.Options(), openapi.E_Operation)
+			if *g.conf.ExcludeDeprecated && (deprecated || extOperation.(*openapi.Operation).GetDeprecated()) {
+				continue
+			}
 
 			annotationsCount++
 			var host string
@@ -523,22 +720,90 @@ func (g *OpenAPIGenerator) addPathsToDocument(d *openapi.Document, services []*p
 			if host == "" {
 				host = proto.GetExtension(service.Desc.Options(), api.E_BaseDomain).(string)
 			}
-			op, path2 := g.buildOperation(d, operationID, string(service.Desc.Name()), comment, host, path, inputMessage, outputMessage)
+			op, path2 := g.buildOperation(d, operationID, string(service.Desc.Name()), methodComment.Description, host, path, inputMessage, outputMessage)
+			op.Summary = methodComment.Summary
+			op.ExternalDocs = externalDocs(methodComment)
 			// Merge any `Operation` annotations with the current
-			extOperation := proto.GetExtension(method.Desc.Options(), openapi.E_Operation)
-
 			if extOperation != nil {
-				proto.Merge(op, extOperation.(*openapi.Operation))
+				common.MergeMessages(op, extOperation.(*openapi.Operation))
+			}
+			if deprecated {
+				op.Deprecated = true
+				op.SpecificationExtension = append(op.SpecificationExtension, deprecatedReasonExtension(reason)...)
 			}
-			g.addOperationToDocument(d, op, path2)
+			if method.Desc.Options().(*descriptorpb.MethodOptions).GetIdempotencyLevel() == descriptorpb.MethodOptions_NO_SIDE_EFFECTS {
+				op.SpecificationExtension = append(op.SpecificationExtension, safeExtension())
+			}
+			g.addOperationToDocument(d, op, path2, g.methodLocation(method))
 		}
 		if annotationsCount > 0 {
-			comment := g.filterCommentString(service.Comments.Leading)
-			d.Tags = append(d.Tags, &openapi.Tag{Name: string(service.Desc.Name()), Description: comment})
+			serviceComment := g.parseComment(service.Comments)
+			d.Tags = append(d.Tags, &openapi.Tag{Name: string(service.Desc.Name()), Description: serviceComment.Text, ExternalDocs: externalDocs(serviceComment)})
 		}
 	}
 }
 
+// getDeprecation reports whether a descriptor is marked `deprecated = true` or has a `Deprecated:` line
+// in its comments, and returns the reason of the deprecation.
+func (g *OpenAPIGenerator) getDeprecation(desc protoreflect.Descriptor, comments protogen.CommentSet) (bool, string) {
+	comment := g.parseComment(comments)
+	deprecated, reason := comment.Deprecated, comment.DeprecatedReason
+	if options, ok := desc.Options().(interface{ GetDeprecated() bool }); ok && options.GetDeprecated() {
+		deprecated = true
+	}
+	return deprecated, reason
+}
+
+// visible reports whether an element is documented according to its visibility option,
+// and reports the invalid visibilities.
+func (g *OpenAPIGenerator) visible(desc protoreflect.Descriptor, visibility string) bool {
+	visible, err := g.filter.Visible(visibility)
+	if err != nil {
+		g.errorf(descriptorLocation(desc), common.DiagnosticInvalidOption, "%s", err)
+	}
+	return visible
+}
+
+// visibleField reports whether a field is documented according to its visibility.
+func (g *OpenAPIGenerator) visibleField(field protoreflect.FieldDescriptor) bool {
+	return g.visible(field, proto.GetExtension(field.Options(), openapi.E_FieldVisibility).(string))
+}
+
+// externalDocs returns the externalDocs of the first `@see` tag of a comment.
+func externalDocs(comment *common.Comment) *openapi.ExternalDocs {
+	if len(comment.See) == 0 {
+		return nil
+	}
+	return &openapi.ExternalDocs{Url: comment.See[0].URL, Description: comment.See[0].Description}
+}
+
+// example returns the value of the first `@example` tag of a comment.
+func example(comment *common.Comment) *openapi.Any {
+	if len(comment.Examples) == 0 {
+		return nil
+	}
+	return &openapi.Any{Yaml: comment.Examples[0]}
+}
+
+// deprecatedReasonExtension returns the x-deprecated-reason extension, or nothing when no reason is given.
+func deprecatedReasonExtension(reason string) []*openapi.NamedAny {
+	if reason == "" {
+		return nil
+	}
+	return []*openapi.NamedAny{{
+		Name:  consts.ExtensionDeprecatedReason,
+		Value: &openapi.Any{Yaml: common.YAMLValue(reason)},
+	}}
+}
+
+// safeExtension returns the x-safe extension, which allows an operation in the read-only mode of the proxy.
+func safeExtension() *openapi.NamedAny {
+	return &openapi.NamedAny{
+		Name:  consts.ExtensionSafe,
+		Value: &openapi.Any{Yaml: common.YAMLValue(true)},
+	}
+}
+
 // addSchemaToDocument adds the schema to the document if required
 func (g *OpenAPIGenerator) addSchemaToDocument(d *openapi.Document, schema *openapi.NamedSchemaOrReference) {
 	if common.Contains(g.generatedSchemas, schema.Name) {
@@ -565,7 +830,7 @@ func (g *OpenAPIGenerator) addSchemasForMessagesToDocument(d *openapi.Document,
 		}
 
 		typeName := g.reflect.fullMessageTypeName(message.Desc)
-		messageDescription := g.filterCommentString(message.Comments.Leading)
+		messageComment := g.parseComment(message.Comments)
 
 		// `google.protobuf.Value` and `google.protobuf.Any` have special JSON transcoding
 		// so we can't just reflect on the message descriptor.
@@ -589,8 +854,12 @@ func (g *OpenAPIGenerator) addSchemasForMessagesToDocument(d *openapi.Document,
 
 		var required []string
 		for _, field := range message.Fields {
+			fieldComment := g.parseComment(field.Comments)
+			if fieldComment.Internal || !g.visibleField(field.Desc) {
+				continue
+			}
 			// Get the field description from the comments.
-			description := g.filterCommentString(field.Comments.Leading)
+			description := fieldComment.Text
 			// Check the field annotations to see if this is a readonly or writeonly field.
 			inputOnly := false
 			outputOnly := false
@@ -609,7 +878,7 @@ func (g *OpenAPIGenerator) addSchemasForMessagesToDocument(d *openapi.Document,
 						}
 					}
 				default:
-					logs.Errorf("unsupported extension type %T", extension)
+					g.errorf(descriptorLocation(field.Desc), common.DiagnosticInvalidOption, "unsupported extension type %T", extension)
 				}
 			}
 
@@ -618,9 +887,10 @@ func (g *OpenAPIGenerator) addSchemasForMessagesToDocument(d *openapi.Document,
 			if fieldSchema == nil {
 				continue
 			}
+			deprecated, reason := g.getDeprecation(field.Desc, field.Comments)
 
 			// If this field has siblings and is a $ref now, create a new schema use `allOf` to wrap it
-			wrapperNeeded := inputOnly || outputOnly || description != ""
+			wrapperNeeded := inputOnly || outputOnly || description != "" || deprecated || len(fieldComment.Examples) > 0 || len(fieldComment.See) > 0
 			if wrapperNeeded {
 				if _, ok := fieldSchema.Oneof.(*openapi.SchemaOrReference_Reference); ok {
 					fieldSchema = &openapi.SchemaOrReference{Oneof: &openapi.SchemaOrReference_Schema{Schema: &openapi.Schema{
@@ -631,13 +901,19 @@ func (g *OpenAPIGenerator) addSchemasForMessagesToDocument(d *openapi.Document,
 
 			if schema, ok := fieldSchema.Oneof.(*openapi.SchemaOrReference_Schema); ok {
 				schema.Schema.Description = description
+				schema.Schema.Example = example(fieldComment)
+				schema.Schema.ExternalDocs = externalDocs(fieldComment)
 				schema.Schema.ReadOnly = outputOnly
 				schema.Schema.WriteOnly = inputOnly
 
 				// Merge any `Property` annotations with the current
 				extProperty := proto.GetExtension(field.Desc.Options(), openapi.E_Property)
 				if extProperty != nil {
-					proto.Merge(schema.Schema, extProperty.(*openapi.Schema))
+					common.MergeMessages(schema.Schema, extProperty.(*openapi.Schema))
+				}
+				if deprecated {
+					schema.Schema.Deprecated = true
+					schema.Schema.SpecificationExtension = append(schema.Schema.SpecificationExtension, deprecatedReasonExtension(reason)...)
 				}
 			}
 
@@ -650,22 +926,31 @@ func (g *OpenAPIGenerator) addSchemasForMessagesToDocument(d *openapi.Document,
 					Value: fieldSchema,
 				},
 			)
+			g.lintLocations[common.SchemaPointer(schemaName, name)] = descriptorLocation(field.Desc)
 		}
 
 		schema := &openapi.Schema{
-			Type:        consts.SchemaObjectType,
-			Description: messageDescription,
-			Properties:  definitionProperties,
-			Required:    required,
+			Type:         consts.SchemaObjectType,
+			Description:  messageComment.Text,
+			Example:      example(messageComment),
+			ExternalDocs: externalDocs(messageComment),
+			Properties:   definitionProperties,
+			Required:     required,
 		}
 
 		// Merge any `Schema` annotations with the current
 		extSchema := proto.GetExtension(message.Desc.Options(), openapi.E_Schema)
 		if extSchema != nil {
-			proto.Merge(schema, extSchema.(*openapi.Schema))
+			common.MergeMessages(schema, extSchema.(*openapi.Schema))
+		}
+
+		if deprecated, reason := g.getDeprecation(message.Desc, message.Comments); deprecated {
+			schema.Deprecated = true
+			schema.SpecificationExtension = append(schema.SpecificationExtension, deprecatedReasonExtension(reason)...)
 		}
 
 		// Add the schema to the components.schema list.
+		g.lintLocations[common.SchemaPointer(schemaName)] = descriptorLocation(message.Desc)
 		g.addSchemaToDocument(d, &openapi.NamedSchemaOrReference{
 			Name: schemaName,
 			Value: &openapi.SchemaOrReference{
@@ -676,3 +961,35 @@ func (g *OpenAPIGenerator) addSchemasForMessagesToDocument(d *openapi.Document,
 		})
 	}
 }
+
+// metaResponseHeaders returns the headers of the backward metainfo of the responses, or nil if there are none.
+func (g *OpenAPIGenerator) metaResponseHeaders() *openapi.HeadersOrReferences {
+	var headers []*openapi.NamedHeaderOrReference
+	for _, header := range g.metaKeys.ResponseHeaders() {
+		headers = append(headers, &openapi.NamedHeaderOrReference{
+			Name: header.Name,
+			Value: &openapi.HeaderOrReference{
+				Oneof: &openapi.HeaderOrReference_Header{
+					Header: &openapi.Header{
+						Description: header.Description,
+						Schema: &openapi.SchemaOrReference{
+							Oneof: &openapi.SchemaOrReference_Schema{Schema: &openapi.Schema{Type: "string"}},
+						},
+					},
+				},
+			},
+		})
+	}
+	if len(headers) == 0 {
+		return nil
+	}
+	return &openapi.HeadersOrReferences{AdditionalProperties: headers}
+}
+
+// splitKeys splits the keys of an option separated by ";".
+func splitKeys(keys string) []string {
+	if keys == "" {
+		return nil
+	}
+	return strings.Split(keys, ";")
+}
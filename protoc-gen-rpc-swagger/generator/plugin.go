@@ -0,0 +1,135 @@
+/*
+ * Copyright 2024 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package generator
+
+import (
+	"flag"
+	"path/filepath"
+	"strings"
+
+	"github.com/hertz-contrib/swagger-generate/common/consts"
+	common "github.com/hertz-contrib/swagger-generate/common/utils"
+	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/types/pluginpb"
+	"gopkg.in/yaml.v3"
+)
+
+// NewConfiguration defines the options of the plugin on a flag set, and returns the configurations they are parsed into.
+func NewConfiguration(flags *flag.FlagSet) (Configuration, ServerConfiguration) {
+	servicePrefix := flags.Bool("service_prefix", false, `document methods as "/{Service}/{Method}" instead of "/{Method}"`)
+	idlName := flags.String("idl_name", "", `name of the files and the routes of the IDL, to generate several IDLs into one package`)
+
+	conf := Configuration{
+		Version:           flags.String("version", "3.0.3", "version number text, e.g. 1.2.3"),
+		Title:             flags.String("title", "", "name of the API"),
+		Description:       flags.String("description", "", "description of the API"),
+		Naming:            flags.String("naming", "json", `naming convention. Use "proto" for passing names directly from the proto files`),
+		FQSchemaNaming:    flags.Bool("fq_schema_naming", false, `schema naming convention. If "true", generates fully-qualified schema names by prefixing them with the proto message package name`),
+		EnumType:          flags.String("enum_type", "integer", `type for enum serialization. Use "string" for string-based serialization`),
+		OutputMode:        flags.String("output_mode", "merged", `output generation mode. By default, a single openapi.yaml is generated at the out folder. Use "source_relative' to generate a separate '[inputfile].openapi.yaml' next to each '[inputfile].proto'.`),
+		Strict:            flags.Bool("strict", false, `fail the generation when errors are found, like conflicting operations or unsupported types`),
+		WarningsAsErrors:  flags.Bool("warnings_as_errors", false, `report the warnings as errors, and fail the generation like strict`),
+		ServicePrefix:     servicePrefix,
+		IdlName:           idlName,
+		ExcludeDeprecated: flags.Bool("exclude_deprecated", false, `drop deprecated operations from the document`),
+		TrailingComments:  flags.Bool("trailing_comments", false, `append the trailing comments of the elements to their descriptions`),
+		DetachedComments:  flags.Bool("detached_comments", false, `prepend the leading detached comments of the elements to their descriptions`),
+		ExamplesFile:      flags.String("examples_file", "", `YAML file of examples by operation ID that replace the synthesized examples`),
+		DisableExamples:   flags.Bool("disable_examples", false, `do not synthesize examples from the schemas`),
+		HoistComponents:   flags.Bool("hoist_components", false, `move the parameters, headers and responses repeated in several operations to the components`),
+		Overlay:           flags.String("overlay", "", `OpenAPI Overlay or merge patch YAML file applied to the document`),
+		Lint:              flags.Bool("lint", false, `report the issues of the linter on the document`),
+		LintDisable:       flags.String("lint_disable", "", `lint rules to skip, separated by ";"`),
+		Include:           flags.String("include", "", `globs of the "Service.Method" names of the methods to document, separated by ";"`),
+		Exclude:           flags.String("exclude", "", `globs of the "Service.Method" names of the methods to leave out, separated by ";"`),
+		Visibility:        flags.String("visibility", "", `document only the public elements (public), or all of them (internal)`),
+		MetaKeys:          flags.String("meta_keys", "", `metainfo keys documented as "X-Meta-*" request headers, separated by ";"`),
+		PersistentKeys:    flags.String("persistent_keys", "", `metainfo keys documented as "X-Meta-Persist-*" request headers, separated by ";"`),
+		BackwardKeys:      flags.String("backward_keys", "", `metainfo keys documented as "X-Meta-Backward-*" response headers, separated by ";"`),
+	}
+
+	serverConf := ServerConfiguration{
+		KitexAddr:      flags.String("kitex_addr", "127.0.0.1:8888", "kitex server address"),
+		ServicePrefix:  servicePrefix,
+		IdlName:        idlName,
+		Transport:      flags.String("transport", "ttheader", `transport protocol of the proxy: "ttheader", "ttheader_framed", "framed" or "grpc"`),
+		RPCTimeout:     flags.String("rpc_timeout", "", `timeout of the calls of the proxy, as a duration like "3s"`),
+		ConnectTimeout: flags.String("connect_timeout", "", `timeout of the connections of the proxy, as a duration like "500ms"`),
+		MaxRetries:     flags.Int("max_retries", 0, `maximum number of retries of the failed calls of the proxy`),
+	}
+
+	return conf, serverConf
+}
+
+// Generate generates the OpenAPI documents and the swagger server of a protoc plugin request,
+// and returns the problems found in the IDL.
+func Generate(plugin *protogen.Plugin, conf Configuration, serverConf ServerConfiguration) ([]*common.Diagnostic, error) {
+	var diagnostics []*common.Diagnostic
+	// Enable "optional" keyword in front of type (e.g. optional string label = 1;)
+	plugin.SupportedFeatures = uint64(pluginpb.CodeGeneratorResponse_FEATURE_PROTO3_OPTIONAL)
+	if err := common.CheckIdlName(*conf.IdlName); err != nil {
+		return nil, err
+	}
+	documentFile, idlFile := common.IdlOutputFiles(*conf.IdlName)
+	var documents []*yaml.Node
+	if *conf.OutputMode == "source_relative" {
+		for _, file := range plugin.Files {
+			if !file.Generate {
+				continue
+			}
+			outfileName := strings.TrimSuffix(file.Desc.Path(), filepath.Ext(file.Desc.Path())) + "." + consts.DefaultOutputYamlFile
+			outputFile := plugin.NewGeneratedFile(outfileName, "")
+			gen := NewOpenAPIGenerator(plugin, conf, []*protogen.File{file})
+			err := gen.Run(outputFile)
+			diagnostics = append(diagnostics, gen.Diagnostics()...)
+			if err != nil {
+				return diagnostics, err
+			}
+			documents = append(documents, gen.Document())
+		}
+	} else {
+		outputFile := plugin.NewGeneratedFile(documentFile, "")
+		gen := NewOpenAPIGenerator(plugin, conf, plugin.Files)
+		err := gen.Run(outputFile)
+		diagnostics = append(diagnostics, gen.Diagnostics()...)
+		if err != nil {
+			return diagnostics, err
+		}
+		documents = append(documents, gen.Document())
+	}
+	outputFile := plugin.NewGeneratedFile(consts.DefaultOutputSwaggerFile, "")
+	gen, err := NewServerGenerator(serverConf, plugin.Files)
+	if err != nil {
+		return diagnostics, err
+	}
+	diagnostics = append(diagnostics, gen.Diagnostics()...)
+	if err = gen.Idl.ApplyDocuments(documents...); err != nil {
+		return diagnostics, err
+	}
+	if err = gen.Generate(outputFile); err != nil {
+		return diagnostics, err
+	}
+	if gen.Noop {
+		if err = gen.GenerateNoopFile(plugin.NewGeneratedFile(consts.DefaultOutputNoopFile, "")); err != nil {
+			return diagnostics, err
+		}
+	}
+	if err = gen.GenerateIdlFile(plugin.NewGeneratedFile(idlFile, "")); err != nil {
+		return diagnostics, err
+	}
+	return diagnostics, nil
+}
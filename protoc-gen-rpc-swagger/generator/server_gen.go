@@ -29,16 +29,33 @@ import (
 	"github.com/hertz-contrib/swagger-generate/common/consts"
 	"github.com/hertz-contrib/swagger-generate/common/tpl"
 	"github.com/hertz-contrib/swagger-generate/common/utils"
+	"github.com/jhump/protoreflect/desc"
+	"github.com/jhump/protoreflect/desc/protoprint"
 	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
 )
 
 type ServerConfiguration struct {
-	KitexAddr *string
+	KitexAddr      *string
+	ServicePrefix  *bool
+	IdlName        *string
+	Transport      *string
+	RPCTimeout     *string
+	ConnectTimeout *string
+	MaxRetries     *int
 }
 
 type ServerGenerator struct {
+	utils.ClientOptions
 	IdlPath   string
 	KitexAddr string
+	Idl       *utils.Idl // Idl is the IDL registered into the server, with its services and files.
+	Noop      bool       // Noop is set by Generate unless the swagger file was generated without build constraint.
+
+	diagnostics []*utils.Diagnostic
 }
 
 func NewServerGenerator(conf ServerConfiguration, inputFiles []*protogen.File) (*ServerGenerator, error) {
@@ -47,18 +64,13 @@ func NewServerGenerator(conf ServerConfiguration, inputFiles []*protogen.File) (
 		*kitexAddr = consts.DefaultKitexAddr
 	}
 
-	var idlPath string
 	var genFiles []*protogen.File
 	for _, f := range inputFiles {
 		if f.Generate {
 			genFiles = append(genFiles, f)
 		}
 	}
-	if len(genFiles) > 1 {
-		return nil, errors.New("only one .proto file is supported for generation swagger")
-	} else if len(genFiles) == 1 {
-		idlPath = genFiles[0].Desc.Path()
-	} else {
+	if len(genFiles) == 0 {
 		return nil, errors.New("no .proto files marked for generation")
 	}
 	// Check if Hertz and Kitex addresses are valid (basic validation)
@@ -66,12 +78,116 @@ func NewServerGenerator(conf ServerConfiguration, inputFiles []*protogen.File) (
 		return nil, fmt.Errorf("invalid Kitex address: %w", err)
 	}
 
+	clientOptions := utils.ClientOptions{
+		Transport:      *conf.Transport,
+		RPCTimeout:     *conf.RPCTimeout,
+		ConnectTimeout: *conf.ConnectTimeout,
+		MaxRetries:     *conf.MaxRetries,
+	}
+	transports := []string{consts.TransportTTHeader, consts.TransportTTHeaderFramed, consts.TransportFramed, consts.TransportGRPC}
+	if err := clientOptions.Check(transports, []string{consts.CodecJSON}); err != nil {
+		return nil, err
+	}
+
+	idlFiles, err := printIdlFiles(inputFiles)
+	if err != nil {
+		return nil, err
+	}
+
+	idl := &utils.Idl{
+		Name:          *conf.IdlName,
+		ServicePrefix: *conf.ServicePrefix,
+		Files:         idlFiles,
+	}
+	var diagnostics []*utils.Diagnostic
+	for _, f := range genFiles {
+		for i, svc := range f.Services {
+			service := &utils.IdlService{
+				Name:    string(svc.Desc.Name()),
+				Package: string(f.Desc.Package()),
+				File:    f.Desc.Path(),
+			}
+			for _, m := range svc.Methods {
+				// The generic clients of a file call the methods of the last service declaring their names.
+				if later := serviceDeclaring(f.Services[i+1:], m.Desc.Name()); later != nil {
+					diagnostics = append(diagnostics, &utils.Diagnostic{
+						Severity: utils.SeverityWarning,
+						Code:     utils.DiagnosticUnproxiedMethod,
+						Location: fmt.Sprintf("%s (%s)", descriptorLocation(m.Desc), m.Desc.FullName()),
+						Message:  fmt.Sprintf("method %s is not proxied, %s declares a method of the same name", m.Desc.Name(), later.Desc.Name()),
+					})
+					continue
+				}
+				service.Methods = append(service.Methods, &utils.IdlMethod{Name: string(m.Desc.Name())})
+			}
+			idl.Services = append(idl.Services, service)
+		}
+	}
+
 	return &ServerGenerator{
-		IdlPath:   idlPath,
-		KitexAddr: *kitexAddr,
+		ClientOptions: clientOptions,
+		IdlPath:       genFiles[0].Desc.Path(),
+		KitexAddr:     *kitexAddr,
+		Idl:           idl,
+		diagnostics:   diagnostics,
 	}, nil
 }
 
+// serviceDeclaring returns the first of the services declaring a method of a name, or nil.
+func serviceDeclaring(services []*protogen.Service, name protoreflect.Name) *protogen.Service {
+	for _, svc := range services {
+		for _, m := range svc.Methods {
+			if m.Desc.Name() == name {
+				return svc
+			}
+		}
+	}
+	return nil
+}
+
+// Diagnostics returns the methods left out of the proxy.
+func (g *ServerGenerator) Diagnostics() []*utils.Diagnostic {
+	return g.diagnostics
+}
+
+// printIdlFiles returns the contents of the proto files of a plugin request, printed from their descriptors
+// since protoc does not pass their sources, keyed by their import paths. The well-known types are left out,
+// the generic client of the server knows them.
+func printIdlFiles(inputFiles []*protogen.File) (map[string]string, error) {
+	fds := make([]*descriptorpb.FileDescriptorProto, 0, len(inputFiles))
+	for _, f := range inputFiles {
+		// The options of the extensions known to the plugin are decoded again as unknown fields,
+		// which the printer interprets with the extensions declared by the imports.
+		b, err := proto.Marshal(f.Proto)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal %s: %w", f.Desc.Path(), err)
+		}
+		fd := &descriptorpb.FileDescriptorProto{}
+		if err = (proto.UnmarshalOptions{Resolver: &protoregistry.Types{}}).Unmarshal(b, fd); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal %s: %w", f.Desc.Path(), err)
+		}
+		fds = append(fds, fd)
+	}
+	descs, err := desc.CreateFileDescriptors(fds)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load the proto files: %w", err)
+	}
+
+	files := make(map[string]string, len(descs))
+	printer := &protoprint.Printer{}
+	for path, d := range descs {
+		if strings.HasPrefix(path, "google/protobuf/") {
+			continue
+		}
+		content, err := printer.PrintProtoToString(d)
+		if err != nil {
+			return nil, fmt.Errorf("failed to print %s: %w", path, err)
+		}
+		files[path] = content
+	}
+	return files, nil
+}
+
 func validateAddress(addr string) error {
 	if addr == "" {
 		return errors.New("address cannot be empty")
@@ -85,15 +201,18 @@ func validateAddress(addr string) error {
 func (g *ServerGenerator) Generate(outputFile *protogen.GeneratedFile) error {
 	filePath := filepath.Join(filepath.Dir(g.IdlPath), consts.DefaultOutputSwaggerFile)
 	if utils.FileExists(filePath) {
-		updatedContent, err := updateVariables(filePath, g.KitexAddr, g.IdlPath)
+		updatedContent, err := updateVariables(filePath, g.KitexAddr, g.ClientOptions)
 		if err != nil {
-			return errors.New("failed to update variables in the existing file")
+			return fmt.Errorf("failed to update variables in the existing file: %w", err)
 		}
 		if _, err = outputFile.Write([]byte(updatedContent)); err != nil {
 			return errors.New("failed to write output file")
 		}
+		// The swagger files generated before the build constraint was introduced have no no-op file
+		g.Noop = strings.Contains(updatedContent, consts.BuildConstraintSwagger)
 	} else {
-		tmpl, err := template.New("server").Delims("{{", "}}").Parse(consts.CodeGenerationCommentPbRpc + "\n" + tpl.ServerTemplateRpcPb)
+		g.Noop = true
+		tmpl, err := template.New("server").Delims("{{", "}}").Parse(consts.BuildConstraintSwagger + "\n\n" + consts.CodeGenerationCommentPbRpc + "\n" + tpl.ServerTemplateRpcPb)
 		if err != nil {
 			return fmt.Errorf("failed to parse template: %w", err)
 		}
@@ -111,17 +230,39 @@ func (g *ServerGenerator) Generate(outputFile *protogen.GeneratedFile) error {
 	return nil
 }
 
-func updateVariables(filePath, newKitexAddr, newIdlPath string) (string, error) {
+// GenerateNoopFile generates the swagger file compiled instead of the swagger server with the noswagger build tag.
+func (g *ServerGenerator) GenerateNoopFile(outputFile *protogen.GeneratedFile) error {
+	if _, err := outputFile.Write([]byte(consts.BuildConstraintNoop + "\n\n" + consts.CodeGenerationCommentPbRpc + "\n" + tpl.NoopTemplateRpcPb)); err != nil {
+		return fmt.Errorf("failed to write output file: %v", err)
+	}
+	return nil
+}
+
+// GenerateIdlFile generates the file registering the IDL into the server, regenerated with the document.
+func (g *ServerGenerator) GenerateIdlFile(outputFile *protogen.GeneratedFile) error {
+	content, err := utils.EmbedIdl(consts.CodeGenerationCommentPbRpc, g.Idl)
+	if err != nil {
+		return err
+	}
+	if _, err = outputFile.Write(content); err != nil {
+		return fmt.Errorf("failed to write output file: %v", err)
+	}
+	return nil
+}
+
+func updateVariables(filePath, newKitexAddr string, clientOptions utils.ClientOptions) (string, error) {
 	content, err := ioutil.ReadFile(filePath)
 	if err != nil {
 		return "", fmt.Errorf("failed to read file: %v", err)
 	}
 
-	kitexAddrPattern := regexp.MustCompile(`kitexAddr\s*=\s*"(.*?)"`)
-	idlPathPattern := regexp.MustCompile(`idlFile\s*=\s*"(.*?)"`)
+	// Files generated before swagger-runtime was used declare the proxy themselves, and do not compile with idl.go.
+	if !bytes.Contains(content, []byte("kitexproxy.Server")) {
+		return "", errors.New("the existing file does not support the generated IDL files, remove it to regenerate")
+	}
 
+	kitexAddrPattern := regexp.MustCompile(`kitexAddr\s*=\s*"(.*?)"`)
 	updatedContent := kitexAddrPattern.ReplaceAllString(string(content), fmt.Sprintf(`kitexAddr = "%s"`, newKitexAddr))
-	updatedContent = idlPathPattern.ReplaceAllString(updatedContent, fmt.Sprintf(`idlFile = "%s"`, newIdlPath))
 
-	return updatedContent, nil
+	return utils.UpdateConsts(filePath, updatedContent, clientOptions.Consts())
 }
@@ -34,8 +34,11 @@
 package wellknown
 
 import (
+	"github.com/hertz-contrib/swagger-generate/common/consts"
+	common "github.com/hertz-contrib/swagger-generate/common/utils"
 	v3 "github.com/hertz-contrib/swagger-generate/idl/protobuf/openapi"
 	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
 )
 
 func NewStringSchema() *v3.SchemaOrReference {
@@ -80,16 +83,32 @@ func NewNumberSchema(format string) *v3.SchemaOrReference {
 
 func NewEnumSchema(enum_type *string, field protoreflect.FieldDescriptor) *v3.SchemaOrReference {
 	schema := &v3.Schema{Format: "enum"}
+	values := field.Enum().Values()
+	var deprecatedValues []interface{}
 	if enum_type != nil && *enum_type == "string" {
 		schema.Type = "string"
-		schema.Enum = make([]*v3.Any, 0, field.Enum().Values().Len())
-		for i := 0; i < field.Enum().Values().Len(); i++ {
+		schema.Enum = make([]*v3.Any, 0, values.Len())
+		for i := 0; i < values.Len(); i++ {
 			schema.Enum = append(schema.Enum, &v3.Any{
-				Yaml: string(field.Enum().Values().Get(i).Name()),
+				Yaml: string(values.Get(i).Name()),
 			})
+			if values.Get(i).Options().(*descriptorpb.EnumValueOptions).GetDeprecated() {
+				deprecatedValues = append(deprecatedValues, string(values.Get(i).Name()))
+			}
 		}
 	} else {
 		schema.Type = "integer"
+		for i := 0; i < values.Len(); i++ {
+			if values.Get(i).Options().(*descriptorpb.EnumValueOptions).GetDeprecated() {
+				deprecatedValues = append(deprecatedValues, int32(values.Get(i).Number()))
+			}
+		}
+	}
+	if len(deprecatedValues) > 0 {
+		schema.SpecificationExtension = append(schema.SpecificationExtension, &v3.NamedAny{
+			Name:  consts.ExtensionDeprecatedEnumValues,
+			Value: &v3.Any{Yaml: common.YAMLValue(deprecatedValues)},
+		})
 	}
 	return &v3.SchemaOrReference{
 		Oneof: &v3.SchemaOrReference_Schema{
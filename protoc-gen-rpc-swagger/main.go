@@ -35,66 +35,32 @@ package main
 
 import (
 	"flag"
-	"path/filepath"
-	"strings"
+	"fmt"
+	"os"
 
-	"github.com/hertz-contrib/swagger-generate/common/consts"
+	common "github.com/hertz-contrib/swagger-generate/common/utils"
 	"github.com/hertz-contrib/swagger-generate/protoc-gen-rpc-swagger/generator"
 	"google.golang.org/protobuf/compiler/protogen"
-	"google.golang.org/protobuf/types/pluginpb"
 )
 
 var flags flag.FlagSet
 
 func main() {
-	conf := generator.Configuration{
-		Version:        flags.String("version", "3.0.3", "version number text, e.g. 1.2.3"),
-		Title:          flags.String("title", "", "name of the API"),
-		Description:    flags.String("description", "", "description of the API"),
-		Naming:         flags.String("naming", "json", `naming convention. Use "proto" for passing names directly from the proto files`),
-		FQSchemaNaming: flags.Bool("fq_schema_naming", false, `schema naming convention. If "true", generates fully-qualified schema names by prefixing them with the proto message package name`),
-		EnumType:       flags.String("enum_type", "integer", `type for enum serialization. Use "string" for string-based serialization`),
-		OutputMode:     flags.String("output_mode", "merged", `output generation mode. By default, a single openapi.yaml is generated at the out folder. Use "source_relative' to generate a separate '[inputfile].openapi.yaml' next to each '[inputfile].proto'.`),
-	}
-
-	serverConf := generator.ServerConfiguration{
-		KitexAddr: flags.String("kitex_addr", "127.0.0.1:8888", "kitex server address"),
-	}
+	conf, serverConf := generator.NewConfiguration(&flags)
 
 	opts := protogen.Options{
 		ParamFunc: flags.Set,
 	}
 
 	opts.Run(func(plugin *protogen.Plugin) error {
-		// Enable "optional" keyword in front of type (e.g. optional string label = 1;)
-		plugin.SupportedFeatures = uint64(pluginpb.CodeGeneratorResponse_FEATURE_PROTO3_OPTIONAL)
-		if *conf.OutputMode == "source_relative" {
-			for _, file := range plugin.Files {
-				if !file.Generate {
-					continue
-				}
-				outfileName := strings.TrimSuffix(file.Desc.Path(), filepath.Ext(file.Desc.Path())) + "." + consts.DefaultOutputYamlFile
-				outputFile := plugin.NewGeneratedFile(outfileName, "")
-				gen := generator.NewOpenAPIGenerator(plugin, conf, []*protogen.File{file})
-				if err := gen.Run(outputFile); err != nil {
-					return err
-				}
+		diagnostics, err := generator.Generate(plugin, conf, serverConf)
+		for _, d := range diagnostics {
+			if d.Severity == common.SeverityError {
+				fmt.Fprintf(os.Stderr, "[ERROR] %s\n", d)
+			} else {
+				fmt.Fprintf(os.Stderr, "[WARN] %s\n", d)
 			}
-		} else {
-			outputFile := plugin.NewGeneratedFile(consts.DefaultOutputYamlFile, "")
-			gen := generator.NewOpenAPIGenerator(plugin, conf, plugin.Files)
-			if err := gen.Run(outputFile); err != nil {
-				return err
-			}
-		}
-		outputFile := plugin.NewGeneratedFile(consts.DefaultOutputSwaggerFile, "")
-		gen, err := generator.NewServerGenerator(serverConf, plugin.Files)
-		if err != nil {
-			return err
-		}
-		if err = gen.Generate(outputFile); err != nil {
-			return err
 		}
-		return nil
+		return err
 	})
 }
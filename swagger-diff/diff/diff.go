@@ -0,0 +1,529 @@
+/*
+ * Copyright 2024 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package diff
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	common "github.com/hertz-contrib/swagger-generate/common/utils"
+	"github.com/hertz-contrib/swagger-generate/idl/thrift"
+)
+
+// Change is a difference between two documents.
+type Change struct {
+	// Operation is the method and path of the operation, e.g. `GET /pets/{id}`.
+	Operation   string `json:"operation"`
+	OperationID string `json:"operationId,omitempty"`
+	// Location is the part of the operation that changed, e.g. `response 200 application/json`.
+	Location string `json:"location,omitempty"`
+	// Path is the property in the schema of the location, e.g. `items[].name`.
+	Path     string `json:"path,omitempty"`
+	Message  string `json:"message"`
+	Breaking bool   `json:"breaking"`
+	// Allowed is true for the breaking changes of allow-listed operations.
+	Allowed bool `json:"allowed,omitempty"`
+}
+
+// Compare returns the changes of the operations from the old document to the new one.
+// Changes are breaking when a client written against the old document may fail with the new one.
+func Compare(old, new *openapi.Document) []*Change {
+	c := &comparer{old: old, new: new}
+	oldOperations, newOperations := operations(old), operations(new)
+	for _, o := range oldOperations {
+		n := findOperation(newOperations, o.key)
+		if n == nil {
+			c.operation = o
+			c.add("", true, "operation removed")
+			continue
+		}
+		c.operation = n
+		c.compareOperation(o.Operation, n.Operation)
+	}
+	for _, n := range newOperations {
+		if findOperation(oldOperations, n.key) == nil {
+			c.operation = n
+			c.add("", false, "operation added")
+		}
+	}
+	return c.changes
+}
+
+// Allow marks the breaking changes of the operations with the given IDs as allowed.
+func Allow(changes []*Change, operationIDs []string) {
+	for _, change := range changes {
+		for _, id := range operationIDs {
+			if change.Breaking && change.OperationID == id {
+				change.Allowed = true
+			}
+		}
+	}
+}
+
+// HasBreaking returns true if there are breaking changes that are not allowed.
+func HasBreaking(changes []*Change) bool {
+	for _, change := range changes {
+		if change.Breaking && !change.Allowed {
+			return true
+		}
+	}
+	return false
+}
+
+type operation struct {
+	*openapi.Operation
+	// name is the method and path of the operation.
+	name string
+	// key is the name with the path templates removed, so that renamed templates match.
+	key string
+}
+
+var pathTemplate = regexp.MustCompile(`{[^}]*}`)
+
+// operations returns the operations of a document in order.
+func operations(d *openapi.Document) []*operation {
+	var operations []*operation
+	if d.Paths == nil {
+		return operations
+	}
+	for _, path := range d.Paths.Path {
+		item := path.Value
+		if item == nil {
+			continue
+		}
+		for _, o := range []struct {
+			method    string
+			operation *openapi.Operation
+		}{
+			{"GET", item.Get}, {"PUT", item.Put}, {"POST", item.Post}, {"DELETE", item.Delete},
+			{"OPTIONS", item.Options}, {"HEAD", item.Head}, {"PATCH", item.Patch}, {"TRACE", item.Trace},
+		} {
+			if o.operation == nil {
+				continue
+			}
+			operations = append(operations, &operation{
+				Operation: mergePathParameters(o.operation, item.Parameters),
+				name:      o.method + " " + path.Name,
+				key:       o.method + " " + pathTemplate.ReplaceAllString(path.Name, "{}"),
+			})
+		}
+	}
+	return operations
+}
+
+// mergePathParameters returns an operation with the parameters of its path item.
+func mergePathParameters(o *openapi.Operation, parameters []*openapi.ParameterOrReference) *openapi.Operation {
+	if len(parameters) == 0 {
+		return o
+	}
+	merged := *o
+	merged.Parameters = append(append([]*openapi.ParameterOrReference{}, parameters...), o.Parameters...)
+	return &merged
+}
+
+func findOperation(operations []*operation, key string) *operation {
+	for _, o := range operations {
+		if o.key == key {
+			return o
+		}
+	}
+	return nil
+}
+
+type comparer struct {
+	old, new  *openapi.Document
+	operation *operation
+	changes   []*Change
+	// visited holds the pairs of schema references being compared, to stop at recursive schemas.
+	visited map[string]bool
+}
+
+func (c *comparer) add(location string, breaking bool, format string, a ...interface{}) {
+	c.addPath(location, "", breaking, format, a...)
+}
+
+func (c *comparer) addPath(location, path string, breaking bool, format string, a ...interface{}) {
+	c.changes = append(c.changes, &Change{
+		Operation:   c.operation.name,
+		OperationID: c.operation.OperationID,
+		Location:    location,
+		Path:        path,
+		Message:     fmt.Sprintf(format, a...),
+		Breaking:    breaking,
+	})
+}
+
+func (c *comparer) compareOperation(old, new *openapi.Operation) {
+	if !old.Deprecated && new.Deprecated {
+		c.add("", false, "operation deprecated")
+	}
+	c.compareParameters(old.Parameters, new.Parameters)
+	c.compareRequestBody(c.requestBody(c.old, old.RequestBody), c.requestBody(c.new, new.RequestBody))
+	c.compareResponses(old.Responses, new.Responses)
+}
+
+func (c *comparer) compareParameters(old, new []*openapi.ParameterOrReference) {
+	oldParameters, newParameters := c.parameters(c.old, old), c.parameters(c.new, new)
+	for _, o := range oldParameters {
+		location := "parameter " + o.In + ":" + o.Name
+		n := findParameter(newParameters, o)
+		if n == nil {
+			c.add(location, true, "parameter removed")
+			continue
+		}
+		if !o.Required && n.Required {
+			c.add(location, true, "parameter became required")
+		} else if o.Required && !n.Required {
+			c.add(location, false, "parameter became optional")
+		}
+		if !o.Deprecated && n.Deprecated {
+			c.add(location, false, "parameter deprecated")
+		}
+		c.compareSchema(location, "", o.Schema, n.Schema, true)
+	}
+	for _, n := range newParameters {
+		if findParameter(oldParameters, n) == nil {
+			location := "parameter " + n.In + ":" + n.Name
+			if n.Required {
+				c.add(location, true, "required parameter added")
+			} else {
+				c.add(location, false, "optional parameter added")
+			}
+		}
+	}
+}
+
+// parameters resolves a list of parameters, the later ones overriding the earlier ones with the same location and name.
+func (c *comparer) parameters(d *openapi.Document, list []*openapi.ParameterOrReference) []*openapi.Parameter {
+	var parameters []*openapi.Parameter
+	for _, p := range list {
+		parameter := p.Parameter
+		if p.Reference != nil {
+			parameter = resolveParameter(d, p.Reference.Xref)
+		}
+		if parameter == nil {
+			continue
+		}
+		if i := indexParameter(parameters, parameter); i >= 0 {
+			parameters[i] = parameter
+			continue
+		}
+		parameters = append(parameters, parameter)
+	}
+	return parameters
+}
+
+func findParameter(parameters []*openapi.Parameter, p *openapi.Parameter) *openapi.Parameter {
+	if i := indexParameter(parameters, p); i >= 0 {
+		return parameters[i]
+	}
+	return nil
+}
+
+func indexParameter(parameters []*openapi.Parameter, p *openapi.Parameter) int {
+	for i, parameter := range parameters {
+		if parameter.In == p.In && parameter.Name == p.Name {
+			return i
+		}
+	}
+	return -1
+}
+
+func (c *comparer) requestBody(d *openapi.Document, body *openapi.RequestBodyOrReference) *openapi.RequestBody {
+	if body == nil {
+		return nil
+	}
+	if body.Reference != nil {
+		return resolveRequestBody(d, body.Reference.Xref)
+	}
+	return body.RequestBody
+}
+
+func (c *comparer) compareRequestBody(old, new *openapi.RequestBody) {
+	switch {
+	case old == nil && new == nil:
+		return
+	case old == nil:
+		c.add("request body", new.Required, "request body added")
+		return
+	case new == nil:
+		c.add("request body", true, "request body removed")
+		return
+	}
+	if !old.Required && new.Required {
+		c.add("request body", true, "request body became required")
+	}
+	c.compareContent("request body", old.Content, new.Content, true)
+}
+
+func (c *comparer) compareResponses(old, new *openapi.Responses) {
+	oldResponses, newResponses := c.responses(c.old, old), c.responses(c.new, new)
+	for _, o := range oldResponses {
+		location := "response " + o.Name
+		n := findResponse(newResponses, o.Name)
+		if n == nil {
+			c.add(location, true, "response removed")
+			continue
+		}
+		c.compareHeaders(location, o.Value.Headers, n.Value.Headers)
+		c.compareContent(location, o.Value.Content, n.Value.Content, false)
+	}
+	for _, n := range newResponses {
+		if findResponse(oldResponses, n.Name) == nil {
+			c.add("response "+n.Name, false, "response added")
+		}
+	}
+}
+
+type namedResponse struct {
+	Name  string
+	Value *openapi.Response
+}
+
+// responses resolves the responses of an operation, with the default response named `default`.
+func (c *comparer) responses(d *openapi.Document, responses *openapi.Responses) []*namedResponse {
+	if responses == nil {
+		return nil
+	}
+	list := responses.ResponseOrReference
+	if responses.Default != nil {
+		list = append([]*openapi.NamedResponseOrReference{{Name: "default", Value: responses.Default}}, list...)
+	}
+	var named []*namedResponse
+	for _, r := range list {
+		if r.Value == nil {
+			continue
+		}
+		response := r.Value.Response
+		if r.Value.Reference != nil {
+			response = resolveResponse(d, r.Value.Reference.Xref)
+		}
+		if response != nil {
+			named = append(named, &namedResponse{Name: r.Name, Value: response})
+		}
+	}
+	return named
+}
+
+func findResponse(responses []*namedResponse, name string) *namedResponse {
+	for _, r := range responses {
+		if r.Name == name {
+			return r
+		}
+	}
+	return nil
+}
+
+func (c *comparer) compareHeaders(location string, old, new *openapi.HeadersOrReferences) {
+	if old == nil {
+		return
+	}
+	for _, o := range old.AdditionalProperties {
+		var n *openapi.NamedHeaderOrReference
+		if new != nil {
+			for _, header := range new.AdditionalProperties {
+				if header.Name == o.Name {
+					n = header
+				}
+			}
+		}
+		headerLocation := location + " header " + o.Name
+		if n == nil {
+			c.add(headerLocation, true, "header removed")
+			continue
+		}
+		if oldHeader, newHeader := resolveHeader(c.old, o.Value), resolveHeader(c.new, n.Value); oldHeader != nil && newHeader != nil {
+			c.compareSchema(headerLocation, "", oldHeader.Schema, newHeader.Schema, false)
+		}
+	}
+}
+
+// compareContent compares the media types of a request body or a response.
+func (c *comparer) compareContent(location string, old, new *openapi.MediaTypes, request bool) {
+	if old == nil {
+		return
+	}
+	for _, o := range old.AdditionalProperties {
+		mediaLocation := location + " " + o.Name
+		n := findMediaType(new, o.Name)
+		if n == nil {
+			c.add(mediaLocation, true, "media type removed")
+			continue
+		}
+		if o.Value != nil && n.Value != nil {
+			c.compareSchema(mediaLocation, "", o.Value.Schema, n.Value.Schema, request)
+		}
+	}
+	if new == nil {
+		return
+	}
+	for _, n := range new.AdditionalProperties {
+		if findMediaType(old, n.Name) == nil {
+			c.add(location+" "+n.Name, false, "media type added")
+		}
+	}
+}
+
+func findMediaType(content *openapi.MediaTypes, name string) *openapi.NamedMediaType {
+	if content == nil {
+		return nil
+	}
+	for _, m := range content.AdditionalProperties {
+		if m.Name == name {
+			return m
+		}
+	}
+	return nil
+}
+
+// compareSchema compares the schemas of a request or a response. Requests break when the new schema
+// accepts less than the old one, and responses break when the new schema returns more than the old one.
+func (c *comparer) compareSchema(location, path string, old, new *openapi.SchemaOrReference, request bool) {
+	if old == nil || new == nil {
+		return
+	}
+	if old.Reference != nil && new.Reference != nil {
+		pair := old.Reference.Xref + " " + new.Reference.Xref
+		if c.visited[pair] {
+			return
+		}
+		if c.visited == nil {
+			c.visited = make(map[string]bool)
+		}
+		c.visited[pair] = true
+		defer delete(c.visited, pair)
+	}
+	o, n := resolveSchema(c.old, old), resolveSchema(c.new, new)
+	if o == nil || n == nil {
+		return
+	}
+
+	if o.Type != n.Type {
+		c.addPath(location, path, true, "type changed from %s to %s", typeName(o.Type), typeName(n.Type))
+		return
+	}
+	if o.Format != n.Format {
+		c.addPath(location, path, true, "format changed from %s to %s", typeName(o.Format), typeName(n.Format))
+	}
+	if request && o.Nullable && !n.Nullable {
+		c.addPath(location, path, true, "no longer nullable")
+	} else if !request && !o.Nullable && n.Nullable {
+		c.addPath(location, path, true, "became nullable")
+	}
+	c.compareEnum(location, path, o.Enum, n.Enum, request)
+
+	if o.Items != nil && n.Items != nil && len(o.Items.SchemaOrReference) > 0 && len(n.Items.SchemaOrReference) > 0 {
+		c.compareSchema(location, path+"[]", o.Items.SchemaOrReference[0], n.Items.SchemaOrReference[0], request)
+	}
+	if o.AdditionalProperties != nil && n.AdditionalProperties != nil {
+		c.compareSchema(location, path+"{}", o.AdditionalProperties.SchemaOrReference, n.AdditionalProperties.SchemaOrReference, request)
+	}
+	c.compareProperties(location, path, o, n, request)
+}
+
+func (c *comparer) compareEnum(location, path string, old, new []*openapi.Any, request bool) {
+	oldValues, newValues := enumValues(old), enumValues(new)
+	if len(oldValues) == 0 {
+		if len(newValues) > 0 {
+			c.addPath(location, path, request, "enum added")
+		}
+		return
+	}
+	if len(newValues) == 0 {
+		c.addPath(location, path, !request, "enum removed")
+		return
+	}
+	for _, v := range oldValues {
+		if !common.Contains(newValues, v) {
+			c.addPath(location, path, request, "enum value %s removed", v)
+		}
+	}
+	for _, v := range newValues {
+		if !common.Contains(oldValues, v) {
+			c.addPath(location, path, !request, "enum value %s added", v)
+		}
+	}
+}
+
+func (c *comparer) compareProperties(location, path string, old, new *openapi.Schema, request bool) {
+	oldProperties, newProperties := properties(old), properties(new)
+	for _, o := range oldProperties {
+		propertyPath := propertyPath(path, o.Name)
+		n := findProperty(newProperties, o.Name)
+		if n == nil {
+			// Servers ignore unknown request properties, but clients may read the removed response properties.
+			c.addPath(location, propertyPath, !request, "property removed")
+			continue
+		}
+		oldRequired, newRequired := common.Contains(old.Required, o.Name), common.Contains(new.Required, o.Name)
+		if !oldRequired && newRequired {
+			c.addPath(location, propertyPath, request, "property became required")
+		} else if oldRequired && !newRequired {
+			c.addPath(location, propertyPath, !request, "property became optional")
+		}
+		c.compareSchema(location, propertyPath, o.Value, n.Value, request)
+	}
+	for _, n := range newProperties {
+		if findProperty(oldProperties, n.Name) != nil {
+			continue
+		}
+		if common.Contains(new.Required, n.Name) {
+			c.addPath(location, propertyPath(path, n.Name), request, "required property added")
+		} else {
+			c.addPath(location, propertyPath(path, n.Name), false, "optional property added")
+		}
+	}
+}
+
+func propertyPath(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
+
+func properties(s *openapi.Schema) []*openapi.NamedSchemaOrReference {
+	if s.Properties == nil {
+		return nil
+	}
+	return s.Properties.AdditionalProperties
+}
+
+func findProperty(properties []*openapi.NamedSchemaOrReference, name string) *openapi.NamedSchemaOrReference {
+	for _, p := range properties {
+		if p.Name == name {
+			return p
+		}
+	}
+	return nil
+}
+
+func enumValues(enum []*openapi.Any) []string {
+	values := make([]string, 0, len(enum))
+	for _, v := range enum {
+		values = append(values, strings.TrimSpace(v.Yaml))
+	}
+	return values
+}
+
+func typeName(t string) string {
+	if t == "" {
+		return "none"
+	}
+	return t
+}
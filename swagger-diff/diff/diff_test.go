@@ -0,0 +1,222 @@
+/*
+ * Copyright 2024 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package diff
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/hertz-contrib/swagger-generate/idl/thrift"
+)
+
+// oldPets is the first version of the API compared by the tests.
+const oldPets = `openapi: 3.0.3
+info:
+  title: pets
+  version: 1.0.0
+paths:
+  /pets:
+    get:
+      operationId: ListPets
+      parameters:
+        - {name: limit, in: query, schema: {type: integer}}
+      responses:
+        '200':
+          description: ok
+          headers:
+            X-Total: {schema: {type: integer}}
+          content:
+            application/json:
+              schema: {type: array, items: {$ref: '#/components/schemas/Pet'}}
+    post:
+      operationId: CreatePet
+      requestBody:
+        content:
+          application/json:
+            schema: {$ref: '#/components/schemas/NewPet'}
+      responses:
+        '200': {description: ok}
+  /pets/{id}:
+    get:
+      operationId: GetPet
+      parameters:
+        - {name: id, in: path, required: true, schema: {type: integer}}
+      responses:
+        '200': {$ref: '#/components/responses/Pet'}
+    delete:
+      operationId: DeletePet
+      parameters:
+        - {name: id, in: path, required: true, schema: {type: integer}}
+      responses:
+        '204': {description: deleted}
+components:
+  responses:
+    Pet:
+      description: ok
+      content:
+        application/json:
+          schema: {$ref: '#/components/schemas/Pet'}
+  schemas:
+    Kind: {type: string, enum: [cat, dog]}
+    NewPet:
+      type: object
+      properties:
+        name: {type: string}
+        kind: {$ref: '#/components/schemas/Kind'}
+    Pet:
+      type: object
+      properties:
+        id: {type: integer}
+        name: {type: string}
+        kind: {$ref: '#/components/schemas/Kind'}
+        parent: {$ref: '#/components/schemas/Pet'}
+`
+
+// newPets is the next version of the API, with compatible and breaking changes to every operation.
+const newPets = `openapi: 3.0.3
+info:
+  title: pets
+  version: 2.0.0
+paths:
+  /pets:
+    get:
+      operationId: ListPets
+      parameters:
+        - {name: limit, in: query, required: true, schema: {type: integer}}
+        - {name: tag, in: query, schema: {type: string}}
+      responses:
+        '200':
+          description: ok
+          content:
+            application/json:
+              schema: {type: array, items: {$ref: '#/components/schemas/Pet'}}
+    post:
+      operationId: CreatePet
+      requestBody:
+        content:
+          application/json:
+            schema: {$ref: '#/components/schemas/NewPet'}
+      responses:
+        '200': {description: ok}
+  /pets/{petId}:
+    get:
+      operationId: GetPet
+      deprecated: true
+      parameters:
+        - {name: id, in: path, required: true, schema: {type: string}}
+      responses:
+        '200': {$ref: '#/components/responses/Pet'}
+        '404': {description: not found}
+    put:
+      operationId: UpdatePet
+      responses:
+        '200': {description: ok}
+components:
+  responses:
+    Pet:
+      description: ok
+      content:
+        application/json:
+          schema: {$ref: '#/components/schemas/Pet'}
+  schemas:
+    Kind: {type: string, enum: [cat, dog, bird]}
+    NewPet:
+      type: object
+      required: [name]
+      properties:
+        name: {type: string}
+        kind: {$ref: '#/components/schemas/Kind'}
+    Pet:
+      type: object
+      required: [id]
+      properties:
+        id: {type: string, format: int64}
+        name: {type: string, nullable: true}
+        kind: {$ref: '#/components/schemas/Kind'}
+        parent: {$ref: '#/components/schemas/Pet'}
+`
+
+func parseDocument(t *testing.T, s string) *openapi.Document {
+	t.Helper()
+	d, err := openapi.ParseDocument([]byte(s))
+	if err != nil {
+		t.Fatalf("failed to parse document: %s", err)
+	}
+	return d
+}
+
+// summary returns a change like `breaking GET /pets location path: message`.
+func summary(change *Change) string {
+	kind := "compatible"
+	if change.Breaking {
+		kind = "breaking"
+	}
+	return fmt.Sprintf("%s %s %s %s: %s", kind, change.Operation, change.Location, change.Path, change.Message)
+}
+
+func TestCompare(t *testing.T) {
+	var got []string
+	for _, change := range Compare(parseDocument(t, oldPets), parseDocument(t, newPets)) {
+		got = append(got, summary(change))
+	}
+	want := []string{
+		"breaking GET /pets parameter query:limit : parameter became required",
+		"compatible GET /pets parameter query:tag : optional parameter added",
+		"breaking GET /pets response 200 header X-Total : header removed",
+		"compatible GET /pets response 200 application/json [].id: property became required",
+		"breaking GET /pets response 200 application/json [].id: type changed from integer to string",
+		"breaking GET /pets response 200 application/json [].name: became nullable",
+		"breaking GET /pets response 200 application/json [].kind: enum value bird added",
+		"breaking POST /pets request body application/json name: property became required",
+		"compatible POST /pets request body application/json kind: enum value bird added",
+		// The operations match with the path template renamed, and the recursive parent is compared once.
+		"compatible GET /pets/{petId}  : operation deprecated",
+		"breaking GET /pets/{petId} parameter path:id : type changed from integer to string",
+		"compatible GET /pets/{petId} response 200 application/json id: property became required",
+		"breaking GET /pets/{petId} response 200 application/json id: type changed from integer to string",
+		"breaking GET /pets/{petId} response 200 application/json name: became nullable",
+		"breaking GET /pets/{petId} response 200 application/json kind: enum value bird added",
+		"compatible GET /pets/{petId} response 404 : response added",
+		"breaking DELETE /pets/{id}  : operation removed",
+		"compatible PUT /pets/{petId}  : operation added",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Compare() = %q, want %q", got, want)
+	}
+
+	if changes := Compare(parseDocument(t, newPets), parseDocument(t, newPets)); len(changes) != 0 {
+		t.Errorf("Compare() of the same document = %d changes, want none", len(changes))
+	}
+}
+
+func TestAllow(t *testing.T) {
+	changes := Compare(parseDocument(t, oldPets), parseDocument(t, newPets))
+	Allow(changes, []string{"ListPets", "CreatePet", "GetPet"})
+	if !HasBreaking(changes) {
+		t.Fatalf("HasBreaking() = false with DeletePet removed, want true")
+	}
+	Allow(changes, []string{"DeletePet"})
+	if HasBreaking(changes) {
+		t.Errorf("HasBreaking() = true after allowing every operation, want false")
+	}
+	for _, change := range changes {
+		if change.Allowed != change.Breaking {
+			t.Errorf("%s: allowed = %v, want %v", summary(change), change.Allowed, change.Breaking)
+		}
+	}
+}
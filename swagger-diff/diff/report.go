@@ -0,0 +1,128 @@
+/*
+ * Copyright 2024 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package diff
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+)
+
+const (
+	FormatText     = "text"
+	FormatMarkdown = "markdown"
+	FormatJSON     = "json"
+)
+
+// Write writes the changelog of the changes in a format.
+func Write(w io.Writer, changes []*Change, format string) error {
+	switch format {
+	case FormatText:
+		return writeText(w, changes)
+	case FormatMarkdown:
+		return writeMarkdown(w, changes)
+	case FormatJSON:
+		return writeJSON(w, changes)
+	}
+	return fmt.Errorf("unknown format %s", format)
+}
+
+func writeText(w io.Writer, changes []*Change) error {
+	if len(changes) == 0 {
+		_, err := fmt.Fprintln(w, "No changes.")
+		return err
+	}
+	for _, change := range changes {
+		if _, err := fmt.Fprintf(w, "%-14s %s\n", kind(change), describe(change, false)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func writeMarkdown(w io.Writer, changes []*Change) error {
+	var b strings.Builder
+	b.WriteString("# API Changes\n")
+	if len(changes) == 0 {
+		b.WriteString("\nNo changes.\n")
+	}
+	for _, section := range []struct {
+		title string
+		match func(*Change) bool
+	}{
+		{"Breaking changes", func(c *Change) bool { return c.Breaking && !c.Allowed }},
+		{"Allowed breaking changes", func(c *Change) bool { return c.Breaking && c.Allowed }},
+		{"Non-breaking changes", func(c *Change) bool { return !c.Breaking }},
+	} {
+		var lines []string
+		for _, change := range changes {
+			if section.match(change) {
+				lines = append(lines, "- "+describe(change, true))
+			}
+		}
+		if len(lines) > 0 {
+			fmt.Fprintf(&b, "\n## %s\n\n%s\n", section.title, strings.Join(lines, "\n"))
+		}
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func writeJSON(w io.Writer, changes []*Change) error {
+	report := struct {
+		Breaking bool      `json:"breaking"`
+		Changes  []*Change `json:"changes"`
+	}{HasBreaking(changes), changes}
+	if report.Changes == nil {
+		report.Changes = []*Change{}
+	}
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(report)
+}
+
+func kind(change *Change) string {
+	switch {
+	case change.Breaking && change.Allowed:
+		return "allowed"
+	case change.Breaking:
+		return "breaking"
+	}
+	return "non-breaking"
+}
+
+// describe returns a line like `GET /pets/{id} (Pets_Get) response 200 application/json name: property removed`.
+func describe(change *Change, markdown bool) string {
+	code := func(s string) string {
+		if markdown {
+			return "`" + s + "`"
+		}
+		return s
+	}
+	parts := []string{code(change.Operation)}
+	if change.OperationID != "" {
+		parts = append(parts, "("+code(change.OperationID)+")")
+	}
+	if change.Location != "" {
+		parts = append(parts, change.Location)
+	}
+	if change.Path != "" {
+		parts = append(parts, code(change.Path))
+	}
+	return strings.Join(parts, " ") + ": " + change.Message
+}
@@ -0,0 +1,113 @@
+/*
+ * Copyright 2024 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package diff
+
+import (
+	"strings"
+
+	"github.com/hertz-contrib/swagger-generate/idl/thrift"
+)
+
+const (
+	schemasPrefix       = "#/components/schemas/"
+	parametersPrefix    = "#/components/parameters/"
+	responsesPrefix     = "#/components/responses/"
+	requestBodiesPrefix = "#/components/requestBodies/"
+	headersPrefix       = "#/components/headers/"
+)
+
+// resolveSchema returns the schema of a reference in the components of a document.
+// The generators wrap references in an `allOf` to add a description, which is unwrapped too.
+func resolveSchema(d *openapi.Document, s *openapi.SchemaOrReference) *openapi.Schema {
+	for i := 0; s != nil && s.Reference != nil && i < 32; i++ {
+		s = findSchema(d, s.Reference.Xref)
+	}
+	if s == nil || s.Reference != nil {
+		return nil
+	}
+	if schema := s.Schema; schema != nil && len(schema.AllOf) == 1 && schema.Type == "" && schema.Properties == nil {
+		return resolveSchema(d, schema.AllOf[0])
+	}
+	return s.Schema
+}
+
+func findSchema(d *openapi.Document, ref string) *openapi.SchemaOrReference {
+	if d.Components == nil || d.Components.Schemas == nil || !strings.HasPrefix(ref, schemasPrefix) {
+		return nil
+	}
+	for _, s := range d.Components.Schemas.AdditionalProperties {
+		if s.Name == strings.TrimPrefix(ref, schemasPrefix) {
+			return s.Value
+		}
+	}
+	return nil
+}
+
+func resolveParameter(d *openapi.Document, ref string) *openapi.Parameter {
+	if d.Components == nil || d.Components.Parameters == nil || !strings.HasPrefix(ref, parametersPrefix) {
+		return nil
+	}
+	for _, p := range d.Components.Parameters.AdditionalProperties {
+		if p.Name == strings.TrimPrefix(ref, parametersPrefix) && p.Value != nil {
+			return p.Value.Parameter
+		}
+	}
+	return nil
+}
+
+func resolveResponse(d *openapi.Document, ref string) *openapi.Response {
+	if d.Components == nil || d.Components.Responses == nil || !strings.HasPrefix(ref, responsesPrefix) {
+		return nil
+	}
+	for _, r := range d.Components.Responses.AdditionalProperties {
+		if r.Name == strings.TrimPrefix(ref, responsesPrefix) && r.Value != nil {
+			return r.Value.Response
+		}
+	}
+	return nil
+}
+
+func resolveRequestBody(d *openapi.Document, ref string) *openapi.RequestBody {
+	if d.Components == nil || d.Components.RequestBodies == nil || !strings.HasPrefix(ref, requestBodiesPrefix) {
+		return nil
+	}
+	for _, r := range d.Components.RequestBodies.AdditionalProperties {
+		if r.Name == strings.TrimPrefix(ref, requestBodiesPrefix) && r.Value != nil {
+			return r.Value.RequestBody
+		}
+	}
+	return nil
+}
+
+// resolveHeader returns a header, or the header of its reference in the components of a document.
+func resolveHeader(d *openapi.Document, h *openapi.HeaderOrReference) *openapi.Header {
+	if h == nil {
+		return nil
+	}
+	if h.Reference == nil {
+		return h.Header
+	}
+	if d.Components == nil || d.Components.Headers == nil || !strings.HasPrefix(h.Reference.Xref, headersPrefix) {
+		return nil
+	}
+	for _, header := range d.Components.Headers.AdditionalProperties {
+		if header.Name == strings.TrimPrefix(h.Reference.Xref, headersPrefix) && header.Value != nil {
+			return header.Value.Header
+		}
+	}
+	return nil
+}
@@ -0,0 +1,117 @@
+/*
+ * Copyright 2024 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/hertz-contrib/swagger-generate/idl/thrift"
+	"github.com/hertz-contrib/swagger-generate/swagger-diff/diff"
+)
+
+func main() {
+	var format, allow, allowFile string
+
+	f := flag.NewFlagSet(os.Args[0], flag.ContinueOnError)
+	f.StringVar(&format, "format", diff.FormatText, "Format of the changelog: text, markdown or json")
+	f.StringVar(&allow, "allow", "", "Comma separated operation IDs whose breaking changes are allowed")
+	f.StringVar(&allowFile, "allow-file", "", "File of operation IDs whose breaking changes are allowed, one per line")
+	f.Usage = func() {
+		fmt.Fprintf(f.Output(), "Usage: %s [options] <old.yaml> <new.yaml>\n", f.Name())
+		f.PrintDefaults()
+	}
+
+	if err := f.Parse(os.Args[1:]); err != nil {
+		os.Exit(2)
+	}
+	if f.NArg() != 2 {
+		f.Usage()
+		os.Exit(2)
+	}
+
+	os.Exit(run(f.Arg(0), f.Arg(1), format, allow, allowFile))
+}
+
+// run prints the changes between two documents, and returns 1 if there are breaking changes that are not allowed.
+func run(oldFile, newFile, format, allow, allowFile string) int {
+	old, err := readDocument(oldFile)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 2
+	}
+	new, err := readDocument(newFile)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 2
+	}
+
+	allowed, err := allowedOperations(allow, allowFile)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 2
+	}
+
+	changes := diff.Compare(old, new)
+	diff.Allow(changes, allowed)
+	if err = diff.Write(os.Stdout, changes, format); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 2
+	}
+	if diff.HasBreaking(changes) {
+		return 1
+	}
+	return 0
+}
+
+func readDocument(file string) (*openapi.Document, error) {
+	b, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	d, err := openapi.ParseDocument(b)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %s", file, err)
+	}
+	return d, nil
+}
+
+// allowedOperations returns the operation IDs of the allow flag and the lines of the allow file,
+// skipping empty lines and `#` comments.
+func allowedOperations(allow, allowFile string) ([]string, error) {
+	var ids []string
+	for _, id := range strings.Split(allow, ",") {
+		if id = strings.TrimSpace(id); id != "" {
+			ids = append(ids, id)
+		}
+	}
+	if allowFile == "" {
+		return ids, nil
+	}
+	b, err := os.ReadFile(allowFile)
+	if err != nil {
+		return nil, err
+	}
+	for _, line := range strings.Split(string(b), "\n") {
+		if line = strings.TrimSpace(line); line != "" && !strings.HasPrefix(line, "#") {
+			ids = append(ids, line)
+		}
+	}
+	return ids, nil
+}
@@ -0,0 +1,158 @@
+/*
+ * Copyright 2024 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package generate generates the OpenAPI documents and the swagger servers of thrift and proto files in-process,
+// with the plugins of the repository.
+//
+// Every call parses its own IDL files and builds its own generators, so calls can run concurrently.
+package generate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/hertz-contrib/swagger-generate/common/consts"
+	common "github.com/hertz-contrib/swagger-generate/common/utils"
+	protoopenapi "github.com/hertz-contrib/swagger-generate/idl/protobuf/openapi"
+	thriftopenapi "github.com/hertz-contrib/swagger-generate/idl/thrift"
+)
+
+// Type is the type of the services of the IDL files.
+type Type string
+
+const (
+	// TypeHTTP generates the documents of Hertz services.
+	TypeHTTP Type = "http"
+	// TypeRPC generates the documents of Kitex services, and a swagger server that proxies to them.
+	TypeRPC Type = "rpc"
+)
+
+// Diagnostic is a problem found in the IDL files.
+type Diagnostic = common.Diagnostic
+
+// Input is the IDL files to generate.
+type Input struct {
+	// Files are one thrift file, or proto files. The includes of a thrift file and the imports of
+	// the proto files are generated too.
+	Files []string
+	// Includes are the directories searched for the included IDL files.
+	Includes []string
+}
+
+// Options are the options of the generation.
+//
+// A generation of thrift files has one thrift file, like thriftgo: the document of several thrift files is
+// generated by a call for each, into different output directories, or for TypeRPC into the same output
+// directory with different `idl_name` plugin options, which are proxied by the same swagger server.
+type Options struct {
+	// Type is the type of the services, TypeHTTP by default.
+	Type Type
+	// OutputDir is the directory of the generated files, consts.DefaultOutputDir by default.
+	OutputDir string
+	// PluginOptions are the options of the plugin, e.g. `lint=true`.
+	PluginOptions []string
+}
+
+// File is a generated file.
+type File struct {
+	// Name is the path of the file, in the output directory.
+	Name    string
+	Content []byte
+}
+
+// Document is a generated OpenAPI document.
+type Document struct {
+	*File
+	// Thrift is the model of the document of a thrift file.
+	Thrift *thriftopenapi.Document
+	// Proto is the model of the document of proto files.
+	Proto *protoopenapi.Document
+}
+
+// Result is the result of a generation.
+type Result struct {
+	// Documents are the generated OpenAPI documents.
+	Documents []*Document
+	// Files are all the generated files, the documents, swagger.go and the idl.go registering the IDL of RPC services.
+	Files []*File
+}
+
+// Generate parses the IDL files and generates their documents with the plugin of the type.
+// The diagnostics are returned even if the generation fails.
+func Generate(ctx context.Context, input Input, opts Options) (*Result, []*Diagnostic, error) {
+	if len(input.Files) == 0 {
+		return nil, nil, errors.New("no IDL file to generate")
+	}
+	if opts.Type == "" {
+		opts.Type = TypeHTTP
+	}
+	if opts.Type != TypeHTTP && opts.Type != TypeRPC {
+		return nil, nil, fmt.Errorf("unknown type %s, the types are %s and %s", opts.Type, TypeHTTP, TypeRPC)
+	}
+	if opts.OutputDir == "" {
+		opts.OutputDir = consts.DefaultOutputDir
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, nil, err
+	}
+
+	var (
+		files       []*File
+		diagnostics []*Diagnostic
+		err         error
+	)
+	switch ext := filepath.Ext(input.Files[0]); ext {
+	case ".thrift":
+		if len(input.Files) > 1 {
+			return nil, nil, errors.New("only one thrift file can be generated at a time, its includes are parsed too, " +
+				"generate the others with different IdlName options into the same output directory")
+		}
+		files, diagnostics, err = generateThrift(ctx, input, opts)
+	case ".proto":
+		for _, file := range input.Files {
+			if filepath.Ext(file) != ext {
+				return nil, nil, fmt.Errorf("%s is not a proto file", file)
+			}
+		}
+		files, diagnostics, err = generateProto(ctx, input, opts)
+	default:
+		return nil, nil, fmt.Errorf("unknown IDL %s, the files must end with .thrift or .proto", input.Files[0])
+	}
+	if err != nil {
+		return nil, diagnostics, err
+	}
+
+	res := &Result{Files: files}
+	for _, f := range files {
+		if !strings.HasSuffix(f.Name, consts.DefaultOutputYamlFile) {
+			continue
+		}
+		d := &Document{File: f}
+		if filepath.Ext(input.Files[0]) == ".thrift" {
+			d.Thrift, err = thriftopenapi.ParseDocument(f.Content)
+		} else {
+			d.Proto, err = protoopenapi.ParseDocument(f.Content)
+		}
+		if err != nil {
+			return nil, diagnostics, fmt.Errorf("%s: %s", f.Name, err)
+		}
+		res.Documents = append(res.Documents, d)
+	}
+	return res, diagnostics, nil
+}
@@ -0,0 +1,135 @@
+/*
+ * Copyright 2024 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package generate
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"path/filepath"
+	"strings"
+
+	"github.com/bufbuild/protocompile"
+	httpgenerator "github.com/hertz-contrib/swagger-generate/protoc-gen-http-swagger/generator"
+	rpcgenerator "github.com/hertz-contrib/swagger-generate/protoc-gen-rpc-swagger/generator"
+	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/pluginpb"
+)
+
+// generateProto parses proto files and their imports like protoc, and runs the protoc generators of the type.
+func generateProto(ctx context.Context, input Input, opts Options) ([]*File, []*Diagnostic, error) {
+	names := make([]string, len(input.Files))
+	for i, file := range input.Files {
+		names[i] = importName(file, input.Includes)
+	}
+	compiler := protocompile.Compiler{
+		Resolver:       protocompile.WithStandardImports(&protocompile.SourceResolver{ImportPaths: input.Includes}),
+		SourceInfoMode: protocompile.SourceInfoStandard,
+	}
+	fds, err := compiler.Compile(ctx, names...)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req := &pluginpb.CodeGeneratorRequest{
+		FileToGenerate: names,
+		Parameter:      proto.String(strings.Join(opts.PluginOptions, ",")),
+	}
+	// protoc sends the files in topological order, with the imports before the files that import them.
+	seen := make(map[string]bool)
+	var addFile func(fd protoreflect.FileDescriptor) error
+	addFile = func(fd protoreflect.FileDescriptor) error {
+		if seen[fd.Path()] {
+			return nil
+		}
+		seen[fd.Path()] = true
+		for i := 0; i < fd.Imports().Len(); i++ {
+			if err := addFile(fd.Imports().Get(i).FileDescriptor); err != nil {
+				return err
+			}
+		}
+		// The descriptor is marshaled and unmarshaled again, so that the extensions of the options
+		// are decoded by the types of the generators, as in a plugin request.
+		b, err := proto.Marshal(protodesc.ToFileDescriptorProto(fd))
+		if err != nil {
+			return err
+		}
+		fdp := &descriptorpb.FileDescriptorProto{}
+		if err = proto.Unmarshal(b, fdp); err != nil {
+			return err
+		}
+		req.ProtoFile = append(req.ProtoFile, fdp)
+		return nil
+	}
+	for _, fd := range fds {
+		if err = addFile(fd); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	// The configurations are parsed into a flag set of their own, instead of the global one of the plugins.
+	var flags flag.FlagSet
+	var run func(plugin *protogen.Plugin) ([]*Diagnostic, error)
+	if opts.Type == TypeRPC {
+		conf, serverConf := rpcgenerator.NewConfiguration(&flags)
+		run = func(plugin *protogen.Plugin) ([]*Diagnostic, error) {
+			return rpcgenerator.Generate(plugin, conf, serverConf)
+		}
+	} else {
+		conf := httpgenerator.NewConfiguration(&flags)
+		run = func(plugin *protogen.Plugin) ([]*Diagnostic, error) {
+			return httpgenerator.Generate(plugin, conf)
+		}
+	}
+
+	plugin, err := protogen.Options{ParamFunc: flags.Set}.New(req)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err = ctx.Err(); err != nil {
+		return nil, nil, err
+	}
+	diagnostics, err := run(plugin)
+	if err != nil {
+		return nil, diagnostics, err
+	}
+	res := plugin.Response()
+	if res.Error != nil {
+		return nil, diagnostics, errors.New(res.GetError())
+	}
+	// The names of the files are relative to the output directory, as with protoc.
+	files := make([]*File, 0, len(res.File))
+	for _, f := range res.File {
+		files = append(files, &File{Name: filepath.Join(opts.OutputDir, f.GetName()), Content: []byte(f.GetContent())})
+	}
+	return files, diagnostics, nil
+}
+
+// importName returns the name of a proto file relative to the first include directory that contains it,
+// which is the name its imports refer to.
+func importName(file string, includes []string) string {
+	for _, include := range includes {
+		if rel, err := filepath.Rel(include, file); err == nil && !strings.HasPrefix(rel, "..") {
+			return filepath.ToSlash(rel)
+		}
+	}
+	return filepath.ToSlash(file)
+}
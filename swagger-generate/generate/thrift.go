@@ -0,0 +1,104 @@
+/*
+ * Copyright 2024 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package generate
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/cloudwego/thriftgo/parser"
+	"github.com/cloudwego/thriftgo/plugin"
+	"github.com/cloudwego/thriftgo/semantic"
+	common "github.com/hertz-contrib/swagger-generate/common/utils"
+	httpargs "github.com/hertz-contrib/swagger-generate/thrift-gen-http-swagger/args"
+	httpgenerator "github.com/hertz-contrib/swagger-generate/thrift-gen-http-swagger/generator"
+	rpcargs "github.com/hertz-contrib/swagger-generate/thrift-gen-rpc-swagger/args"
+	rpcgenerator "github.com/hertz-contrib/swagger-generate/thrift-gen-rpc-swagger/generator"
+)
+
+// generateThrift parses a thrift file and its includes like thriftgo, and runs the thrift generators of the type.
+// The thrift generators build the reflection descriptors of their own AST, so concurrent calls share nothing.
+func generateThrift(ctx context.Context, input Input, opts Options) ([]*File, []*Diagnostic, error) {
+	ast, err := parser.ParseFile(input.Files[0], input.Includes, true)
+	if err != nil {
+		return nil, nil, err
+	}
+	if path := parser.CircleDetect(ast); len(path) > 0 {
+		return nil, nil, fmt.Errorf("found include circle:\n\t%s", path)
+	}
+	checker := semantic.NewChecker(semantic.Options{FixWarnings: true})
+	warnings, err := checker.CheckAll(ast)
+	var diagnostics []*Diagnostic
+	for _, w := range warnings {
+		diagnostics = append(diagnostics, &Diagnostic{Severity: common.SeverityWarning, Code: common.DiagnosticIDL, Message: w})
+	}
+	if err != nil {
+		return nil, diagnostics, err
+	}
+	if err = semantic.ResolveSymbols(ast); err != nil {
+		return nil, diagnostics, err
+	}
+	if err = ctx.Err(); err != nil {
+		return nil, diagnostics, err
+	}
+
+	// The output directory is only given when the plugin options don't set it, so that the output_dir option replaces it.
+	parameters := opts.PluginOptions
+	if !hasOutputDir(opts.PluginOptions) {
+		parameters = append([]string{"output_dir=" + opts.OutputDir}, opts.PluginOptions...)
+	}
+	var (
+		contents          []*plugin.Generated
+		pluginDiagnostics []*Diagnostic
+	)
+	if opts.Type == TypeRPC {
+		args := new(rpcargs.Arguments)
+		if err = args.Unpack(parameters); err != nil {
+			return nil, diagnostics, err
+		}
+		contents, pluginDiagnostics, err = rpcgenerator.Generate(ast, args)
+	} else {
+		args := new(httpargs.Arguments)
+		if err = args.Unpack(parameters); err != nil {
+			return nil, diagnostics, err
+		}
+		contents, pluginDiagnostics, err = httpgenerator.Generate(ast, args)
+	}
+	diagnostics = append(diagnostics, pluginDiagnostics...)
+	if err != nil {
+		return nil, diagnostics, err
+	}
+
+	// The names of the contents already contain the output directory, as with thriftgo.
+	files := make([]*File, 0, len(contents))
+	for _, c := range contents {
+		files = append(files, &File{Name: c.GetName(), Content: []byte(c.Content)})
+	}
+	return files, diagnostics, nil
+}
+
+// hasOutputDir reports whether the plugin options set the output directory, by either spelling of the option.
+func hasOutputDir(options []string) bool {
+	for _, option := range options {
+		key, _, _ := strings.Cut(option, "=")
+		if common.ArgName(key) == "output_dir" {
+			return true
+		}
+	}
+	return false
+}
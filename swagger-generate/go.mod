@@ -0,0 +1,27 @@
+module github.com/hertz-contrib/swagger-generate/swagger-generate
+
+go 1.18
+
+require (
+	github.com/bufbuild/protocompile v0.6.0
+	github.com/cloudwego/thriftgo v0.3.15
+	github.com/hertz-contrib/swagger-generate v0.0.0-20240921161005-987932fb30c5
+	github.com/hertz-contrib/swagger-generate/protoc-gen-http-swagger v0.0.0-20240921161005-987932fb30c5
+	github.com/hertz-contrib/swagger-generate/protoc-gen-rpc-swagger v0.0.0-20240921161005-987932fb30c5
+	github.com/hertz-contrib/swagger-generate/thrift-gen-http-swagger v0.0.0-20240921161005-987932fb30c5
+	github.com/hertz-contrib/swagger-generate/thrift-gen-rpc-swagger v0.0.0-20240921161005-987932fb30c5
+	google.golang.org/protobuf v1.34.2
+)
+
+require (
+	github.com/apache/thrift v0.17.0 // indirect
+	github.com/cloudwego/hertz/cmd/hz v0.9.1 // indirect
+	github.com/golang/protobuf v1.5.4 // indirect
+	github.com/google/gnostic-models v0.6.9-0.20230804172637-c7be7c783f49 // indirect
+	github.com/jhump/protoreflect v1.12.0 // indirect
+	golang.org/x/sync v0.8.0 // indirect
+	google.golang.org/genproto/googleapis/api v0.0.0-20240730163845-b1a4ccb954bf // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
+
+replace github.com/apache/thrift v0.17.0 => github.com/apache/thrift v0.13.0
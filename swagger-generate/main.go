@@ -0,0 +1,123 @@
+/*
+ * Copyright 2024 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/hertz-contrib/swagger-generate/common/consts"
+	common "github.com/hertz-contrib/swagger-generate/common/utils"
+	"github.com/hertz-contrib/swagger-generate/swagger-generate/generate"
+)
+
+// stringList is a flag that can be repeated.
+type stringList []string
+
+func (l *stringList) String() string {
+	return strings.Join(*l, ",")
+}
+
+func (l *stringList) Set(value string) error {
+	*l = append(*l, value)
+	return nil
+}
+
+func main() {
+	var (
+		typ      string
+		out      string
+		includes stringList
+		options  stringList
+	)
+
+	f := flag.NewFlagSet(os.Args[0], flag.ContinueOnError)
+	f.StringVar(&typ, "type", string(generate.TypeHTTP), "Type of the services: http for Hertz, rpc for Kitex")
+	f.StringVar(&out, "out", consts.DefaultOutputDir, "Output directory of the documents and of swagger.go")
+	f.Var(&includes, "I", "Directory searched for the included IDL files, can be repeated")
+	f.Var(&options, "opt", "Comma separated options of the plugin, e.g. lint=true, can be repeated")
+	f.Usage = func() {
+		fmt.Fprintf(f.Output(), "Usage: %s [options] <file.thrift | file.proto...>\n", f.Name())
+		f.PrintDefaults()
+	}
+
+	if err := f.Parse(os.Args[1:]); err != nil {
+		os.Exit(2)
+	}
+	if f.NArg() == 0 {
+		f.Usage()
+		os.Exit(2)
+	}
+
+	if err := run(f.Args(), typ, out, includes, splitOptions(options)); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+// run generates the documents of the IDL files, prints the diagnostics and writes the generated files.
+func run(files []string, typ, out string, includes, options []string) error {
+	res, diagnostics, err := generate.Generate(context.Background(), generate.Input{
+		Files:    files,
+		Includes: includes,
+	}, generate.Options{
+		Type:          generate.Type(typ),
+		OutputDir:     out,
+		PluginOptions: options,
+	})
+	for _, d := range diagnostics {
+		if d.Severity == common.SeverityError {
+			fmt.Fprintf(os.Stderr, "[ERROR] %s\n", d)
+		} else {
+			fmt.Fprintf(os.Stderr, "[WARN] %s\n", d)
+		}
+	}
+	if err != nil {
+		return err
+	}
+	for _, f := range res.Files {
+		if err = writeFile(f.Name, f.Content); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// splitOptions splits the comma separated options, like the parameters of the plugins.
+func splitOptions(options []string) []string {
+	var ret []string
+	for _, option := range options {
+		for _, o := range strings.Split(option, ",") {
+			if o = strings.TrimSpace(o); o != "" {
+				ret = append(ret, o)
+			}
+		}
+	}
+	return ret
+}
+
+// writeFile writes a generated file, creating its directory.
+func writeFile(name string, content []byte) error {
+	if err := os.MkdirAll(filepath.Dir(name), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(name, content, 0o644)
+}
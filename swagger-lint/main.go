@@ -0,0 +1,81 @@
+/*
+ * Copyright 2024 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	common "github.com/hertz-contrib/swagger-generate/common/utils"
+	"gopkg.in/yaml.v3"
+)
+
+func main() {
+	var disable string
+
+	f := flag.NewFlagSet(os.Args[0], flag.ContinueOnError)
+	f.StringVar(&disable, "disable", "", "Comma separated lint rules to skip: "+strings.Join(common.LintRules, ", "))
+	f.Usage = func() {
+		fmt.Fprintf(f.Output(), "Usage: %s [options] <openapi.yaml>...\n", f.Name())
+		f.PrintDefaults()
+	}
+
+	if err := f.Parse(os.Args[1:]); err != nil {
+		os.Exit(2)
+	}
+	if f.NArg() == 0 {
+		f.Usage()
+		os.Exit(2)
+	}
+
+	os.Exit(run(f.Args(), disable))
+}
+
+// run prints the issues of the linter on the documents, and returns 1 if there are issues.
+func run(files []string, disable string) int {
+	var disabled []string
+	for _, rule := range strings.Split(disable, ",") {
+		if rule = strings.TrimSpace(rule); rule != "" {
+			disabled = append(disabled, rule)
+		}
+	}
+	if err := common.CheckLintRules(disabled); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 2
+	}
+
+	code := 0
+	for _, file := range files {
+		b, err := os.ReadFile(file)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return 2
+		}
+		var document yaml.Node
+		if err = yaml.Unmarshal(b, &document); err != nil {
+			fmt.Fprintf(os.Stderr, "%s: %s\n", file, err)
+			return 2
+		}
+		for _, issue := range common.LintDocument(&document, disabled) {
+			fmt.Printf("%s: %s: %s\n", file, issue.Pointer, issue)
+			code = 1
+		}
+	}
+	return code
+}
@@ -0,0 +1,157 @@
+/*
+ * Copyright 2024 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package swaggerruntime
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/base64"
+	"net"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/cloudwego/hertz/pkg/app"
+	"github.com/cloudwego/hertz/pkg/common/hlog"
+	"github.com/hertz-contrib/cors"
+)
+
+// AccessOptions control the access to the swagger endpoints, and to the proxy of the RPC servers.
+type AccessOptions struct {
+	// Enabled serves the endpoints, true by default.
+	Enabled bool
+	// Username and Password require the basic authentication of the requests when Username is set.
+	Username string
+	Password string
+	// Token requires the requests to carry it as a bearer token when it is set. Either credential is accepted
+	// when both are required.
+	Token string
+	// AllowedIPs are the IPs and the CIDRs of the clients allowed, all of them if empty. The address of
+	// the connection is checked, not the forwarding headers.
+	AllowedIPs []string
+	// CORS is the CORS configuration of the endpoints, allowing all the origins by default.
+	CORS cors.Config
+}
+
+// LoadAccessOptions returns the access options from the SWAGGER_ENABLED, SWAGGER_USERNAME, SWAGGER_PASSWORD,
+// SWAGGER_TOKEN, SWAGGER_ALLOWED_IPS and SWAGGER_CORS_ORIGINS environment variables, the lists separated by commas.
+func LoadAccessOptions() AccessOptions {
+	opts := AccessOptions{
+		Enabled:    true,
+		Username:   os.Getenv("SWAGGER_USERNAME"),
+		Password:   os.Getenv("SWAGGER_PASSWORD"),
+		Token:      os.Getenv("SWAGGER_TOKEN"),
+		AllowedIPs: splitList(os.Getenv("SWAGGER_ALLOWED_IPS")),
+		CORS:       cors.DefaultConfig(),
+	}
+	if enabled := os.Getenv("SWAGGER_ENABLED"); enabled != "" {
+		var err error
+		if opts.Enabled, err = strconv.ParseBool(enabled); err != nil {
+			hlog.Fatal("Invalid SWAGGER_ENABLED:", err)
+		}
+	}
+	if origins := splitList(os.Getenv("SWAGGER_CORS_ORIGINS")); len(origins) > 0 {
+		opts.CORS.AllowOrigins = origins
+	} else {
+		opts.CORS.AllowAllOrigins = true
+	}
+	opts.CORS.AddAllowHeaders("Authorization")
+	return opts
+}
+
+func splitList(s string) []string {
+	var list []string
+	for _, item := range strings.Split(s, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			list = append(list, item)
+		}
+	}
+	return list
+}
+
+// AccessControl returns the middlewares of the swagger endpoints: the CORS of the options, then the checks
+// of the client IPs and of the credentials.
+func AccessControl(opts AccessOptions) []app.HandlerFunc {
+	networks := parseNetworks(opts.AllowedIPs, "allowed IP")
+	username, password, token := opts.Username, opts.Password, opts.Token
+
+	return []app.HandlerFunc{cors.New(opts.CORS), func(c context.Context, ctx *app.RequestContext) {
+		if len(networks) > 0 && !allowedIP(ctx.RemoteAddr(), networks) {
+			ctx.AbortWithStatusJSON(http.StatusForbidden, map[string]interface{}{"error": "IP not allowed"})
+			return
+		}
+		if username == "" && token == "" {
+			return
+		}
+		authorization := string(ctx.Request.Header.Peek("Authorization"))
+		if token != "" && strings.HasPrefix(authorization, "Bearer ") && equal(strings.TrimPrefix(authorization, "Bearer "), token) {
+			return
+		}
+		if username != "" && strings.HasPrefix(authorization, "Basic ") {
+			credentials, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(authorization, "Basic "))
+			if user, pass, ok := strings.Cut(string(credentials), ":"); err == nil && ok && equal(user, username) && equal(pass, password) {
+				return
+			}
+		}
+		if username != "" {
+			ctx.Header("WWW-Authenticate", "Basic realm=\"swagger\"")
+		} else {
+			ctx.Header("WWW-Authenticate", "Bearer")
+		}
+		ctx.AbortWithStatusJSON(http.StatusUnauthorized, map[string]interface{}{"error": "Unauthorized"})
+	}}
+}
+
+// parseNetworks parses a list of IPs and CIDRs, the IPs being the networks of their single address.
+func parseNetworks(ips []string, name string) []*net.IPNet {
+	var networks []*net.IPNet
+	for _, ip := range ips {
+		if !strings.Contains(ip, "/") {
+			if strings.Contains(ip, ":") {
+				ip += "/128"
+			} else {
+				ip += "/32"
+			}
+		}
+		_, network, err := net.ParseCIDR(ip)
+		if err != nil {
+			hlog.Fatal("Invalid "+name+":", err)
+		}
+		networks = append(networks, network)
+	}
+	return networks
+}
+
+func allowedIP(addr net.Addr, networks []*net.IPNet) bool {
+	host, _, err := net.SplitHostPort(addr.String())
+	if err != nil {
+		host = addr.String()
+	}
+	ip := net.ParseIP(host)
+	for _, network := range networks {
+		if ip != nil && network.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// equal compares credentials in constant time.
+func equal(a, b string) bool {
+	return subtle.ConstantTimeCompare([]byte(a), []byte(b)) == 1
+}
@@ -0,0 +1,99 @@
+/*
+ * Copyright 2024 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package swaggerruntime
+
+import (
+	"context"
+	"encoding/base64"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/cloudwego/hertz/pkg/app"
+	"github.com/cloudwego/hertz/pkg/common/config"
+	"github.com/cloudwego/hertz/pkg/common/ut"
+	"github.com/cloudwego/hertz/pkg/route"
+	"github.com/hertz-contrib/cors"
+)
+
+func TestLoadAccessOptions(t *testing.T) {
+	t.Setenv("SWAGGER_ENABLED", "false")
+	t.Setenv("SWAGGER_ALLOWED_IPS", " 10.0.0.1, ,192.168.0.0/16 ")
+	t.Setenv("SWAGGER_CORS_ORIGINS", "https://example.com")
+
+	opts := LoadAccessOptions()
+	if opts.Enabled {
+		t.Errorf("LoadAccessOptions() Enabled = true, want false")
+	}
+	if want := []string{"10.0.0.1", "192.168.0.0/16"}; !reflect.DeepEqual(opts.AllowedIPs, want) {
+		t.Errorf("LoadAccessOptions() AllowedIPs = %v, want %v", opts.AllowedIPs, want)
+	}
+	if opts.CORS.AllowAllOrigins || !reflect.DeepEqual(opts.CORS.AllowOrigins, []string{"https://example.com"}) {
+		t.Errorf("LoadAccessOptions() CORS origins = %v, all %v", opts.CORS.AllowOrigins, opts.CORS.AllowAllOrigins)
+	}
+}
+
+func TestAccessControl(t *testing.T) {
+	basic := "Basic " + base64.StdEncoding.EncodeToString([]byte("admin:secret"))
+	tests := []struct {
+		name          string
+		opts          AccessOptions
+		authorization string
+		status        int
+		authenticate  string
+	}{
+		{name: "open", status: http.StatusOK},
+		{name: "basic", opts: AccessOptions{Username: "admin", Password: "secret"}, authorization: basic, status: http.StatusOK},
+		{name: "basic missing", opts: AccessOptions{Username: "admin", Password: "secret"}, status: http.StatusUnauthorized, authenticate: `Basic realm="swagger"`},
+		{
+			name:          "basic wrong password",
+			opts:          AccessOptions{Username: "admin", Password: "other"},
+			authorization: basic,
+			status:        http.StatusUnauthorized,
+			authenticate:  `Basic realm="swagger"`,
+		},
+		{name: "token", opts: AccessOptions{Token: "t0k"}, authorization: "Bearer t0k", status: http.StatusOK},
+		{name: "token wrong", opts: AccessOptions{Token: "t0k"}, authorization: "Bearer t0", status: http.StatusUnauthorized, authenticate: "Bearer"},
+		{name: "either credential", opts: AccessOptions{Username: "admin", Password: "secret", Token: "t0k"}, authorization: "Bearer t0k", status: http.StatusOK},
+		// The requests of the tests come from 0.0.0.0
+		{name: "IP allowed", opts: AccessOptions{AllowedIPs: []string{"10.0.0.0/8", "0.0.0.0"}}, status: http.StatusOK},
+		{name: "IP not allowed", opts: AccessOptions{AllowedIPs: []string{"10.0.0.0/8"}, Token: "t0k"}, authorization: "Bearer t0k", status: http.StatusForbidden},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.opts.CORS = cors.DefaultConfig()
+			tt.opts.CORS.AllowAllOrigins = true
+			engine := route.NewEngine(config.NewOptions(nil))
+			engine.Use(AccessControl(tt.opts)...)
+			engine.GET("/openapi.yaml", func(c context.Context, ctx *app.RequestContext) {
+				ctx.SetStatusCode(http.StatusOK)
+			})
+
+			var headers []ut.Header
+			if tt.authorization != "" {
+				headers = append(headers, ut.Header{Key: "Authorization", Value: tt.authorization})
+			}
+			w := ut.PerformRequest(engine, http.MethodGet, "/openapi.yaml", nil, headers...)
+			if w.Code != tt.status {
+				t.Errorf("GET /openapi.yaml = %d, want %d", w.Code, tt.status)
+			}
+			if got := string(w.Header().Peek("WWW-Authenticate")); got != tt.authenticate {
+				t.Errorf("GET /openapi.yaml WWW-Authenticate = %q, want %q", got, tt.authenticate)
+			}
+		})
+	}
+}
@@ -0,0 +1,53 @@
+/*
+ * Copyright 2024 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package swaggerruntime
+
+import (
+	"strings"
+
+	"github.com/cloudwego/hertz/pkg/network"
+)
+
+// ProtocolDetector reports whether a connection of the Kitex server carries HTTP requests served by the swagger
+// server, peeking at its first bytes without reading them.
+type ProtocolDetector func(conn network.Conn) bool
+
+// httpMethods are the methods of the HTTP/1.x requests detected by DetectHTTP1.
+var httpMethods = []string{"GET", "HEAD", "POST", "PUT", "DELETE", "CONNECT", "OPTIONS", "TRACE", "PATCH"}
+
+// DetectHTTP1 detects the HTTP/1.x requests by their method followed by a space, peeking at one more byte
+// while they may still match. The other protocols, like the HTTP/2 connection preface or TLS, are served by Kitex.
+func DetectHTTP1(conn network.Conn) bool {
+	for n := 1; ; n++ {
+		b, err := conn.Peek(n)
+		if err != nil {
+			return false
+		}
+		prefix := false
+		for _, method := range httpMethods {
+			if request := method + " "; strings.HasPrefix(request, string(b)) {
+				if len(b) == len(request) {
+					return true
+				}
+				prefix = true
+			}
+		}
+		if !prefix {
+			return false
+		}
+	}
+}
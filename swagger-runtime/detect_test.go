@@ -0,0 +1,62 @@
+/*
+ * Copyright 2024 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package swaggerruntime
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/cloudwego/hertz/pkg/network"
+)
+
+// peekConn is a connection whose Peek returns the bytes received so far, and an error past them.
+type peekConn struct {
+	network.Conn
+	received string
+}
+
+func (c *peekConn) Peek(n int) ([]byte, error) {
+	if n > len(c.received) {
+		return nil, errors.New("timeout")
+	}
+	return []byte(c.received[:n]), nil
+}
+
+func TestDetectHTTP1(t *testing.T) {
+	tests := []struct {
+		name     string
+		received string
+		want     bool
+	}{
+		{"HTTP/1.1", "GET /swagger/index.html HTTP/1.1\r\n", true},
+		{"longest method", "CONNECT example.com:443 HTTP/1.1\r\n", true},
+		// The HTTP/2 connection preface starts like a method but is not one.
+		{"HTTP/2 prior knowledge", "PRI * HTTP/2.0\r\n\r\nSM\r\n\r\n", false},
+		{"TLS", "\x16\x03\x01\x02\x00\x01\x00\x01\xfc\x03\x03", false},
+		{"TTHeader", "\x00\x00\x00\x2a\x10\x00", false},
+		// A partial method waits for the next bytes, and fails when they do not come.
+		{"partial method", "CONNEC", false},
+		{"not a method", "GETS / HTTP/1.1\r\n", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := DetectHTTP1(&peekConn{received: tt.received}); got != tt.want {
+				t.Errorf("DetectHTTP1(%q) = %v, want %v", tt.received, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,262 @@
+/*
+ * Copyright 2024 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package swaggerruntime
+
+import (
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/cloudwego/hertz/pkg/app"
+	"github.com/cloudwego/hertz/pkg/common/hlog"
+	"gopkg.in/yaml.v3"
+)
+
+// maxRewrittenDocuments bounds the documents rewritten for different servers cached per document.
+const maxRewrittenDocuments = 64
+
+// swaggerDocument is a document served by the swagger endpoints, at {path}/openapi.yaml and {path}/openapi.json,
+// with the documents rewritten for the servers of their requests by their server URLs.
+type swaggerDocument struct {
+	name      string
+	path      string
+	root      *yaml.Node
+	value     interface{}
+	contents  *documentContents
+	mu        sync.Mutex
+	rewritten map[string]*documentContents
+	// urls are the server URLs of the rewritten documents, from the oldest, evicted first.
+	urls []string
+}
+
+// documentContents are the contents of a document in YAML and in JSON.
+type documentContents struct {
+	yaml *documentContent
+	json *documentContent
+}
+
+// documentContent is the content of a document, with its ETag and its gzipped content.
+type documentContent struct {
+	contentType string
+	data        []byte
+	gzipped     []byte
+	etag        string
+}
+
+// contentsFor returns the contents of the document served for a request, whose servers are rewritten
+// by the server URL of the request when the options rewrite them. The forwarding headers are only trusted
+// from the trusted proxies, if any.
+func (d *swaggerDocument) contentsFor(ctx *app.RequestContext, opts SwaggerOptions, proxies []*net.IPNet) *documentContents {
+	if !opts.RewriteServers {
+		return d.contents
+	}
+	forwarded := len(proxies) == 0 || allowedIP(ctx.RemoteAddr(), proxies)
+	url := serverURL(ctx, opts.BasePath, forwarded)
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if contents, ok := d.rewritten[url]; ok {
+		return contents
+	}
+	contents, err := d.rewrite(url)
+	if err != nil {
+		hlog.Warnf("Failed to rewrite the servers of the document %s: %s", d.name, err)
+		return d.contents
+	}
+	if d.rewritten == nil {
+		d.rewritten = make(map[string]*documentContents)
+	}
+	if len(d.urls) >= maxRewrittenDocuments {
+		delete(d.rewritten, d.urls[0])
+		d.urls = d.urls[1:]
+	}
+	d.rewritten[url] = contents
+	d.urls = append(d.urls, url)
+	return contents
+}
+
+// rewrite returns the contents of the document with the server URL as its only server.
+func (d *swaggerDocument) rewrite(url string) (*documentContents, error) {
+	// The nodes are copied down to the servers, the document is shared by the requests
+	rewritten := *d.root
+	root := &rewritten
+	if root.Kind == yaml.DocumentNode && len(root.Content) == 1 {
+		mapping := *root.Content[0]
+		root.Content = []*yaml.Node{&mapping}
+		root = &mapping
+	}
+	value, ok := d.value.(map[string]interface{})
+	if root.Kind != yaml.MappingNode || !ok {
+		return nil, errors.New("the document is not a mapping")
+	}
+
+	servers := []interface{}{map[string]interface{}{"url": url}}
+	serversNode := &yaml.Node{}
+	if err := serversNode.Encode(servers); err != nil {
+		return nil, err
+	}
+	root.Content = append([]*yaml.Node{}, root.Content...)
+	replaced := false
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		if root.Content[i].Value == "servers" {
+			root.Content[i+1], replaced = serversNode, true
+		}
+	}
+	if !replaced {
+		root.Content = append(root.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: "servers"}, serversNode)
+	}
+	yamlDocument, err := yaml.Marshal(&rewritten)
+	if err != nil {
+		return nil, err
+	}
+
+	object := make(map[string]interface{}, len(value)+1)
+	for k, v := range value {
+		object[k] = v
+	}
+	object["servers"] = servers
+	jsonDocument, err := json.Marshal(object)
+	if err != nil {
+		return nil, err
+	}
+	return &documentContents{
+		yaml: newDocumentContent("application/x-yaml", yamlDocument),
+		json: newDocumentContent("application/json", jsonDocument),
+	}, nil
+}
+
+// serverURL returns the URL a request is sent to, from its Host header, or the X-Forwarded-Proto, X-Forwarded-Host
+// and X-Forwarded-Prefix headers of the proxies it is forwarded by if they are trusted, followed by a base path.
+func serverURL(ctx *app.RequestContext, basePath string, forwarded bool) string {
+	var scheme, host, prefix string
+	if forwarded {
+		scheme = forwardedHeader(ctx, "X-Forwarded-Proto")
+		host = forwardedHeader(ctx, "X-Forwarded-Host")
+		prefix = strings.TrimSuffix(forwardedHeader(ctx, "X-Forwarded-Prefix"), "/")
+	}
+	if scheme == "" {
+		scheme = string(ctx.URI().Scheme())
+	}
+	if scheme == "" {
+		scheme = "http"
+	}
+	if host == "" {
+		host = string(ctx.Host())
+	}
+	if basePath = strings.TrimSuffix(basePath, "/"); basePath != "" && !strings.HasPrefix(basePath, "/") {
+		basePath = "/" + basePath
+	}
+	return scheme + "://" + host + prefix + basePath
+}
+
+// forwardedHeader returns the first value of a forwarding header, set by the proxy closest to the client.
+func forwardedHeader(ctx *app.RequestContext, key string) string {
+	value, _, _ := strings.Cut(string(ctx.Request.Header.Peek(key)), ",")
+	return strings.TrimSpace(value)
+}
+
+func newDocumentContent(contentType string, data []byte) *documentContent {
+	var gzipped bytes.Buffer
+	w := gzip.NewWriter(&gzipped)
+	w.Write(data)
+	w.Close()
+	sum := sha256.Sum256(data)
+	return &documentContent{
+		contentType: contentType,
+		data:        data,
+		gzipped:     gzipped.Bytes(),
+		etag:        "\"" + hex.EncodeToString(sum[:16]) + "\"",
+	}
+}
+
+// write writes the content gzipped when the request accepts it, or 304 when the request has its ETag,
+// compared weakly. The content varies with the forwarding headers when the servers are rewritten.
+func (d *documentContent) write(ctx *app.RequestContext, rewritten bool) {
+	vary := "Accept-Encoding"
+	if rewritten {
+		vary += ", Host, X-Forwarded-Proto, X-Forwarded-Host, X-Forwarded-Prefix"
+	}
+	ctx.Header("Vary", vary)
+	ctx.Header("ETag", d.etag)
+	for _, etag := range strings.Split(string(ctx.Request.Header.Peek("If-None-Match")), ",") {
+		if etag = strings.TrimPrefix(strings.TrimSpace(etag), "W/"); etag == d.etag || etag == "*" {
+			ctx.SetStatusCode(http.StatusNotModified)
+			return
+		}
+	}
+	if acceptsGzip(string(ctx.Request.Header.Peek("Accept-Encoding"))) {
+		ctx.Header("Content-Encoding", "gzip")
+		ctx.Data(http.StatusOK, d.contentType, d.gzipped)
+		return
+	}
+	ctx.Data(http.StatusOK, d.contentType, d.data)
+}
+
+// acceptsGzip reports whether an Accept-Encoding header accepts gzip, by name or by *, with a non-zero q-value.
+func acceptsGzip(header string) bool {
+	wildcard := false
+	for _, coding := range strings.Split(header, ",") {
+		name, params, _ := strings.Cut(coding, ";")
+		q := 1.0
+		for _, param := range strings.Split(params, ";") {
+			if key, value, ok := strings.Cut(param, "="); ok && strings.EqualFold(strings.TrimSpace(key), "q") {
+				var err error
+				if q, err = strconv.ParseFloat(strings.TrimSpace(value), 64); err != nil {
+					q = 0
+				}
+			}
+		}
+		switch name = strings.ToLower(strings.TrimSpace(name)); name {
+		case "gzip", "x-gzip":
+			// An explicit gzip takes precedence over *.
+			return q > 0
+		case "*":
+			wildcard = q > 0
+		}
+	}
+	return wildcard
+}
+
+// jsonValue returns a value decoded from YAML with the keys of its mappings as strings, to be encoded into JSON.
+func jsonValue(value interface{}) interface{} {
+	switch value := value.(type) {
+	case map[string]interface{}:
+		for k, v := range value {
+			value[k] = jsonValue(v)
+		}
+	case map[interface{}]interface{}:
+		m := make(map[string]interface{}, len(value))
+		for k, v := range value {
+			m[fmt.Sprint(k)] = jsonValue(v)
+		}
+		return m
+	case []interface{}:
+		for i, v := range value {
+			value[i] = jsonValue(v)
+		}
+	}
+	return value
+}
@@ -0,0 +1,211 @@
+/*
+ * Copyright 2024 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package swaggerruntime
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/cloudwego/hertz/pkg/app"
+	"gopkg.in/yaml.v3"
+)
+
+func TestAcceptsGzip(t *testing.T) {
+	tests := []struct {
+		header string
+		want   bool
+	}{
+		{"", false},
+		{"gzip", true},
+		{"deflate, GZIP", true},
+		{"x-gzip", true},
+		{"gzip;q=0", false},
+		{"gzip; q=0.5", true},
+		{"*", true},
+		{"*;q=0", false},
+		// An explicit gzip takes precedence over *.
+		{"gzip;q=0, *", false},
+		{"*;q=0, gzip", true},
+		{"gzip;q=invalid", false},
+		{"br, deflate", false},
+	}
+	for _, tt := range tests {
+		if got := acceptsGzip(tt.header); got != tt.want {
+			t.Errorf("acceptsGzip(%q) = %v, want %v", tt.header, got, tt.want)
+		}
+	}
+}
+
+func TestServerURL(t *testing.T) {
+	tests := []struct {
+		name      string
+		headers   map[string]string
+		basePath  string
+		forwarded bool
+		want      string
+	}{
+		{"host", nil, "", true, "http://example.com"},
+		{"base path", nil, "api/", true, "http://example.com/api"},
+		{
+			name:      "forwarded",
+			headers:   map[string]string{"X-Forwarded-Proto": "https", "X-Forwarded-Host": "api.example.com, proxy", "X-Forwarded-Prefix": "/v1/"},
+			basePath:  "/api",
+			forwarded: true,
+			want:      "https://api.example.com/v1/api",
+		},
+		{
+			name:    "untrusted proxy",
+			headers: map[string]string{"X-Forwarded-Proto": "https", "X-Forwarded-Host": "api.example.com"},
+			want:    "http://example.com",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := app.NewContext(0)
+			ctx.Request.SetRequestURI("/openapi.yaml")
+			ctx.Request.SetHost("example.com")
+			for k, v := range tt.headers {
+				ctx.Request.Header.Set(k, v)
+			}
+			if got := serverURL(ctx, tt.basePath, tt.forwarded); got != tt.want {
+				t.Errorf("serverURL() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+const testDocument = `openapi: 3.0.3
+info:
+  title: Pets
+  version: 1.0.0
+servers:
+  - url: http://localhost:8888
+paths: {}
+`
+
+func TestRewrite(t *testing.T) {
+	document, err := newSwaggerDocument("pets", "", []byte(testDocument))
+	if err != nil {
+		t.Fatal(err)
+	}
+	contents, err := document.rewrite("https://example.com/api")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var rewritten map[string]interface{}
+	if err = yaml.Unmarshal(contents.yaml.data, &rewritten); err != nil {
+		t.Fatalf("the rewritten YAML document is invalid: %s", err)
+	}
+	var rewrittenJSON map[string]interface{}
+	if err = json.Unmarshal(contents.json.data, &rewrittenJSON); err != nil {
+		t.Fatalf("the rewritten JSON document is invalid: %s", err)
+	}
+	for _, value := range []map[string]interface{}{rewritten, rewrittenJSON} {
+		if got := fmt.Sprint(value["servers"]); got != "[map[url:https://example.com/api]]" {
+			t.Errorf("rewrite() servers = %s", got)
+		}
+		if got := fmt.Sprint(value["info"]); got != "map[title:Pets version:1.0.0]" {
+			t.Errorf("rewrite() info = %s", got)
+		}
+	}
+	// The document is shared by the requests, it is left unchanged
+	if !bytes.Equal(document.contents.yaml.data, []byte(testDocument)) || strings.Contains(string(document.contents.json.data), "example.com") {
+		t.Errorf("rewrite() changed the document")
+	}
+
+	withoutServers, err := newSwaggerDocument("pets", "", []byte("openapi: 3.0.3\npaths: {}\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if contents, err = withoutServers.rewrite("http://example.com"); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(contents.yaml.data), "servers:\n    - url: http://example.com\n") {
+		t.Errorf("rewrite() without servers = %s", contents.yaml.data)
+	}
+
+	list, err := newSwaggerDocument("list", "", []byte("- a\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = list.rewrite("http://example.com"); err == nil {
+		t.Errorf("rewrite() of a list succeeded")
+	}
+}
+
+func TestDocumentContentWrite(t *testing.T) {
+	content := newDocumentContent("application/x-yaml", []byte(testDocument))
+	tests := []struct {
+		name      string
+		headers   map[string]string
+		rewritten bool
+		status    int
+		gzipped   bool
+	}{
+		{name: "plain", status: http.StatusOK},
+		{name: "gzipped", headers: map[string]string{"Accept-Encoding": "gzip"}, status: http.StatusOK, gzipped: true},
+		{name: "not modified", headers: map[string]string{"If-None-Match": `"other", W/` + content.etag}, status: http.StatusNotModified},
+		{name: "modified", headers: map[string]string{"If-None-Match": `"other"`}, status: http.StatusOK},
+		{name: "rewritten", rewritten: true, status: http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := app.NewContext(0)
+			for k, v := range tt.headers {
+				ctx.Request.Header.Set(k, v)
+			}
+			content.write(ctx, tt.rewritten)
+
+			if got := ctx.Response.StatusCode(); got != tt.status {
+				t.Fatalf("write() status = %d, want %d", got, tt.status)
+			}
+			if got := string(ctx.Response.Header.Peek("ETag")); got != content.etag {
+				t.Errorf("write() ETag = %s, want %s", got, content.etag)
+			}
+			vary := string(ctx.Response.Header.Peek("Vary"))
+			if !strings.HasPrefix(vary, "Accept-Encoding") || strings.Contains(vary, "X-Forwarded-Host") != tt.rewritten {
+				t.Errorf("write() Vary = %s", vary)
+			}
+			if tt.status == http.StatusNotModified {
+				return
+			}
+			body := ctx.Response.Body()
+			if tt.gzipped {
+				if got := string(ctx.Response.Header.Peek("Content-Encoding")); got != "gzip" {
+					t.Errorf("write() Content-Encoding = %s, want gzip", got)
+				}
+				r, err := gzip.NewReader(bytes.NewReader(body))
+				if err != nil {
+					t.Fatal(err)
+				}
+				if body, err = io.ReadAll(r); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if string(body) != testDocument {
+				t.Errorf("write() body = %s", body)
+			}
+		})
+	}
+}
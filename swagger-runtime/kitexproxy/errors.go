@@ -0,0 +1,113 @@
+/*
+ * Copyright 2024 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package kitexproxy
+
+import (
+	"encoding/json"
+	"errors"
+	"net"
+	"net/http"
+
+	"github.com/cloudwego/hertz/pkg/app"
+	"github.com/cloudwego/hertz/pkg/common/hlog"
+	"github.com/cloudwego/kitex/pkg/kerrors"
+	"github.com/cloudwego/kitex/pkg/remote"
+	"github.com/cloudwego/kitex/pkg/remote/trans/nphttp2/codes"
+	"github.com/cloudwego/kitex/pkg/remote/trans/nphttp2/status"
+)
+
+// handleCallError writes the error of a call: the declared exceptions with their JSON bodies
+// and the status of the exception responses of the document, the biz status errors with their codes and messages,
+// and the other errors with the status of callErrorStatusCode.
+func handleCallError(ctx *app.RequestContext, err error) {
+	hlog.Errorf("GenericCall error: %v", err)
+	if bizErr, ok := kerrors.FromBizStatusError(err); ok {
+		body := map[string]interface{}{
+			"code":    bizErr.BizStatusCode(),
+			"message": bizErr.BizMessage(),
+		}
+		if extra := bizErr.BizExtra(); len(extra) > 0 {
+			body["extra"] = extra
+		}
+		ctx.JSON(bizStatusCode(bizErr.BizStatusCode()), body)
+		return
+	}
+	if exception := declaredException(err); exception != nil {
+		ctx.Data(http.StatusBadRequest, "application/json", exception)
+		return
+	}
+	ctx.JSON(callErrorStatusCode(err), map[string]interface{}{
+		"error": err.Error(),
+	})
+}
+
+// declaredException returns the JSON body of the declared exception a call failed with, read from an exception
+// field of the result struct of its method. The exceptions read without dynamicgo are formatted as Go values by
+// Kitex, their messages are returned as {"error": ...} bodies.
+func declaredException(err error) []byte {
+	var exception *exceptionError
+	if !errors.As(err, &exception) {
+		return nil
+	}
+	body := []byte(exception.cause.Error())
+	if !json.Valid(body) {
+		body, _ = json.Marshal(map[string]interface{}{"error": exception.cause.Error()})
+	}
+	return body
+}
+
+// bizStatusCode returns the HTTP status of a biz status code: the code if it is an HTTP error status,
+// 500 otherwise.
+func bizStatusCode(code int32) int {
+	if code >= 400 && code < 600 {
+		return int(code)
+	}
+	return http.StatusInternalServerError
+}
+
+// callErrorStatusCode returns the HTTP status of the errors of a call: 504 for the timeouts, 502 for the failures
+// to reach the Kitex server, and 500 for the errors returned by the server and the errors of the proxy.
+func callErrorStatusCode(err error) int {
+	var netErr net.Error
+	var transErr *remote.TransError
+	if s, ok := status.FromError(err); ok {
+		switch s.Code() {
+		case codes.DeadlineExceeded:
+			return http.StatusGatewayTimeout
+		case codes.Unavailable:
+			return http.StatusBadGateway
+		}
+	}
+	switch {
+	case kerrors.IsTimeoutError(err), errors.As(err, &netErr) && netErr.Timeout():
+		return http.StatusGatewayTimeout
+	case errors.As(err, &transErr):
+		return http.StatusInternalServerError
+	case errors.Is(err, kerrors.ErrRemoteOrNetwork), errors.Is(err, kerrors.ErrGetConnection),
+		errors.Is(err, kerrors.ErrServiceDiscovery), errors.Is(err, kerrors.ErrNoMoreInstance),
+		errors.Is(err, kerrors.ErrCircuitBreak):
+		return http.StatusBadGateway
+	}
+	return http.StatusInternalServerError
+}
+
+func handleError(ctx *app.RequestContext, errMsg string, statusCode int) {
+	hlog.Errorf("Error: %s", errMsg)
+	ctx.JSON(statusCode, map[string]interface{}{
+		"error": errMsg,
+	})
+}
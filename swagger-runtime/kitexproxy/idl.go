@@ -0,0 +1,73 @@
+/*
+ * Copyright 2024 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package kitexproxy
+
+import (
+	"os"
+	"path/filepath"
+)
+
+// IDL is an IDL generated into a swagger package: its document, and the services of the proxy
+// with the IDL files from which their generic clients are built.
+type IDL struct {
+	// Name is the name of the IDL in a package generated from several IDLs, the prefix of the routes
+	// of its document.
+	Name     string
+	Document []byte
+	// ServicePrefix routes the methods by {Service}/{Method} instead of {Method}.
+	ServicePrefix bool
+	Services      []*Service
+	// Files are the contents of the IDL and of its includes, by the paths they are embedded with.
+	Files map[string]string
+	// Schemas are the JSON schemas of the components referenced by the request schemas of the methods.
+	Schemas string
+}
+
+// Service is a service of an IDL, whose generic client is built from the file declaring it.
+type Service struct {
+	Name string
+	// Package is the package of a protobuf service.
+	Package string
+	File    string
+	Methods []*Method
+}
+
+// Method is a method of a service, routed when its operation is documented, with the JSON schema of its
+// request bodies, and the Base field of the requests of a thrift method with the thrift types of the scalar
+// fields of the Base.
+type Method struct {
+	Name      string
+	Safe      bool
+	Schema    string
+	BaseField string
+	BaseKeys  map[string]string
+}
+
+// loadIdlFiles returns the IDL files, read from a directory when they are found there.
+func loadIdlFiles(files map[string]string, dir string) map[string]string {
+	if dir == "" {
+		return files
+	}
+	loaded := make(map[string]string, len(files))
+	for path, content := range files {
+		if b, err := os.ReadFile(filepath.Join(dir, path)); err == nil {
+			content = string(b)
+		}
+		loaded[path] = content
+	}
+	return loaded
+}
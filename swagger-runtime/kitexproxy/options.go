@@ -0,0 +1,109 @@
+/*
+ * Copyright 2024 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package kitexproxy
+
+import (
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/cloudwego/hertz/pkg/common/hlog"
+	"github.com/cloudwego/kitex/client"
+	swaggerruntime "github.com/hertz-contrib/swagger-generate/swagger-runtime"
+)
+
+const defaultMaxBodySize = 4 << 20
+
+// Options are the options of the proxy and of its generic clients.
+type Options struct {
+	// Transport is the transport protocol of the calls: ttheader, ttheader_framed or framed, buffered for the
+	// thrift servers, and grpc for the protobuf servers.
+	Transport string
+	// RPCTimeout and ConnectTimeout are the timeouts of the calls and of the connections, those of Kitex if zero.
+	RPCTimeout     time.Duration
+	ConnectTimeout time.Duration
+	// MaxRetries is the maximum number of retries of the failed calls, without retry if zero.
+	MaxRetries int
+	// Codec is the payload codec of the calls of the thrift servers: json calls the methods with the JSON bodies
+	// of the requests, binary forwards the bodies as binary thrift messages.
+	Codec string
+	// ClientOptions are the options of the application, appended to the options of the generic clients.
+	ClientOptions []client.Option
+	// MaxBodySize is the maximum size in bytes of the bodies of the requests, larger bodies are rejected with 413.
+	MaxBodySize int
+	// ReadOnly rejects with 403 the calls of the methods whose operations are not documented as safe by x-safe.
+	ReadOnly bool
+	// Addr is the address the swagger server listens on, e.g. :8889, instead of serving the HTTP requests
+	// on the port of the Kitex server.
+	Addr string
+	// Detector detects the connections of the Kitex server carrying HTTP requests, DetectHTTP1 by default.
+	Detector swaggerruntime.ProtocolDetector
+}
+
+// Defaults are the options of the plugin generating a swagger package, as written in the package.
+type Defaults struct {
+	Transport      string
+	RPCTimeout     string
+	ConnectTimeout string
+	MaxRetries     int
+	// Codec is empty for the protobuf servers, which only call with JSON.
+	Codec string
+}
+
+// LoadOptions returns the options of the proxy: the defaults, overridden by the SWAGGER_TRANSPORT,
+// SWAGGER_RPC_TIMEOUT, SWAGGER_CONNECT_TIMEOUT, SWAGGER_MAX_RETRIES, SWAGGER_CODEC, SWAGGER_MAX_BODY_SIZE,
+// SWAGGER_READ_ONLY, SWAGGER_ADDR environment variables. SWAGGER_CODEC is only read with a default codec.
+func LoadOptions(defaults Defaults) Options {
+	opts := Options{
+		Transport: envOr("SWAGGER_TRANSPORT", defaults.Transport),
+		Addr:      os.Getenv("SWAGGER_ADDR"),
+	}
+	if defaults.Codec != "" {
+		opts.Codec = envOr("SWAGGER_CODEC", defaults.Codec)
+	}
+	var err error
+	if opts.RPCTimeout, err = parseDuration(envOr("SWAGGER_RPC_TIMEOUT", defaults.RPCTimeout)); err != nil {
+		hlog.Fatal("Invalid RPC timeout:", err)
+	}
+	if opts.ConnectTimeout, err = parseDuration(envOr("SWAGGER_CONNECT_TIMEOUT", defaults.ConnectTimeout)); err != nil {
+		hlog.Fatal("Invalid connect timeout:", err)
+	}
+	if opts.MaxRetries, err = strconv.Atoi(envOr("SWAGGER_MAX_RETRIES", strconv.Itoa(defaults.MaxRetries))); err != nil {
+		hlog.Fatal("Invalid max retries:", err)
+	}
+	if opts.MaxBodySize, err = strconv.Atoi(envOr("SWAGGER_MAX_BODY_SIZE", strconv.Itoa(defaultMaxBodySize))); err != nil {
+		hlog.Fatal("Invalid max body size:", err)
+	}
+	if opts.ReadOnly, err = strconv.ParseBool(envOr("SWAGGER_READ_ONLY", "false")); err != nil {
+		hlog.Fatal("Invalid read-only mode:", err)
+	}
+	return opts
+}
+
+func envOr(key, value string) string {
+	if v, ok := os.LookupEnv(key); ok {
+		return v
+	}
+	return value
+}
+
+func parseDuration(s string) (time.Duration, error) {
+	if s == "" {
+		return 0, nil
+	}
+	return time.ParseDuration(s)
+}
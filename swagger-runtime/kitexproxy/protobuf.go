@@ -0,0 +1,135 @@
+/*
+ * Copyright 2024 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package kitexproxy
+
+import (
+	"context"
+
+	"github.com/cloudwego/dynamicgo/conv"
+	"github.com/cloudwego/dynamicgo/meta"
+	"github.com/cloudwego/dynamicgo/proto"
+	"github.com/cloudwego/hertz/pkg/common/hlog"
+	"github.com/cloudwego/kitex/client"
+	"github.com/cloudwego/kitex/client/callopt"
+	"github.com/cloudwego/kitex/client/genericclient"
+	"github.com/cloudwego/kitex/pkg/generic"
+	gproto "github.com/cloudwego/kitex/pkg/generic/proto"
+	"github.com/cloudwego/kitex/pkg/serviceinfo"
+)
+
+// newProtobufClient creates the generic client of a protobuf service, with the service info of the service built
+// beforehand. Its calls carry the name and the package of the service, which Kitex servers hosting several
+// services route them by.
+func (s *Server) newProtobufClient(svc *Service, files map[string]string) genericclient.Client {
+	// The methods of the services of the file are combined, the generator leaves out the methods whose names
+	// are declared by a later service.
+	dOpts := proto.Options{ParseServiceMode: meta.CombineServices}
+	p, err := generic.NewPbContentProviderWithDynamicGo(context.Background(), dOpts, svc.File, files[svc.File], files)
+	if err != nil {
+		hlog.Fatal("Failed to create PbProvider:", err)
+	}
+	if s.Options.Transport == "grpc" {
+		return s.newGRPCClient(svc, p)
+	}
+
+	g, err := generic.JSONPbGeneric(p)
+	if err != nil {
+		hlog.Fatal("Failed to create JsonPbGeneric:", err)
+	}
+	svcInfo := newProtobufServiceInfo(svc, g.PayloadCodecType())
+	svcInfo.Extra["generic"] = true
+	cli, err := genericclient.NewClientWithServiceInfo("swagger", g, svcInfo, s.clientOptions()...)
+	if err != nil {
+		hlog.Fatal("Failed to create generic client:", err)
+	}
+	return cli
+}
+
+// newProtobufServiceInfo returns the service info of a protobuf service, whose methods are looked up by name.
+func newProtobufServiceInfo(svc *Service, codec serviceinfo.PayloadCodec) *serviceinfo.ServiceInfo {
+	methods := make(map[string]serviceinfo.MethodInfo, len(svc.Methods))
+	for _, m := range svc.Methods {
+		methods[m.Name] = serviceinfo.NewMethodInfo(nil, newGenericArgs, newGenericResult, false)
+	}
+	return &serviceinfo.ServiceInfo{
+		ServiceName:  svc.Name,
+		HandlerType:  (*generic.Service)(nil),
+		Methods:      methods,
+		PayloadCodec: codec,
+		Extra:        map[string]interface{}{"PackageName": svc.Package},
+	}
+}
+
+// grpcClient calls the methods of a service over gRPC. The gRPC codec of Kitex only encodes protobuf messages,
+// not the arguments of the generic clients, so the JSON requests and responses are converted by the client.
+type grpcClient struct {
+	cli      client.Client
+	provider generic.PbDescriptorProviderDynamicGo
+	svc      *proto.ServiceDescriptor
+	convOpts conv.Options
+}
+
+func (s *Server) newGRPCClient(svc *Service, p generic.PbDescriptorProviderDynamicGo) genericclient.Client {
+	opts := append([]client.Option{client.WithDestService("swagger")}, s.clientOptions()...)
+	cli, err := client.NewClient(newProtobufServiceInfo(svc, serviceinfo.Protobuf), opts...)
+	if err != nil {
+		hlog.Fatal("Failed to create gRPC client:", err)
+	}
+	return &grpcClient{cli: cli, provider: p, svc: <-p.Provide()}
+}
+
+// GenericCall calls a method with a JSON request, like the generic clients, and returns its JSON response.
+func (c *grpcClient) GenericCall(ctx context.Context, method string, request interface{}, callOptions ...callopt.Option) (interface{}, error) {
+	w, err := gproto.NewWriteJSON(c.svc, method, true, &c.convOpts)
+	if err != nil {
+		return nil, err
+	}
+	data, err := w.Write(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+	resp := &grpcMessage{}
+	if err = c.cli.Call(client.NewCtxWithCallOptions(ctx, callOptions), method, &grpcMessage{data: data.([]byte)}, resp); err != nil {
+		return nil, err
+	}
+	r, err := gproto.NewReadJSON(c.svc, true, &c.convOpts)
+	if err != nil {
+		return nil, err
+	}
+	return r.Read(ctx, method, resp.data)
+}
+
+// Close closes the provider of the descriptor, the Kitex client is closed by its finalizer like in the generic clients.
+func (c *grpcClient) Close() error {
+	return c.provider.Close()
+}
+
+// grpcMessage is a request or a response of a gRPC call, encoded in protobuf.
+type grpcMessage struct {
+	data []byte
+}
+
+// Marshal implements the protobuf messages of the gRPC codec.
+func (m *grpcMessage) Marshal(out []byte) ([]byte, error) {
+	return append(out, m.data...), nil
+}
+
+// Unmarshal implements the protobuf messages of the gRPC codec.
+func (m *grpcMessage) Unmarshal(in []byte) error {
+	m.data = append(m.data[:0], in...)
+	return nil
+}
@@ -0,0 +1,409 @@
+/*
+ * Copyright 2024 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package kitexproxy serves the swagger endpoints of the Kitex servers generated by thrift-gen-rpc-swagger and
+// protoc-gen-rpc-swagger, and proxies the requests of their documents to the Kitex servers with generic clients.
+package kitexproxy
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"sync"
+	"sync/atomic"
+
+	"github.com/bytedance/gopkg/cloud/metainfo"
+	"github.com/cloudwego/hertz/pkg/app"
+	"github.com/cloudwego/hertz/pkg/app/server"
+	"github.com/cloudwego/hertz/pkg/common/config"
+	"github.com/cloudwego/hertz/pkg/common/hlog"
+	"github.com/cloudwego/hertz/pkg/network"
+	"github.com/cloudwego/hertz/pkg/route"
+	"github.com/cloudwego/kitex/client"
+	"github.com/cloudwego/kitex/client/genericclient"
+	"github.com/cloudwego/kitex/pkg/endpoint"
+	"github.com/cloudwego/kitex/pkg/klog"
+	"github.com/cloudwego/kitex/pkg/remote"
+	"github.com/cloudwego/kitex/pkg/remote/trans/detection"
+	"github.com/cloudwego/kitex/pkg/remote/trans/netpoll"
+	"github.com/cloudwego/kitex/pkg/remote/trans/nphttp2"
+	"github.com/cloudwego/kitex/pkg/retry"
+	"github.com/cloudwego/kitex/pkg/transmeta"
+	"github.com/cloudwego/kitex/transport"
+	swaggerruntime "github.com/hertz-contrib/swagger-generate/swagger-runtime"
+)
+
+// Protocol is the protocol of the IDLs of a server.
+type Protocol int
+
+const (
+	Thrift Protocol = iota
+	Protobuf
+)
+
+// Server is the swagger server of a generated package, started once with the Kitex server and stopped with it.
+// Its options point to the variables of the package, read when it starts.
+type Server struct {
+	// Addr is the address of the Kitex server, called by the generic clients.
+	Addr     string
+	Protocol Protocol
+	Options  *Options
+	Access   *swaggerruntime.AccessOptions
+	Swagger  *swaggerruntime.SwaggerOptions
+	// IdlDir reads the IDL files from this directory, by the paths they are embedded with, when it is set.
+	IdlDir *string
+
+	idls []*IDL
+
+	// mu guards the lifecycle of the server.
+	mu      sync.Mutex
+	hertz   *server.Hertz
+	stopped bool
+	clients []genericclient.Client
+	// engine is the *route.Engine serving the HTTP requests of the connections of the Kitex server,
+	// unset when the swagger server listens on its own address or is stopped.
+	engine atomic.Value
+	calls  calls
+}
+
+// Register registers an IDL generated into the package of the server, from its idl.go file.
+func (s *Server) Register(idl *IDL) {
+	s.idls = append(s.idls, idl)
+}
+
+// proxyMethod is a method called by the proxy, with the generic client of its service, the request schema
+// its bodies are validated against with the schemas of its IDL, and the Base of its requests.
+type proxyMethod struct {
+	cli     genericclient.Client
+	method  *Method
+	schema  interface{}
+	schemas map[string]interface{}
+}
+
+type transHandler struct {
+	remote.ServerTransHandler
+	server *Server
+	detect swaggerruntime.ProtocolDetector
+}
+
+func (t *transHandler) SetInvokeHandleFunc(inkHdlFunc endpoint.Endpoint) {
+	t.ServerTransHandler.(remote.InvokeHandleFuncSetter).SetInvokeHandleFunc(inkHdlFunc)
+}
+
+// NewTransHandler returns the handler of the connections of the Kitex server, serving the HTTP requests
+// with the swagger server, which it starts, and the other ones with the handler of the origin factory,
+// the detection of the TTHeader and gRPC handlers of Kitex if nil.
+func (s *Server) NewTransHandler(origin remote.ServerTransHandlerFactory, opt *remote.ServerOption) (remote.ServerTransHandler, error) {
+	if s.Access.Enabled {
+		s.Start()
+	}
+
+	var kitexOrigin remote.ServerTransHandler
+	var err error
+	if origin != nil {
+		kitexOrigin, err = origin.NewTransHandler(opt)
+	} else {
+		kitexOrigin, err = detection.NewSvrTransHandlerFactory(netpoll.NewSvrTransHandlerFactory(), nphttp2.NewSvrTransHandlerFactory()).NewTransHandler(opt)
+	}
+	if err != nil {
+		return nil, err
+	}
+	detect := s.Options.Detector
+	if detect == nil {
+		detect = swaggerruntime.DetectHTTP1
+	}
+	return &transHandler{ServerTransHandler: kitexOrigin, server: s, detect: detect}, nil
+}
+
+func (t *transHandler) OnRead(ctx context.Context, conn net.Conn) error {
+	c, ok := conn.(network.Conn)
+	engine, _ := t.server.engine.Load().(*route.Engine)
+	if ok && engine != nil && t.detect(c) {
+		klog.Info("using Hertz to process request")
+		err := engine.Serve(ctx, c)
+		if err != nil {
+			err = errors.New(fmt.Sprintf("HERTZ: %s", err.Error()))
+		}
+		return err
+	}
+
+	return t.ServerTransHandler.OnRead(ctx, conn)
+}
+
+// GracefulShutdown stops the swagger server with the Kitex server, before shutting the handler of Kitex down.
+func (t *transHandler) GracefulShutdown(ctx context.Context) error {
+	if err := t.server.Stop(ctx); err != nil {
+		klog.Errorf("Failed to stop the swagger server: %s", err)
+	}
+	if g, ok := t.ServerTransHandler.(remote.GracefulShutdown); ok {
+		return g.GracefulShutdown(ctx)
+	}
+	return nil
+}
+
+// Start starts the swagger server once, listening on the Addr of the options if set, and serving the HTTP
+// requests of the connections of the Kitex server otherwise.
+func (s *Server) Start() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.hertz != nil || s.stopped {
+		return
+	}
+
+	opts := []config.Option{server.WithMaxRequestBodySize(s.Options.MaxBodySize)}
+	addr := s.Addr
+	if s.Options.Addr != "" {
+		opts = append(opts, server.WithHostPorts(s.Options.Addr))
+		addr = s.Options.Addr
+	}
+	h := server.Default(opts...)
+	h.Use(swaggerruntime.AccessControl(*s.Access)...)
+
+	routes := s.newClients()
+	documents := make([]swaggerruntime.Document, 0, len(s.idls))
+	for _, d := range s.idls {
+		documents = append(documents, swaggerruntime.Document{Name: d.Name, YAML: d.Document})
+	}
+	swaggerruntime.BindDocuments(h, *s.Swagger, documents...)
+	s.bindProxy(h, routes)
+
+	hlog.Info("The swagger UI is available at: http://" + addr + swaggerruntime.Prefix(*s.Swagger) + "/swagger/index.html")
+	s.hertz = h
+	if s.Options.Addr != "" {
+		go func() {
+			if err := h.Run(); err != nil {
+				hlog.Errorf("The swagger server failed: %s", err)
+			}
+		}()
+		return
+	}
+
+	err := h.Engine.Init()
+	if err != nil {
+		panic(err)
+	}
+	s.engine.Store(h.Engine)
+}
+
+// Stop stops the swagger server and closes the generic clients of the proxy once its calls in flight
+// are done, the new ones being rejected. The swagger server is not started again.
+func (s *Server) Stop(ctx context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.stopped {
+		return nil
+	}
+	s.stopped = true
+	if s.hertz == nil {
+		return nil
+	}
+
+	s.engine.Store((*route.Engine)(nil))
+	var err error
+	if s.Options.Addr != "" {
+		err = s.hertz.Shutdown(ctx)
+	}
+	// The connections served with the Kitex server stay open after the engine is unset, so the calls of the proxy
+	// are drained before the clients are closed, which happens anyway once the context ends.
+	if drainErr := s.calls.drain(ctx); err == nil {
+		err = drainErr
+	}
+	for _, cli := range s.clients {
+		cli.Close()
+	}
+	s.clients = nil
+	return err
+}
+
+// newClients creates a generic client per service of the IDLs, and returns the methods they call
+// by the routes of the documents, {Service}/{Method} or {Method}. The first method of a route is called.
+func (s *Server) newClients() map[string]*proxyMethod {
+	routes := make(map[string]*proxyMethod)
+	for _, d := range s.idls {
+		files := loadIdlFiles(d.Files, *s.IdlDir)
+		schemas := make(map[string]interface{})
+		if d.Schemas != "" {
+			if err := json.Unmarshal([]byte(d.Schemas), &schemas); err != nil {
+				hlog.Fatal("Failed to parse the schemas:", err)
+			}
+		}
+		for _, svc := range d.Services {
+			var cli genericclient.Client
+			if s.Protocol == Protobuf {
+				cli = s.newProtobufClient(svc, files)
+			} else {
+				cli = s.newThriftClient(svc, files)
+			}
+			s.clients = append(s.clients, cli)
+			for _, m := range svc.Methods {
+				path := m.Name
+				if d.ServicePrefix {
+					path = svc.Name + "/" + m.Name
+				}
+				if _, ok := routes[path]; ok {
+					hlog.Warnf("Method %s of %s is not proxied, /%s is routed to another method", m.Name, svc.Name, path)
+					continue
+				}
+				var schema interface{}
+				if m.Schema != "" {
+					if err := json.Unmarshal([]byte(m.Schema), &schema); err != nil {
+						hlog.Fatal("Failed to parse the request schema of", path, ":", err)
+					}
+				}
+				routes[path] = &proxyMethod{cli: cli, method: m, schema: schema, schemas: schemas}
+			}
+		}
+	}
+	return routes
+}
+
+// clientOptions returns the options of the generic clients, from the options of the server.
+func (s *Server) clientOptions() []client.Option {
+	opts := []client.Option{client.WithHostPorts(s.Addr)}
+	switch t := s.Options.Transport; {
+	case t == "ttheader":
+		opts = append(opts, client.WithTransportProtocol(transport.TTHeader), client.WithMetaHandler(transmeta.ClientTTHeaderHandler))
+	case t == "ttheader_framed":
+		opts = append(opts, client.WithTransportProtocol(transport.TTHeaderFramed), client.WithMetaHandler(transmeta.ClientTTHeaderHandler))
+	case t == "framed":
+		opts = append(opts, client.WithTransportProtocol(transport.Framed))
+	case t == "buffered" && s.Protocol == Thrift:
+		opts = append(opts, client.WithTransportProtocol(transport.PurePayload))
+	case t == "grpc" && s.Protocol == Protobuf:
+		opts = append(opts, client.WithTransportProtocol(transport.GRPC), client.WithMetaHandler(transmeta.ClientHTTP2Handler))
+	default:
+		hlog.Fatal("Unknown transport:", t)
+	}
+	if s.Options.RPCTimeout > 0 {
+		opts = append(opts, client.WithRPCTimeout(s.Options.RPCTimeout))
+	}
+	if s.Options.ConnectTimeout > 0 {
+		opts = append(opts, client.WithConnectTimeout(s.Options.ConnectTimeout))
+	}
+	if s.Options.MaxRetries > 0 {
+		policy := retry.NewFailurePolicy()
+		policy.WithMaxRetryTimes(s.Options.MaxRetries)
+		opts = append(opts, client.WithFailureRetry(policy))
+	}
+	return append(opts, s.Options.ClientOptions...)
+}
+
+// bindProxy binds the proxy of the methods by their routes.
+func (s *Server) bindProxy(h *server.Hertz, routes map[string]*proxyMethod) {
+	h.Any("/*ServiceMethod", func(c context.Context, ctx *app.RequestContext) {
+		serviceMethod := ctx.Param("ServiceMethod")
+
+		if !s.calls.begin() {
+			ctx.SetConnectionClose()
+			handleError(ctx, "The swagger server is stopped", http.StatusServiceUnavailable)
+			return
+		}
+		defer s.calls.Done()
+
+		// Only the documented routes are proxied, /{Service}/{Method} or /{Method}, as POST operations
+		r, ok := routes[serviceMethod]
+		if !ok {
+			handleError(ctx, "Method not found: /"+serviceMethod, http.StatusNotFound)
+			return
+		}
+		if !ctx.IsPost() {
+			ctx.Header("Allow", http.MethodPost)
+			handleError(ctx, "HTTP method not allowed: "+string(ctx.Method()), http.StatusMethodNotAllowed)
+			return
+		}
+		if s.Options.ReadOnly && !r.method.Safe {
+			handleError(ctx, "Method not allowed in read-only mode: /"+serviceMethod, http.StatusForbidden)
+			return
+		}
+
+		bodyBytes := ctx.Request.Body()
+
+		c, metadata := swaggerruntime.WithMetainfo(c, ctx)
+		c = metainfo.WithBackwardValues(c)
+
+		if s.Protocol == Thrift && s.Options.Codec == "binary" {
+			rsp, err := r.cli.GenericCall(c, r.method.Name, bodyBytes)
+			swaggerruntime.SetBackwardHeaders(c, ctx)
+			if err != nil {
+				handleCallError(ctx, err)
+				return
+			}
+			ctx.Data(http.StatusOK, "application/x-thrift", rsp.([]byte))
+			return
+		}
+
+		if err := swaggerruntime.ValidateBody(r.schema, bodyBytes, r.schemas); err != nil {
+			handleError(ctx, err.Error(), http.StatusBadRequest)
+			return
+		}
+		jReq, err := swaggerruntime.FillBase(ctx, bodyBytes, r.method.BaseField, r.method.BaseKeys, metadata)
+		if err != nil {
+			handleError(ctx, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		jRsp, err := r.cli.GenericCall(c, r.method.Name, string(jReq))
+		swaggerruntime.SetBackwardHeaders(c, ctx)
+		if err != nil {
+			handleCallError(ctx, err)
+			return
+		}
+
+		ctx.Data(http.StatusOK, "application/json", []byte(jRsp.(string)))
+	})
+}
+
+// calls are the calls of the proxy in flight, which use the generic clients.
+type calls struct {
+	sync.WaitGroup
+	// mu guards stopped, so that no call of the proxy begins once it is drained.
+	mu      sync.RWMutex
+	stopped bool
+}
+
+// begin counts a call of the proxy in flight, and reports whether the proxy still accepts calls.
+// The calls begun are ended by Done.
+func (c *calls) begin() bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if c.stopped {
+		return false
+	}
+	c.Add(1)
+	return true
+}
+
+// drain rejects the new calls of the proxy, and waits for the calls in flight or for the end of the context.
+func (c *calls) drain(ctx context.Context) error {
+	c.mu.Lock()
+	c.stopped = true
+	c.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		c.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,231 @@
+/*
+ * Copyright 2024 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package kitexproxy
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+
+	"github.com/cloudwego/dynamicgo/meta"
+	dthrift "github.com/cloudwego/dynamicgo/thrift"
+	"github.com/cloudwego/hertz/pkg/common/hlog"
+	"github.com/cloudwego/kitex/client/genericclient"
+	"github.com/cloudwego/kitex/pkg/generic"
+	"github.com/cloudwego/kitex/pkg/generic/descriptor"
+	"github.com/cloudwego/kitex/pkg/generic/thrift"
+	"github.com/cloudwego/kitex/pkg/klog"
+	"github.com/cloudwego/kitex/pkg/remote"
+	"github.com/cloudwego/kitex/pkg/serviceinfo"
+	"github.com/cloudwego/thriftgo/parser"
+)
+
+// newThriftClient creates the generic client of a thrift service, with the service info of the service built
+// beforehand. Its calls carry the name of the service, which Kitex servers hosting several services route them by.
+func (s *Server) newThriftClient(svc *Service, files map[string]string) genericclient.Client {
+	p, err := newServiceProvider(svc, files)
+	if err != nil {
+		hlog.Fatal("Failed to create ThriftProvider:", err)
+	}
+	var g generic.Generic
+	switch s.Options.Codec {
+	case "json":
+		if g, err = generic.JSONThriftGeneric(p); err != nil {
+			hlog.Fatal("Failed to create JsonThriftGeneric:", err)
+		}
+		g = &exceptionGeneric{Generic: g, codec: &exceptionCodec{PayloadCodec: g.PayloadCodec(), svc: p.svc}}
+	case "binary":
+		g = generic.BinaryThriftGeneric()
+	default:
+		hlog.Fatal("Unknown codec:", s.Options.Codec)
+	}
+
+	cli, err := genericclient.NewClientWithServiceInfo("swagger", g, newThriftServiceInfo(svc, p.svc, g.PayloadCodecType()), s.clientOptions()...)
+	if err != nil {
+		hlog.Fatal("Failed to create generic client:", err)
+	}
+	return cli
+}
+
+// newThriftServiceInfo returns the service info of a thrift service, whose methods are looked up by name,
+// one way as declared by the descriptor of the service.
+func newThriftServiceInfo(svc *Service, desc *descriptor.ServiceDescriptor, codec serviceinfo.PayloadCodec) *serviceinfo.ServiceInfo {
+	methods := make(map[string]serviceinfo.MethodInfo, len(svc.Methods))
+	for _, m := range svc.Methods {
+		oneway := false
+		if fn, ok := desc.Functions[m.Name]; ok {
+			oneway = fn.Oneway
+		}
+		methods[m.Name] = serviceinfo.NewMethodInfo(nil, newGenericArgs, newGenericResult, oneway)
+	}
+	return &serviceinfo.ServiceInfo{
+		ServiceName:  svc.Name,
+		HandlerType:  (*generic.Service)(nil),
+		Methods:      methods,
+		PayloadCodec: codec,
+		Extra:        map[string]interface{}{"generic": true},
+	}
+}
+
+func newGenericArgs() interface{} {
+	return &generic.Args{}
+}
+
+func newGenericResult() interface{} {
+	return &generic.Result{}
+}
+
+// serviceProvider provides the descriptor of a service of a thrift file, which the providers of Kitex only
+// build for the last service of a file.
+type serviceProvider struct {
+	svc  *descriptor.ServiceDescriptor
+	svcs chan *descriptor.ServiceDescriptor
+	opts generic.ProviderOption
+}
+
+// newServiceProvider returns the provider of the descriptor of a service, with the descriptor of dynamicgo
+// unless the methods of the service cannot be told apart from the methods of the other services of its file.
+func newServiceProvider(s *Service, files map[string]string) (*serviceProvider, error) {
+	tree, err := generic.ParseContent(s.File, files[s.File], files, true)
+	if err != nil {
+		return nil, err
+	}
+	svc, ok := tree.GetService(s.Name)
+	if !ok {
+		return nil, fmt.Errorf("service %s not found in %s", s.Name, s.File)
+	}
+	// The descriptor is built from a copy of the file declaring this service last, after the services it may extend.
+	file := *tree
+	file.Services = make([]*parser.Service, 0, len(tree.Services))
+	for _, other := range tree.Services {
+		if other != svc {
+			file.Services = append(file.Services, other)
+		}
+	}
+	file.Services = append(file.Services, svc)
+	desc, err := thrift.Parse(&file, thrift.LastServiceOnly)
+	if err != nil {
+		return nil, err
+	}
+	p := &serviceProvider{svc: desc, svcs: make(chan *descriptor.ServiceDescriptor, 1)}
+
+	mode := meta.CombineServices
+	switch svc {
+	case tree.Services[len(tree.Services)-1]:
+		mode = meta.LastServiceOnly
+	case tree.Services[0]:
+		mode = meta.FirstServiceOnly
+	}
+	methods := make([]string, 0, len(s.Methods))
+	for _, m := range s.Methods {
+		methods = append(methods, m.Name)
+	}
+	dOpts := dthrift.Options{EnableThriftBase: true, ParseServiceMode: mode}
+	dsvc, err := dOpts.NewDescriptorFromContentWithMethod(context.Background(), s.File, files[s.File], files, true, methods...)
+	if err != nil {
+		klog.Warnf("The requests of %s are converted without dynamicgo: %s", s.Name, err)
+	} else {
+		desc.DynamicGoDsc = dsvc
+		p.opts.DynamicGoEnabled = true
+	}
+	p.svcs <- desc
+	return p, nil
+}
+
+// Provide implements generic.DescriptorProvider.
+func (p *serviceProvider) Provide() <-chan *descriptor.ServiceDescriptor {
+	return p.svcs
+}
+
+// Close implements generic.DescriptorProvider.
+func (p *serviceProvider) Close() error {
+	close(p.svcs)
+	return nil
+}
+
+// Option implements generic.GetProviderOption.
+func (p *serviceProvider) Option() generic.ProviderOption {
+	return p.opts
+}
+
+// exceptionError is the error of a reply whose result struct sets a declared exception of its method.
+type exceptionError struct {
+	cause error
+}
+
+func (e *exceptionError) Error() string {
+	return e.cause.Error()
+}
+
+func (e *exceptionError) Unwrap() error {
+	return e.cause
+}
+
+// exceptionGeneric is a JSON generic whose codec tells the declared exceptions apart from the other errors
+// of the replies, which the generic clients return alike.
+type exceptionGeneric struct {
+	generic.Generic
+	codec *exceptionCodec
+}
+
+func (g *exceptionGeneric) PayloadCodec() remote.PayloadCodec {
+	return g.codec
+}
+
+// exceptionCodec returns the errors of the replies setting an exception field of the result struct of their
+// method as exceptionErrors.
+type exceptionCodec struct {
+	remote.PayloadCodec
+	svc *descriptor.ServiceDescriptor
+}
+
+func (c *exceptionCodec) Unmarshal(ctx context.Context, msg remote.Message, in remote.ByteBuffer) error {
+	method := msg.RPCInfo().Invocation().MethodName()
+	id, ok := resultFieldID(in, method)
+	err := c.PayloadCodec.Unmarshal(ctx, msg, in)
+	if err == nil || !ok {
+		return err
+	}
+	if fn, ok := c.svc.Functions[method]; ok && fn.Response != nil && fn.Response.Struct != nil {
+		if field, ok := fn.Response.Struct.FieldsByID[int32(id)]; ok && field.IsException {
+			return &exceptionError{cause: err}
+		}
+	}
+	return err
+}
+
+// resultFieldID peeks the ID of the field set by the result struct of a reply of a method, encoded with the
+// strict binary protocol, like the replies of Kitex.
+func resultFieldID(in remote.ByteBuffer, method string) (int16, bool) {
+	const version1, reply = 0x80010000, 2
+	head, err := in.Peek(8)
+	if err != nil || binary.BigEndian.Uint32(head)&0xffff0000 != version1 || head[3] != reply ||
+		int(binary.BigEndian.Uint32(head[4:])) != len(method) {
+		return 0, false
+	}
+	// The message begins with its version and type, its name and its sequence ID, followed by the header
+	// of the field: its type, STOP if none is set, and its ID.
+	b, err := in.Peek(8 + len(method) + 4 + 3)
+	if err != nil {
+		return 0, false
+	}
+	field := b[8+len(method)+4:]
+	if field[0] == 0 {
+		return 0, false
+	}
+	return int16(binary.BigEndian.Uint16(field[1:])), true
+}
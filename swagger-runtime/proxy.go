@@ -0,0 +1,142 @@
+/*
+ * Copyright 2024 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package swaggerruntime
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/bytedance/gopkg/cloud/metainfo"
+	"github.com/cloudwego/hertz/pkg/app"
+)
+
+// WithMetainfo returns the context of a call with the metainfo of the X-Meta-* and X-Meta-Persist-* headers
+// of a request, and the metainfo by key. The keys are the CGI variables of the headers, e.g. USER_ID for
+// X-Meta-User-Id.
+func WithMetainfo(c context.Context, ctx *app.RequestContext) (context.Context, map[string]string) {
+	metadata := make(map[string]string)
+	ctx.Request.Header.VisitAll(func(k, v []byte) {
+		header := strings.ToLower(string(k))
+		switch {
+		case strings.HasPrefix(header, "x-meta-backward-"):
+		case strings.HasPrefix(header, "x-meta-persist-"):
+			key := metainfo.HTTPHeaderToCGIVariable(strings.TrimPrefix(header, "x-meta-persist-"))
+			c = metainfo.WithPersistentValue(c, key, string(v))
+			metadata[key] = string(v)
+		case strings.HasPrefix(header, "x-meta-"):
+			key := metainfo.HTTPHeaderToCGIVariable(strings.TrimPrefix(header, "x-meta-"))
+			c = metainfo.WithValue(c, key, string(v))
+			metadata[key] = string(v)
+		}
+	})
+	return c, metadata
+}
+
+// SetBackwardHeaders sets the backward metainfo of a call as the X-Meta-Backward-* headers of the response.
+func SetBackwardHeaders(c context.Context, ctx *app.RequestContext) {
+	for key, value := range metainfo.RecvAllBackwardValues(c) {
+		ctx.Response.Header.Set("X-Meta-Backward-"+metainfo.CGIVariableToHTTPHeader(key), value)
+	}
+}
+
+// FillBase fills the missing scalar fields of the Kitex Base of a request, the field baseField of its JSON body,
+// with the headers or the metainfo of the same names, like X-Meta-Logid for LogID, converted to their thrift types
+// by baseKeys, the caller "swagger" and the address of the client. It returns an error if a header or a metainfo
+// is not a value of its field. The bodies which are not JSON objects are returned unchanged.
+func FillBase(ctx *app.RequestContext, body []byte, baseField string, baseKeys, metadata map[string]string) ([]byte, error) {
+	if baseField == "" {
+		return body, nil
+	}
+	req := make(map[string]json.RawMessage)
+	if len(body) > 0 {
+		if err := json.Unmarshal(body, &req); err != nil {
+			return body, nil
+		}
+	}
+	base := make(map[string]json.RawMessage)
+	if raw, ok := req[baseField]; ok && string(raw) != "null" {
+		if err := json.Unmarshal(raw, &base); err != nil {
+			return body, nil
+		}
+	}
+
+	for key, typ := range baseKeys {
+		if _, ok := base[key]; ok {
+			continue
+		}
+		value := string(ctx.GetHeader(key))
+		if value == "" {
+			value = metadata[metainfo.HTTPHeaderToCGIVariable(key)]
+		}
+		switch {
+		case value != "":
+		case key == "Caller" && typ == "string":
+			value = "swagger"
+		case key == "Addr" && typ == "string":
+			value = ctx.ClientIP()
+		default:
+			continue
+		}
+		raw, err := baseValue(typ, value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s of the %s: %s", key, baseField, err)
+		}
+		base[key] = raw
+	}
+
+	req[baseField], _ = json.Marshal(base)
+	filled, err := json.Marshal(req)
+	if err != nil {
+		return body, nil
+	}
+	return filled, nil
+}
+
+// baseValue returns the JSON value of a field of the Base of a thrift type from a header or a metainfo.
+func baseValue(typ, value string) (json.RawMessage, error) {
+	var v interface{}
+	var err error
+	switch typ {
+	case "string":
+		v = value
+	case "bool":
+		v, err = strconv.ParseBool(value)
+	case "double":
+		v, err = strconv.ParseFloat(value, 64)
+	case "byte", "i8":
+		v, err = strconv.ParseInt(value, 10, 8)
+	case "i16":
+		v, err = strconv.ParseInt(value, 10, 16)
+	case "i32":
+		v, err = strconv.ParseInt(value, 10, 32)
+	case "i64":
+		v, err = strconv.ParseInt(value, 10, 64)
+	default:
+		return nil, fmt.Errorf("unsupported type %s", typ)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("expected a %s", typ)
+	}
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return nil, fmt.Errorf("expected a finite %s", typ)
+	}
+	return raw, nil
+}
@@ -0,0 +1,172 @@
+/*
+ * Copyright 2024 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package swaggerruntime
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/bytedance/gopkg/cloud/metainfo"
+	"github.com/cloudwego/hertz/pkg/app"
+)
+
+func TestBaseValue(t *testing.T) {
+	tests := []struct {
+		typ   string
+		value string
+		want  string
+		// err is the error, empty when the value is one of the type.
+		err string
+	}{
+		{"string", "abc", `"abc"`, ""},
+		{"string", `a"b`, `"a\"b"`, ""},
+		{"bool", "true", "true", ""},
+		{"bool", "yes", "", "expected a bool"},
+		{"double", "1.5", "1.5", ""},
+		{"double", "NaN", "", "expected a finite double"},
+		{"byte", "127", "127", ""},
+		{"i8", "128", "", "expected a i8"},
+		{"i16", "-32768", "-32768", ""},
+		{"i32", "2147483648", "", "expected a i32"},
+		{"i64", "9007199254740993", "9007199254740993", ""},
+		{"i64", "1.0", "", "expected a i64"},
+		{"binary", "abc", "", "unsupported type binary"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.typ+" "+tt.value, func(t *testing.T) {
+			got, err := baseValue(tt.typ, tt.value)
+			if (err == nil) != (tt.err == "") || (err != nil && err.Error() != tt.err) {
+				t.Fatalf("baseValue() error = %v, want %q", err, tt.err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("baseValue() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+// baseKeys are the scalar fields of the Base of Kitex, with their thrift types.
+var baseKeys = map[string]string{"LogID": "string", "Caller": "string", "Addr": "string", "Client": "string", "Env": "i32"}
+
+func TestFillBase(t *testing.T) {
+	ctx := app.NewContext(0)
+	ctx.Request.Header.Set("LogID", "log-1")
+	ctx.Request.Header.Set("Env", "2")
+	addr, _ := json.Marshal(ctx.ClientIP())
+
+	tests := []struct {
+		name     string
+		body     string
+		field    string
+		metadata map[string]string
+		want     string
+		// err is the error, empty when the Base is filled.
+		err string
+	}{
+		{
+			name:  "filled from the headers",
+			body:  `{"name": "Tom"}`,
+			field: "Base",
+			want:  `{"Base": {"LogID": "log-1", "Caller": "swagger", "Addr": ` + string(addr) + `, "Env": 2}, "name": "Tom"}`,
+		},
+		{
+			name:     "filled from the metainfo",
+			body:     `{}`,
+			field:    "Base",
+			metadata: map[string]string{"CLIENT": "web"},
+			want:     `{"Base": {"LogID": "log-1", "Caller": "swagger", "Addr": ` + string(addr) + `, "Client": "web", "Env": 2}}`,
+		},
+		{
+			name:  "fields of the request kept",
+			body:  `{"Base": {"LogID": "log-0", "Caller": "test", "Addr": "::1", "Env": 1}}`,
+			field: "Base",
+			want:  `{"Base": {"LogID": "log-0", "Caller": "test", "Addr": "::1", "Env": 1}}`,
+		},
+		{
+			name:  "null Base",
+			body:  `{"base": null}`,
+			field: "base",
+			want:  `{"base": {"LogID": "log-1", "Caller": "swagger", "Addr": ` + string(addr) + `, "Env": 2}}`,
+		},
+		{
+			name:  "empty body",
+			field: "Base",
+			want:  `{"Base": {"LogID": "log-1", "Caller": "swagger", "Addr": ` + string(addr) + `, "Env": 2}}`,
+		},
+		{name: "without Base", body: `{"name": "Tom"}`, want: `{"name": "Tom"}`},
+		{name: "not an object", body: `["Tom"]`, field: "Base", want: `["Tom"]`},
+		{name: "Base not an object", body: `{"Base": 1}`, field: "Base", want: `{"Base": 1}`},
+		{
+			name:     "fields of the request completed",
+			body:     `{"Base": {"Env": 1}}`,
+			field:    "Base",
+			metadata: map[string]string{"CLIENT": "web"},
+			want:     `{"Base": {"Env": 1, "LogID": "log-1", "Caller": "swagger", "Addr": ` + string(addr) + `, "Client": "web"}}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := FillBase(ctx, []byte(tt.body), tt.field, baseKeys, tt.metadata)
+			if err != nil {
+				t.Fatalf("FillBase() error = %s", err)
+			}
+			var gotValue, wantValue interface{}
+			if err = json.Unmarshal(got, &gotValue); err != nil {
+				t.Fatalf("FillBase() = %s, not JSON: %s", got, err)
+			}
+			if err = json.Unmarshal([]byte(tt.want), &wantValue); err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(gotValue, wantValue) {
+				t.Errorf("FillBase() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+
+	ctx.Request.Header.Set("Env", "prod")
+	if _, err := FillBase(ctx, []byte(`{}`), "Base", baseKeys, nil); err == nil || err.Error() != "invalid Env of the Base: expected a i32" {
+		t.Errorf("FillBase() with an invalid header error = %v", err)
+	}
+}
+
+func TestWithMetainfo(t *testing.T) {
+	ctx := app.NewContext(0)
+	ctx.Request.Header.Set("X-Meta-User-Id", "1")
+	ctx.Request.Header.Set("X-Meta-Persist-Trace", "t")
+	ctx.Request.Header.Set("X-Meta-Backward-Ignored", "b")
+	ctx.Request.Header.Set("X-Other", "o")
+
+	c, metadata := WithMetainfo(context.Background(), ctx)
+	if want := map[string]string{"USER_ID": "1", "TRACE": "t"}; !reflect.DeepEqual(metadata, want) {
+		t.Errorf("WithMetainfo() metadata = %v, want %v", metadata, want)
+	}
+	if v, ok := metainfo.GetValue(c, "USER_ID"); !ok || v != "1" {
+		t.Errorf("WithMetainfo() transient USER_ID = %q, %v", v, ok)
+	}
+	if v, ok := metainfo.GetPersistentValue(c, "TRACE"); !ok || v != "t" {
+		t.Errorf("WithMetainfo() persistent TRACE = %q, %v", v, ok)
+	}
+
+	c = metainfo.WithBackwardValues(c)
+	metainfo.SetBackwardValue(c, "STATUS", "done")
+	SetBackwardHeaders(c, ctx)
+	if got := string(ctx.Response.Header.Peek("X-Meta-Backward-Status")); got != "done" {
+		t.Errorf("SetBackwardHeaders() X-Meta-Backward-Status = %q, want done", got)
+	}
+}
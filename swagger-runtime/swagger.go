@@ -0,0 +1,230 @@
+/*
+ * Copyright 2024 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package swaggerruntime serves the documents of the generated swagger servers with their UIs, and controls
+// the access to them. The generated swagger packages build their servers on it, and on kitexproxy for the
+// proxies of the RPC servers.
+package swaggerruntime
+
+import (
+	"context"
+	"encoding/json"
+	"io/fs"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/cloudwego/hertz/pkg/app"
+	"github.com/cloudwego/hertz/pkg/common/hlog"
+	"github.com/cloudwego/hertz/pkg/route"
+	uiassets "github.com/hertz-contrib/swagger-generate/ui-assets"
+	"gopkg.in/yaml.v3"
+)
+
+// UI is a UI of the documents served by the swagger endpoints.
+type UI string
+
+const (
+	SwaggerUI UI = "swagger-ui"
+	Redoc     UI = "redoc"
+	RapiDoc   UI = "rapidoc"
+	Scalar    UI = "scalar"
+)
+
+// Document is an OpenAPI document served by the swagger endpoints besides the generated ones, e.g. the document
+// of another package, listed by its name in the document selector of the UI.
+type Document struct {
+	Name string
+	// YAML is the content of the document.
+	YAML []byte
+}
+
+// SwaggerOptions are the options of the swagger endpoints.
+type SwaggerOptions struct {
+	// Prefix is the prefix of the routes of the endpoints, e.g. /docs for /docs/swagger/index.html
+	// and /docs/openapi.yaml.
+	Prefix string
+	// UI is the UI served at {Prefix}/swagger/index.html, Swagger UI by default.
+	UI UI
+	// Documents are the documents served besides the generated ones, at {Prefix}/{Name}/openapi.yaml.
+	Documents []Document
+	// RewriteServers replaces the servers of the documents by the URL their requests are sent to, from their
+	// Host, X-Forwarded-Proto, X-Forwarded-Host and X-Forwarded-Prefix headers, followed by BasePath.
+	RewriteServers bool
+	// BasePath is the path of the routes of the API in the rewritten servers, e.g. /api.
+	BasePath string
+	// TrustedProxies are the IPs and CIDRs of the proxies whose X-Forwarded-* headers rewrite the servers,
+	// checked against the connection. The headers of every client are trusted when it is empty.
+	TrustedProxies []string
+	// Assets are the assets the script of Redoc, RapiDoc or Scalar is served from, redoc.standalone.js,
+	// rapidoc-min.js or scalar.standalone.js, the scripts embedded in github.com/hertz-contrib/swagger-generate/ui-assets
+	// by default. Swagger UI is served from the assets of github.com/swaggo/files.
+	Assets fs.FS
+	// CDN loads the script of the UI from its CDN when it is missing from Assets, instead of failing to bind.
+	CDN bool
+}
+
+// LoadSwaggerOptions returns the options of the swagger endpoints from the SWAGGER_PREFIX, SWAGGER_UI,
+// SWAGGER_REWRITE_SERVERS, SWAGGER_BASE_PATH, SWAGGER_TRUSTED_PROXIES and SWAGGER_CDN environment variables,
+// the lists separated by commas.
+func LoadSwaggerOptions() SwaggerOptions {
+	opts := SwaggerOptions{
+		Prefix:         os.Getenv("SWAGGER_PREFIX"),
+		UI:             UI(os.Getenv("SWAGGER_UI")),
+		BasePath:       os.Getenv("SWAGGER_BASE_PATH"),
+		TrustedProxies: splitList(os.Getenv("SWAGGER_TRUSTED_PROXIES")),
+		Assets:         uiassets.FS,
+	}
+	var err error
+	if rewrite := os.Getenv("SWAGGER_REWRITE_SERVERS"); rewrite != "" {
+		if opts.RewriteServers, err = strconv.ParseBool(rewrite); err != nil {
+			hlog.Fatal("Invalid SWAGGER_REWRITE_SERVERS:", err)
+		}
+	}
+	if cdn := os.Getenv("SWAGGER_CDN"); cdn != "" {
+		if opts.CDN, err = strconv.ParseBool(cdn); err != nil {
+			hlog.Fatal("Invalid SWAGGER_CDN:", err)
+		}
+	}
+	return opts
+}
+
+// SwaggerOption changes the SwaggerOptions of the swagger endpoints.
+type SwaggerOption func(o *SwaggerOptions)
+
+// WithPrefix sets the prefix of the routes of the swagger endpoints.
+func WithPrefix(prefix string) SwaggerOption {
+	return func(o *SwaggerOptions) {
+		o.Prefix = prefix
+	}
+}
+
+// WithUI sets the UI of the documents.
+func WithUI(ui UI) SwaggerOption {
+	return func(o *SwaggerOptions) {
+		o.UI = ui
+	}
+}
+
+// WithDocument serves a document besides the generated one, listed by its name in the document selector.
+func WithDocument(name string, document []byte) SwaggerOption {
+	return func(o *SwaggerOptions) {
+		o.Documents = append(append([]Document{}, o.Documents...), Document{Name: name, YAML: document})
+	}
+}
+
+// WithServerRewrite rewrites the servers of the documents by the URL of their requests, followed by a base path.
+func WithServerRewrite(basePath string) SwaggerOption {
+	return func(o *SwaggerOptions) {
+		o.RewriteServers, o.BasePath = true, basePath
+	}
+}
+
+// WithTrustedProxies trusts the X-Forwarded-* headers rewriting the servers only from the proxies of these IPs and CIDRs.
+func WithTrustedProxies(proxies ...string) SwaggerOption {
+	return func(o *SwaggerOptions) {
+		o.TrustedProxies = proxies
+	}
+}
+
+// WithAssets sets the assets the script of the UI is served from.
+func WithAssets(assets fs.FS) SwaggerOption {
+	return func(o *SwaggerOptions) {
+		o.Assets = assets
+	}
+}
+
+// WithCDN loads the script of the UI from its CDN when it is missing from the assets.
+func WithCDN() SwaggerOption {
+	return func(o *SwaggerOptions) {
+		o.CDN = true
+	}
+}
+
+// Prefix returns the prefix of the routes of the swagger endpoints of the options, starting with a slash
+// and without a trailing one.
+func Prefix(opts SwaggerOptions) string {
+	prefix := strings.TrimSuffix(opts.Prefix, "/")
+	if prefix != "" && !strings.HasPrefix(prefix, "/") {
+		prefix = "/" + prefix
+	}
+	return prefix
+}
+
+// BindDocuments binds the generated documents, and the documents of the options, under the prefix of the options,
+// at {prefix}/{name}/openapi.yaml and {prefix}/{name}/openapi.json, with the UI listing them at
+// {prefix}/swagger/index.html. A generated document without a name is served at the prefix, listed as openapi.
+// The first document of a path is served.
+func BindDocuments(r route.IRoutes, opts SwaggerOptions, documents ...Document) {
+	if opts.UI == "" {
+		opts.UI = SwaggerUI
+	}
+	if _, ok := uiScripts[opts.UI]; !ok && opts.UI != SwaggerUI {
+		hlog.Fatal("Unknown UI:", opts.UI)
+	}
+
+	proxies := parseNetworks(opts.TrustedProxies, "trusted proxy")
+	prefix := Prefix(opts)
+	paths := make(map[string]bool)
+	var served []*swaggerDocument
+	for _, d := range append(append([]Document{}, documents...), opts.Documents...) {
+		name, path := d.Name, prefix+"/"+d.Name
+		if name == "" {
+			name, path = "openapi", prefix
+		}
+		if paths[path] {
+			hlog.Warnf("Document %s is not served, %s/openapi.yaml serves another document", name, path)
+			continue
+		}
+		paths[path] = true
+
+		document, err := newSwaggerDocument(name, path, d.YAML)
+		if err != nil {
+			hlog.Fatal("Failed to load the document ", name, ": ", err)
+		}
+		served = append(served, document)
+
+		r.GET(path+"/openapi.yaml", func(c context.Context, ctx *app.RequestContext) {
+			document.contentsFor(ctx, opts, proxies).yaml.write(ctx, opts.RewriteServers)
+		})
+		r.GET(path+"/openapi.json", func(c context.Context, ctx *app.RequestContext) {
+			document.contentsFor(ctx, opts, proxies).json.write(ctx, opts.RewriteServers)
+		})
+	}
+
+	r.GET(prefix+"/swagger/*any", uiHandler(opts, served))
+}
+
+// newSwaggerDocument parses a document served at a path, and converts it to JSON.
+func newSwaggerDocument(name, path string, content []byte) (*swaggerDocument, error) {
+	document := &swaggerDocument{name: name, path: path, root: &yaml.Node{}}
+	if err := yaml.Unmarshal(content, document.root); err != nil {
+		return nil, err
+	}
+	if err := document.root.Decode(&document.value); err != nil {
+		return nil, err
+	}
+	document.value = jsonValue(document.value)
+	b, err := json.Marshal(document.value)
+	if err != nil {
+		return nil, err
+	}
+	document.contents = &documentContents{
+		yaml: newDocumentContent("application/x-yaml", content),
+		json: newDocumentContent("application/json", b),
+	}
+	return document, nil
+}
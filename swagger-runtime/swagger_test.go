@@ -0,0 +1,127 @@
+/*
+ * Copyright 2024 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package swaggerruntime
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/cloudwego/hertz/pkg/common/config"
+	"github.com/cloudwego/hertz/pkg/common/ut"
+	"github.com/cloudwego/hertz/pkg/route"
+)
+
+func TestBindDocuments(t *testing.T) {
+	users := strings.Replace(testDocument, "title: Pets", "title: Users", 1)
+	extra := strings.Replace(testDocument, "title: Pets", "title: Extra", 1)
+	opts := SwaggerOptions{
+		Prefix:         "docs/",
+		RewriteServers: true,
+		// The document of the options is not served at the path of a generated document
+		Documents: []Document{{Name: "extra", YAML: []byte(extra)}, {Name: "users", YAML: []byte(extra)}},
+	}
+	engine := route.NewEngine(config.NewOptions(nil))
+	BindDocuments(engine, opts, Document{YAML: []byte(testDocument)}, Document{Name: "users", YAML: []byte(users)})
+
+	titles := map[string]string{
+		"/docs/openapi.yaml":       "Pets",
+		"/docs/openapi.json":       "Pets",
+		"/docs/users/openapi.yaml": "Users",
+		"/docs/extra/openapi.json": "Extra",
+	}
+	for path, title := range titles {
+		w := ut.PerformRequest(engine, http.MethodGet, "http://example.com"+path, nil)
+		body := string(w.Result().Body())
+		if w.Code != http.StatusOK || !strings.Contains(body, "title: "+title) && !strings.Contains(body, `"title":"`+title+`"`) {
+			t.Errorf("GET %s = %d %s, want the document %s", path, w.Code, body, title)
+		}
+		if !strings.Contains(body, "http://example.com") || strings.Contains(body, "localhost") {
+			t.Errorf("GET %s = %s, want the servers rewritten", path, body)
+		}
+	}
+
+	w := ut.PerformRequest(engine, http.MethodGet, "/docs/swagger/index.html", nil)
+	page := string(w.Result().Body())
+	urls := `"urls":[{"name":"openapi","url":"/docs/openapi.yaml"},{"name":"users","url":"/docs/users/openapi.yaml"},{"name":"extra","url":"/docs/extra/openapi.yaml"}]`
+	if w.Code != http.StatusOK || !strings.Contains(page, urls) {
+		t.Errorf("GET /docs/swagger/index.html = %d %s, want the documents %s", w.Code, page, urls)
+	}
+	if w = ut.PerformRequest(engine, http.MethodGet, "/docs/swagger/swagger-ui.css", nil); w.Code != http.StatusOK {
+		t.Errorf("GET /docs/swagger/swagger-ui.css = %d, want the asset of Swagger UI", w.Code)
+	}
+}
+
+func TestBindDocumentsUI(t *testing.T) {
+	assets := fstest.MapFS{"redoc.standalone.js": {Data: []byte("redoc();")}}
+	engine := route.NewEngine(config.NewOptions(nil))
+	BindDocuments(engine, SwaggerOptions{UI: Redoc, Assets: assets}, Document{Name: "pets", YAML: []byte(testDocument)}, Document{Name: "users", YAML: []byte(testDocument)})
+
+	w := ut.PerformRequest(engine, http.MethodGet, "/swagger/index.html?document=users", nil)
+	page := string(w.Result().Body())
+	for _, want := range []string{
+		`<redoc spec-url="/users/openapi.yaml"></redoc>`,
+		`<script src="redoc.standalone.js"></script>`,
+		`<option value="pets">pets</option><option value="users" selected>users</option>`,
+	} {
+		if !strings.Contains(page, want) {
+			t.Errorf("GET /swagger/index.html?document=users = %s, want %s", page, want)
+		}
+	}
+	if w = ut.PerformRequest(engine, http.MethodGet, "/swagger/redoc.standalone.js", nil); string(w.Result().Body()) != "redoc();" {
+		t.Errorf("GET /swagger/redoc.standalone.js = %d %s, want the script of the assets", w.Code, w.Result().Body())
+	}
+	if w = ut.PerformRequest(engine, http.MethodGet, "/swagger/rapidoc-min.js", nil); w.Code != http.StatusNotFound {
+		t.Errorf("GET /swagger/rapidoc-min.js = %d, want 404", w.Code)
+	}
+}
+
+func TestUIPage(t *testing.T) {
+	documents := []*swaggerDocument{{name: "openapi", path: ""}, {name: `a"b`, path: "/a"}}
+	tests := []struct {
+		ui       UI
+		selected string
+		script   string
+		want     []string
+	}{
+		{SwaggerUI, "", "", []string{`"urls":[{"name":"openapi","url":"/openapi.yaml"},{"name":"a\"b","url":"/a/openapi.yaml"}]`, `href="swagger-ui.css"`}},
+		{RapiDoc, "", "https://cdn.example.com/rapidoc.js?a=1&b=2", []string{`<rapi-doc spec-url="/openapi.yaml">`, `src="https://cdn.example.com/rapidoc.js?a=1&amp;b=2"`, `<option value="openapi" selected>`}},
+		{Scalar, `a"b`, "scalar.standalone.js", []string{`data-url="/a/openapi.yaml"`, `<option value="a&#34;b" selected>a&#34;b</option>`}},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.ui), func(t *testing.T) {
+			page := uiPage(tt.ui, documents, tt.selected, tt.script)
+			for _, want := range tt.want {
+				if !strings.Contains(page, want) {
+					t.Errorf("uiPage() = %s, want %s", page, want)
+				}
+			}
+		})
+	}
+
+	page := uiPage(SwaggerUI, documents[:1], "", "")
+	config := `"url":"/openapi.yaml"`
+	if !strings.Contains(page, config) || strings.Contains(page, `"urls"`) {
+		t.Errorf("uiPage() of a document = %s, want %s", page, config)
+	}
+	var v interface{}
+	if start, end := strings.Index(page, "const config = ")+len("const config = "), strings.Index(page, ";\n      config.presets"); json.Unmarshal([]byte(page[start:end]), &v) != nil {
+		t.Errorf("uiPage() config is not JSON: %s", page[start:end])
+	}
+}
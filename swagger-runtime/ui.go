@@ -0,0 +1,168 @@
+/*
+ * Copyright 2024 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package swaggerruntime
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"html"
+	"io/fs"
+	"mime"
+	"net/http"
+	"path"
+	"strings"
+
+	"github.com/cloudwego/hertz/pkg/app"
+	"github.com/cloudwego/hertz/pkg/common/hlog"
+	swaggerFiles "github.com/swaggo/files"
+)
+
+// uiScripts are the scripts of the UIs but Swagger UI: the names of their assets, and their CDN URLs.
+var uiScripts = map[UI][2]string{
+	Redoc:   {"redoc.standalone.js", "https://cdn.jsdelivr.net/npm/redoc@2/bundles/redoc.standalone.js"},
+	RapiDoc: {"rapidoc-min.js", "https://cdn.jsdelivr.net/npm/rapidoc@9/dist/rapidoc-min.js"},
+	Scalar:  {"scalar.standalone.js", "https://cdn.jsdelivr.net/npm/@scalar/api-reference@1/dist/browser/standalone.js"},
+}
+
+// uiHandler serves the page of the UI of the options listing the documents, and the assets of the UI.
+// The script of the UI is served from the assets, or loaded from its CDN if the options allow it.
+func uiHandler(opts SwaggerOptions, documents []*swaggerDocument) app.HandlerFunc {
+	asset, script := uiScripts[opts.UI][0], uiScripts[opts.UI][1]
+	if opts.UI != SwaggerUI {
+		if opts.Assets != nil {
+			if _, err := fs.Stat(opts.Assets, asset); err == nil {
+				script = asset
+			}
+		}
+		if script != asset {
+			if !opts.CDN {
+				hlog.Fatalf("The script of %s, %s, is missing from the assets: set the assets, or load it from its CDN with SWAGGER_CDN", opts.UI, asset)
+			}
+			asset = ""
+		}
+	}
+
+	return func(c context.Context, ctx *app.RequestContext) {
+		file := strings.TrimPrefix(ctx.Param("any"), "/")
+		if file == "" || file == "index.html" {
+			page := uiPage(opts.UI, documents, ctx.Query("document"), script)
+			ctx.Data(http.StatusOK, "text/html; charset=utf-8", []byte(page))
+			return
+		}
+
+		var b []byte
+		var err error
+		switch {
+		case opts.UI == SwaggerUI:
+			b, err = swaggerFiles.ReadFile("/" + file)
+		case file == asset:
+			b, err = fs.ReadFile(opts.Assets, asset)
+		default:
+			err = fs.ErrNotExist
+		}
+		if err != nil {
+			ctx.String(http.StatusNotFound, http.StatusText(http.StatusNotFound))
+			return
+		}
+		contentType := mime.TypeByExtension(path.Ext(file))
+		if contentType == "" {
+			contentType = "application/octet-stream"
+		}
+		ctx.Data(http.StatusOK, contentType, b)
+	}
+}
+
+// uiPage returns the page of a UI listing the documents. Swagger UI selects them with its own selector,
+// the other UIs show the selected document, the first one by default, with a selector of the others.
+func uiPage(ui UI, documents []*swaggerDocument, selected, script string) string {
+	if ui == SwaggerUI {
+		config := map[string]interface{}{"dom_id": "#swagger-ui", "deepLinking": true, "layout": "StandaloneLayout"}
+		if len(documents) == 1 {
+			config["url"] = documents[0].path + "/openapi.yaml"
+		} else {
+			urls := make([]map[string]string, 0, len(documents))
+			for _, d := range documents {
+				urls = append(urls, map[string]string{"name": d.name, "url": d.path + "/openapi.yaml"})
+			}
+			config["urls"] = urls
+		}
+		b, _ := json.Marshal(config)
+		return fmt.Sprintf(uiPageFormat, "  <link rel=\"stylesheet\" href=\"swagger-ui.css\">\n", fmt.Sprintf(swaggerUIBody, b))
+	}
+
+	var current *swaggerDocument
+	for _, d := range documents {
+		if current == nil || d.name == selected {
+			current = d
+		}
+		if d.name == selected {
+			break
+		}
+	}
+	var body strings.Builder
+	if len(documents) > 1 {
+		body.WriteString("  <form style=\"padding: 8px\"><select name=\"document\" onchange=\"this.form.submit()\">")
+		for _, d := range documents {
+			attrs := ""
+			if d == current {
+				attrs = " selected"
+			}
+			fmt.Fprintf(&body, "<option value=\"%s\"%s>%s</option>", html.EscapeString(d.name), attrs, html.EscapeString(d.name))
+		}
+		body.WriteString("</select></form>\n")
+	}
+	url := ""
+	if current != nil {
+		url = html.EscapeString(current.path + "/openapi.yaml")
+	}
+	script = html.EscapeString(script)
+	switch ui {
+	case Redoc:
+		fmt.Fprintf(&body, "  <redoc spec-url=\"%s\"></redoc>\n  <script src=\"%s\"></script>\n", url, script)
+	case RapiDoc:
+		fmt.Fprintf(&body, "  <rapi-doc spec-url=\"%s\"></rapi-doc>\n  <script type=\"module\" src=\"%s\"></script>\n", url, script)
+	case Scalar:
+		fmt.Fprintf(&body, "  <script id=\"api-reference\" data-url=\"%s\"></script>\n  <script src=\"%s\"></script>\n", url, script)
+	}
+	return fmt.Sprintf(uiPageFormat, "", body.String())
+}
+
+const uiPageFormat = `<!DOCTYPE html>
+<html lang="en">
+<head>
+  <meta charset="utf-8">
+  <meta name="viewport" content="width=device-width, initial-scale=1">
+  <title>API Documentation</title>
+%s</head>
+<body style="margin: 0">
+%s</body>
+</html>
+`
+
+const swaggerUIBody = `  <div id="swagger-ui"></div>
+  <script src="swagger-ui-bundle.js"></script>
+  <script src="swagger-ui-standalone-preset.js"></script>
+  <script>
+    window.onload = function () {
+      const config = %s;
+      config.presets = [SwaggerUIBundle.presets.apis, SwaggerUIStandalonePreset];
+      config.plugins = [SwaggerUIBundle.plugins.DownloadUrl];
+      window.ui = SwaggerUIBundle(config);
+    };
+  </script>
+`
@@ -0,0 +1,196 @@
+/*
+ * Copyright 2024 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package swaggerruntime
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// ValidateBody returns an error if a JSON body is invalid or does not match a request schema, resolving
+// the references with the schemas of the IDL. An empty body is an empty object.
+func ValidateBody(schema interface{}, body []byte, schemas map[string]interface{}) error {
+	if schema == nil {
+		return nil
+	}
+	var value interface{} = map[string]interface{}{}
+	if len(bytes.TrimSpace(body)) > 0 {
+		decoder := json.NewDecoder(bytes.NewReader(body))
+		decoder.UseNumber()
+		if err := decoder.Decode(&value); err != nil {
+			return fmt.Errorf("invalid JSON body: %s", err)
+		}
+		if decoder.More() {
+			return errors.New("invalid JSON body: unexpected data after the top-level value")
+		}
+	}
+	return validateValue(schema, value, "body", schemas)
+}
+
+// validateValue returns an error if a value does not match a schema, naming the value by its path. The null
+// values are accepted, as unset fields.
+func validateValue(schema interface{}, value interface{}, path string, schemas map[string]interface{}) error {
+	s, ok := schema.(map[string]interface{})
+	if !ok || value == nil {
+		return nil
+	}
+	if ref, ok := s["$ref"].(string); ok {
+		name := strings.NewReplacer("~1", "/", "~0", "~").Replace(strings.TrimPrefix(ref, "#/components/schemas/"))
+		return validateValue(schemas[name], value, path, schemas)
+	}
+	if allOf, ok := s["allOf"].([]interface{}); ok {
+		for _, sub := range allOf {
+			if err := validateValue(sub, value, path, schemas); err != nil {
+				return err
+			}
+		}
+	}
+	for _, key := range []string{"oneOf", "anyOf"} {
+		if subs, ok := s[key].([]interface{}); ok && len(subs) > 0 {
+			var err error
+			for _, sub := range subs {
+				if err = validateValue(sub, value, path, schemas); err == nil {
+					break
+				}
+			}
+			if err != nil {
+				return fmt.Errorf("%s: does not match any schema of %s", path, key)
+			}
+		}
+	}
+	if enum, ok := s["enum"].([]interface{}); ok && !enumContains(enum, value) {
+		return fmt.Errorf("%s: is not one of the values of the enum", path)
+	}
+
+	switch s["type"] {
+	case "object":
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("%s: expected an object", path)
+		}
+		if required, ok := s["required"].([]interface{}); ok {
+			for _, name := range required {
+				if _, ok := object[fmt.Sprint(name)]; !ok {
+					return fmt.Errorf("%s.%s: is required", path, name)
+				}
+			}
+		}
+		properties, _ := s["properties"].(map[string]interface{})
+		for name, v := range object {
+			if property, ok := properties[name]; ok {
+				if err := validateValue(property, v, path+"."+name, schemas); err != nil {
+					return err
+				}
+				continue
+			}
+			switch additional := s["additionalProperties"].(type) {
+			case bool:
+				if !additional {
+					return fmt.Errorf("%s.%s: is not a property of the object", path, name)
+				}
+			case map[string]interface{}:
+				if err := validateValue(additional, v, path+"."+name, schemas); err != nil {
+					return err
+				}
+			}
+		}
+	case "array":
+		array, ok := value.([]interface{})
+		if !ok {
+			return fmt.Errorf("%s: expected an array", path)
+		}
+		if err := checkBounds(s, "minItems", "maxItems", float64(len(array)), path, "items"); err != nil {
+			return err
+		}
+		for i, item := range array {
+			if err := validateValue(s["items"], item, fmt.Sprintf("%s[%d]", path, i), schemas); err != nil {
+				return err
+			}
+		}
+	case "string":
+		str, ok := value.(string)
+		if !ok {
+			// The 64-bit integers of protobuf are documented as strings, and accepted as numbers
+			if _, isNumber := value.(json.Number); isNumber && (s["format"] == "int64" || s["format"] == "uint64") {
+				return nil
+			}
+			return fmt.Errorf("%s: expected a string", path)
+		}
+		return checkBounds(s, "minLength", "maxLength", float64(utf8.RuneCountInString(str)), path, "characters")
+	case "integer", "number":
+		number, ok := value.(json.Number)
+		if !ok {
+			return fmt.Errorf("%s: expected a %s", path, s["type"])
+		}
+		if s["type"] == "integer" {
+			if _, err := strconv.ParseInt(number.String(), 10, 64); err != nil {
+				if _, err = strconv.ParseUint(number.String(), 10, 64); err != nil {
+					return fmt.Errorf("%s: expected an integer", path)
+				}
+			}
+		}
+		f, err := number.Float64()
+		if err != nil {
+			return fmt.Errorf("%s: invalid number %s", path, number)
+		}
+		if minimum, ok := s["minimum"].(float64); ok && f < minimum {
+			return fmt.Errorf("%s: must be at least %v", path, minimum)
+		}
+		if maximum, ok := s["maximum"].(float64); ok && f > maximum {
+			return fmt.Errorf("%s: must be at most %v", path, maximum)
+		}
+	case "boolean":
+		if _, ok := value.(bool); !ok {
+			return fmt.Errorf("%s: expected a boolean", path)
+		}
+	}
+	return nil
+}
+
+// checkBounds returns an error if a length is out of the bounds of a schema.
+func checkBounds(s map[string]interface{}, minKey, maxKey string, length float64, path, unit string) error {
+	if minimum, ok := s[minKey].(float64); ok && length < minimum {
+		return fmt.Errorf("%s: must have at least %v %s", path, minimum, unit)
+	}
+	if maximum, ok := s[maxKey].(float64); ok && length > maximum {
+		return fmt.Errorf("%s: must have at most %v %s", path, maximum, unit)
+	}
+	return nil
+}
+
+// enumContains reports whether a value is one of the values of an enum, comparing the numbers by value.
+func enumContains(enum []interface{}, value interface{}) bool {
+	for _, v := range enum {
+		switch value := value.(type) {
+		case json.Number:
+			f, err := value.Float64()
+			if n, ok := v.(float64); ok && err == nil && n == f {
+				return true
+			}
+		case string, bool:
+			if v == value {
+				return true
+			}
+		}
+	}
+	return false
+}
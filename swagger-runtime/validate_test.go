@@ -0,0 +1,93 @@
+/*
+ * Copyright 2024 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package swaggerruntime
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func decodeSchema(t *testing.T, s string) interface{} {
+	t.Helper()
+	var schema interface{}
+	if err := json.Unmarshal([]byte(s), &schema); err != nil {
+		t.Fatalf("failed to decode schema %s: %s", s, err)
+	}
+	return schema
+}
+
+// petSchemas are the components of the request bodies of the tests.
+const petSchemas = `{
+	"Pet": {
+		"type": "object",
+		"required": ["name"],
+		"additionalProperties": false,
+		"properties": {
+			"id": {"type": "string", "format": "int64"},
+			"name": {"type": "string", "minLength": 1},
+			"kind": {"$ref": "#/components/schemas/Kind"},
+			"age": {"type": "integer", "format": "int32", "minimum": 0},
+			"tags": {"type": "array", "maxItems": 2, "items": {"type": "string"}},
+			"owner": {"oneOf": [{"type": "string"}, {"$ref": "#/components/schemas/Owner"}]},
+			"labels": {"type": "object", "additionalProperties": {"type": "string"}}
+		}
+	},
+	"Kind": {"type": "string", "enum": ["cat", "dog"]},
+	"Owner": {"type": "object", "required": ["id"], "properties": {"id": {"type": "integer"}}},
+	"NewPet": {"allOf": [{"$ref": "#/components/schemas/Pet"}, {"type": "object", "required": ["kind"]}]}
+}`
+
+func TestValidateBody(t *testing.T) {
+	schemas := decodeSchema(t, petSchemas).(map[string]interface{})
+	tests := []struct {
+		name string
+		body string
+		// err is the error, empty when the body is valid.
+		err string
+	}{
+		{"valid", `{"id": "9007199254740993", "name": "Tom", "kind": "cat", "age": 3, "tags": ["a"], "owner": {"id": 1}, "labels": {"a": "b"}}`, ""},
+		{"int64 as a number and null values", `{"id": 9007199254740993, "name": "Tom", "kind": "dog", "age": null}`, ""},
+		{"empty body", ``, "body.name: is required"},
+		{"invalid JSON", `{"name": "Tom"`, "invalid JSON body"},
+		{"trailing data", `{"name": "Tom"} {}`, "unexpected data after the top-level value"},
+		{"not an object", `["Tom"]`, "body: expected an object"},
+		{"unknown property", `{"name": "Tom", "color": "black", "kind": "cat"}`, "body.color: is not a property of the object"},
+		{"required in allOf", `{"name": "Tom"}`, "body.kind: is required"},
+		{"enum", `{"name": "Tom", "kind": "bird"}`, "body.kind: is not one of the values of the enum"},
+		{"int32 as a string", `{"name": "Tom", "kind": "cat", "age": "3"}`, "body.age: expected a integer"},
+		{"fraction", `{"name": "Tom", "kind": "cat", "age": 1.5}`, "body.age: expected an integer"},
+		{"minimum", `{"name": "Tom", "kind": "cat", "age": -1}`, "body.age: must be at least 0"},
+		{"minLength", `{"name": "", "kind": "cat"}`, "body.name: must have at least 1 characters"},
+		{"maxItems", `{"name": "Tom", "kind": "cat", "tags": ["a", "b", "c"]}`, "body.tags: must have at most 2 items"},
+		{"item", `{"name": "Tom", "kind": "cat", "tags": ["a", 1]}`, "body.tags[1]: expected a string"},
+		{"map value", `{"name": "Tom", "kind": "cat", "labels": {"a": 1}}`, "body.labels.a: expected a string"},
+		{"oneOf", `{"name": "Tom", "kind": "cat", "owner": {}}`, "body.owner: does not match any schema of oneOf"},
+	}
+	schema := decodeSchema(t, `{"$ref": "#/components/schemas/NewPet"}`)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateBody(schema, []byte(tt.body), schemas)
+			if (err == nil) != (tt.err == "") || (err != nil && !strings.Contains(err.Error(), tt.err)) {
+				t.Errorf("ValidateBody() error = %v, want %q", err, tt.err)
+			}
+		})
+	}
+	if err := ValidateBody(nil, []byte("{"), schemas); err != nil {
+		t.Errorf("ValidateBody() without schema error = %s, want nil", err)
+	}
+}
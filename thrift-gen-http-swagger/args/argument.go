@@ -22,8 +22,29 @@ import (
 	"github.com/hertz-contrib/swagger-generate/common/utils"
 )
 
+// Arguments are the options of the plugin, passed like `lint=true,lint_disable=kebab-case-path` by the
+// snake_case names of the fields; the names of the fields, like `Lint=true`, are deprecated aliases.
 type Arguments struct {
-	OutputDir string
+	OutputDir         string
+	Strict            bool     // Strict fails the generation when errors are found, like conflicting operations or unsupported types.
+	WarningsAsErrors  bool     // WarningsAsErrors reports the warnings as errors, and fails the generation like Strict.
+	ExcludeDeprecated bool     // ExcludeDeprecated drops deprecated operations from the document.
+	ExamplesFile      string   // ExamplesFile is a YAML file of examples by operation ID that replace the synthesized ones.
+	DisableExamples   bool     // DisableExamples stops synthesizing examples from the schemas.
+	HoistComponents   bool     // HoistComponents moves the parameters, headers and responses repeated in several operations to the components.
+	Overlay           string   // Overlay is an OpenAPI Overlay or merge patch YAML file applied to the document.
+	Lint              bool     // Lint reports the issues of the linter on the document.
+	LintDisable       []string // LintDisable are the lint rules to skip, separated by `;`.
+	Include           []string // Include are the globs of the `Service.Method` names of the methods to document, separated by `;`.
+	Exclude           []string // Exclude are the globs of the `Service.Method` names of the methods to leave out, separated by `;`.
+	Visibility        string   // Visibility documents only the public elements (public), or all of them (internal).
+	BaseStruct        string   // BaseStruct is the struct of the Kitex Base field of the requests, Base by default.
+	BaseRespStruct    string   // BaseRespStruct is the struct of the Kitex BaseResp field of the responses, BaseResp by default.
+	BaseFieldID       int      // BaseFieldID is the ID of the Base and BaseResp fields, 255 by default.
+	BaseMode          string   // BaseMode documents the Base fields as they are (keep) or not at all (hide).
+	BaseRespMode      string   // BaseRespMode documents the BaseResp fields as they are (keep), not at all (hide) or as a shared envelope (envelope).
+
+	deprecated []string
 }
 
 func (a *Arguments) Unpack(args []string) error {
@@ -31,5 +52,11 @@ func (a *Arguments) Unpack(args []string) error {
 	if err != nil {
 		return fmt.Errorf("unpack argument failed: %s", err)
 	}
+	a.deprecated = utils.DeprecatedArgs(args)
 	return nil
 }
+
+// Deprecated returns the options spelled by the deprecated names of the fields, e.g. `Lint`.
+func (a *Arguments) Deprecated() []string {
+	return a.deprecated
+}
@@ -1,3 +1,5 @@
+//go:build !noswagger
+
 /*
  * Copyright 2024 CloudWeGo Authors
  *
@@ -18,29 +20,94 @@
 package swagger
 
 import (
-	"context"
 	_ "embed"
+	"io/fs"
 
-	"github.com/cloudwego/hertz/pkg/app"
 	"github.com/cloudwego/hertz/pkg/app/server"
-	"github.com/hertz-contrib/cors"
-	"github.com/hertz-contrib/swagger"
-	swaggerFiles "github.com/swaggo/files"
+	swaggerruntime "github.com/hertz-contrib/swagger-generate/swagger-runtime"
 )
 
 //go:embed openapi.yaml
 var openapiYAML []byte
 
-func BindSwagger(h *server.Hertz) {
-	h.Use(cors.Default())
+// BindSwagger binds the swagger endpoints to a Hertz server, with the options of Swagger changed by the options,
+// unless they are disabled by Access. The middlewares of Access only apply to these endpoints.
+func BindSwagger(h *server.Hertz, opts ...SwaggerOption) {
+	if !Access.Enabled {
+		return
+	}
+	options := Swagger
+	for _, opt := range opts {
+		opt(&options)
+	}
+	g := h.Group("", swaggerruntime.AccessControl(Access)...)
+	swaggerruntime.BindDocuments(g, options, swaggerruntime.Document{YAML: openapiYAML})
+}
+
+// AccessOptions control the access to the swagger endpoints, and to the proxy of the RPC servers.
+type AccessOptions = swaggerruntime.AccessOptions
+
+// Access are the access options of the swagger endpoints, from the SWAGGER_ENABLED, SWAGGER_USERNAME,
+// SWAGGER_PASSWORD, SWAGGER_TOKEN, SWAGGER_ALLOWED_IPS and SWAGGER_CORS_ORIGINS environment variables,
+// the lists separated by commas. They are changed before the endpoints are bound.
+var Access = swaggerruntime.LoadAccessOptions()
+
+// UI is a UI of the documents served by the swagger endpoints.
+type UI = swaggerruntime.UI
+
+const (
+	SwaggerUI = swaggerruntime.SwaggerUI
+	Redoc     = swaggerruntime.Redoc
+	RapiDoc   = swaggerruntime.RapiDoc
+	Scalar    = swaggerruntime.Scalar
+)
+
+// Document is an OpenAPI document served by the swagger endpoints besides the generated ones, e.g. the document
+// of another package, listed by its name in the document selector of the UI.
+type Document = swaggerruntime.Document
+
+// SwaggerOptions are the options of the swagger endpoints.
+type SwaggerOptions = swaggerruntime.SwaggerOptions
+
+// Swagger are the options of the swagger endpoints, from the SWAGGER_PREFIX, SWAGGER_UI, SWAGGER_REWRITE_SERVERS,
+// SWAGGER_BASE_PATH, SWAGGER_TRUSTED_PROXIES and SWAGGER_CDN environment variables, the lists separated by commas.
+// They are changed before the endpoints are bound.
+var Swagger = swaggerruntime.LoadSwaggerOptions()
+
+// SwaggerOption changes the SwaggerOptions of BindSwagger.
+type SwaggerOption = swaggerruntime.SwaggerOption
 
-	h.GET("/swagger/*any", swagger.WrapHandler(
-		swaggerFiles.Handler,
-		swagger.URL("/openapi.yaml"),
-	))
+// WithPrefix sets the prefix of the routes of the swagger endpoints.
+func WithPrefix(prefix string) SwaggerOption {
+	return swaggerruntime.WithPrefix(prefix)
+}
+
+// WithUI sets the UI of the documents.
+func WithUI(ui UI) SwaggerOption {
+	return swaggerruntime.WithUI(ui)
+}
+
+// WithDocument serves a document besides the generated one, listed by its name in the document selector.
+func WithDocument(name string, document []byte) SwaggerOption {
+	return swaggerruntime.WithDocument(name, document)
+}
+
+// WithServerRewrite rewrites the servers of the documents by the URL of their requests, followed by a base path.
+func WithServerRewrite(basePath string) SwaggerOption {
+	return swaggerruntime.WithServerRewrite(basePath)
+}
+
+// WithTrustedProxies trusts the X-Forwarded-* headers rewriting the servers only from the proxies of these IPs and CIDRs.
+func WithTrustedProxies(proxies ...string) SwaggerOption {
+	return swaggerruntime.WithTrustedProxies(proxies...)
+}
+
+// WithAssets sets the assets the script of the UI is served from.
+func WithAssets(assets fs.FS) SwaggerOption {
+	return swaggerruntime.WithAssets(assets)
+}
 
-	h.GET("/openapi.yaml", func(c context.Context, ctx *app.RequestContext) {
-		ctx.Header("Content-Type", "application/x-yaml")
-		ctx.Write(openapiYAML)
-	})
+// WithCDN loads the script of the UI from its CDN when it is missing from the assets.
+func WithCDN() SwaggerOption {
+	return swaggerruntime.WithCDN()
 }
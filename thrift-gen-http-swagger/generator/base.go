@@ -0,0 +1,63 @@
+/*
+ * Copyright 2024 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package generator
+
+import (
+	"github.com/cloudwego/thriftgo/thrift_reflection"
+	"github.com/hertz-contrib/swagger-generate/common/consts"
+	openapi "github.com/hertz-contrib/swagger-generate/idl/thrift"
+)
+
+// envelopeSchema returns the schema of the JSON content of a response with a BaseResp body field in the envelope mode:
+// the envelope shared by the responses, combined with the schema of the response without its BaseResp if ref is set.
+// Otherwise it returns the reference to the schema of the response, or nil if ref is empty.
+func (g *OpenAPIGenerator) envelopeSchema(d *openapi.Document, desc *thrift_reflection.StructDescriptor, ref string) *openapi.SchemaOrReference {
+	var schema *openapi.SchemaOrReference
+	if ref != "" {
+		schema = &openapi.SchemaOrReference{Reference: &openapi.Reference{Xref: ref}}
+	}
+	if g.base.BaseRespMode() != consts.BaseRespModeEnvelope {
+		return schema
+	}
+	for _, field := range desc.GetFields() {
+		if !g.base.IsBaseResp(field) || field.Annotations[consts.ApiBody] == nil {
+			continue
+		}
+		propertyName := field.GetName()
+		if field.Annotations[consts.ApiBody][0] != "" {
+			propertyName = field.Annotations[consts.ApiBody][0]
+		}
+		name := g.base.BaseRespStruct() + consts.ComponentSchemaSuffixEnvelope
+		g.addSchemaToDocument(d, &openapi.NamedSchemaOrReference{
+			Name: name,
+			Value: &openapi.SchemaOrReference{Schema: &openapi.Schema{
+				Type:        consts.SchemaObjectType,
+				Description: consts.BaseRespEnvelopeDesc,
+				Properties: &openapi.Properties{AdditionalProperties: []*openapi.NamedSchemaOrReference{{
+					Name:  propertyName,
+					Value: g.schemaOrReferenceForField(field.Type),
+				}}},
+			}},
+		})
+		envelope := &openapi.SchemaOrReference{Reference: &openapi.Reference{Xref: consts.ComponentSchemaPrefix + name}}
+		if schema == nil {
+			return envelope
+		}
+		return &openapi.SchemaOrReference{Schema: &openapi.Schema{AllOf: []*openapi.SchemaOrReference{envelope, schema}}}
+	}
+	return schema
+}
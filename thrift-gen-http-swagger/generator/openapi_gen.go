@@ -34,12 +34,12 @@
 package generator
 
 import (
+	"fmt"
 	"path/filepath"
 	"regexp"
 	"sort"
 	"strings"
 
-	"github.com/cloudwego/hertz/cmd/hz/util/logs"
 	"github.com/cloudwego/thriftgo/parser"
 	"github.com/cloudwego/thriftgo/plugin"
 	"github.com/cloudwego/thriftgo/thrift_reflection"
@@ -48,27 +48,43 @@ import (
 	openapi "github.com/hertz-contrib/swagger-generate/idl/thrift"
 	"github.com/hertz-contrib/swagger-generate/thrift-gen-http-swagger/args"
 	"github.com/hertz-contrib/swagger-generate/thrift-gen-http-swagger/utils"
+	"gopkg.in/yaml.v3"
 )
 
 type OpenAPIGenerator struct {
-	fileDesc         *thrift_reflection.FileDescriptor
-	ast              *parser.Thrift
-	generatedSchemas []string
-	requiredSchemas  []string
-	requiredTypeDesc []*thrift_reflection.StructDescriptor
+	fileDesc           *thrift_reflection.FileDescriptor
+	descs              *common.Descriptors
+	ast                *parser.Thrift
+	generatedSchemas   []string
+	requiredSchemas    []string
+	requiredTypeDesc   []*thrift_reflection.StructDescriptor
+	operationLocations map[string]string // IDL locations of the operations already added, keyed by route.
+	sources            *common.ThriftSources
+	lintLocations      common.LintLocations // IDL locations of the elements of the document, keyed by JSON pointer.
+	lintIssues         []*common.LintIssue  // Issues found in the IDL, reported with the issues of the document.
+	diagnostics        []*common.Diagnostic
+	excludeDeprecated  bool
+	base               *common.BaseConventions
+	filter             *common.Filter
+	fieldVisibilities  map[*thrift_reflection.FieldDescriptor]bool // Visibilities of the fields already validated.
 }
 
 // NewOpenAPIGenerator creates a new generator for a protoc plugin invocation.
 func NewOpenAPIGenerator(ast *parser.Thrift) *OpenAPIGenerator {
-	_, fileDesc := thrift_reflection.RegisterAST(ast)
+	descs, fileDesc := common.NewDescriptors(ast)
 	return &OpenAPIGenerator{
-		fileDesc:         fileDesc,
-		ast:              ast,
-		generatedSchemas: make([]string, 0),
+		fileDesc:           fileDesc,
+		descs:              descs,
+		ast:                ast,
+		generatedSchemas:   make([]string, 0),
+		operationLocations: make(map[string]string),
+		sources:            common.NewThriftSources(),
+		lintLocations:      make(common.LintLocations),
+		fieldVisibilities:  make(map[*thrift_reflection.FieldDescriptor]bool),
 	}
 }
 
-func (g *OpenAPIGenerator) BuildDocument(arguments *args.Arguments) []*plugin.Generated {
+func (g *OpenAPIGenerator) BuildDocument(arguments *args.Arguments) ([]*plugin.Generated, error) {
 	d := &openapi.Document{}
 
 	version := consts.OpenAPIVersion
@@ -88,17 +104,35 @@ func (g *OpenAPIGenerator) BuildDocument(arguments *args.Arguments) []*plugin.Ge
 	var extDocument *openapi.Document
 	err := g.getDocumentOption(&extDocument)
 	if err != nil {
-		logs.Errorf("Error merging document option: %s", err)
-		return nil
+		return nil, fmt.Errorf("error parsing document option: %s", err)
 	}
 	if extDocument != nil {
+		// The default info is only a placeholder, the info of the annotation replaces it
+		if extDocument.Info != nil {
+			d.Info = &openapi.Info{}
+		}
 		err := common.MergeStructs(d, extDocument)
 		if err != nil {
-			logs.Errorf("Error merging document option: %s", err)
-			return nil
+			return nil, fmt.Errorf("error merging document option: %s", err)
 		}
 	}
 
+	if err = common.CheckLintRules(arguments.LintDisable); err != nil {
+		return nil, err
+	}
+
+	if g.base, err = common.NewBaseConventions(g.descs, arguments.BaseStruct, arguments.BaseRespStruct, arguments.BaseFieldID, arguments.BaseMode, arguments.BaseRespMode); err != nil {
+		return nil, err
+	}
+	// Hertz does not fill the Base from the headers, unlike the proxy of the RPC swagger servers.
+	if g.base.BaseMode() == consts.BaseModeHeader {
+		return nil, fmt.Errorf("BaseMode %s is only supported by thrift-gen-rpc-swagger, expected %s or %s", consts.BaseModeHeader, consts.BaseModeKeep, consts.BaseModeHide)
+	}
+	if g.filter, err = common.NewFilter(arguments.Include, arguments.Exclude, arguments.Visibility); err != nil {
+		return nil, err
+	}
+
+	g.excludeDeprecated = arguments.ExcludeDeprecated
 	g.addPathsToDocument(d, g.fileDesc.GetServices())
 
 	for len(g.requiredSchemas) > 0 {
@@ -164,12 +198,24 @@ func (g *OpenAPIGenerator) BuildDocument(arguments *args.Arguments) []*plugin.Ge
 		}
 	}
 
-	// Set all servers on API level
+	// Set all servers on API level, merged with the servers of the document annotation
 	if len(allServers) > 0 {
-		d.Servers = []*openapi.Server{}
+		servers := []*openapi.Server{}
 		for _, server := range allServers {
-			d.Servers = append(d.Servers, &openapi.Server{URL: server})
+			s := &openapi.Server{URL: server}
+			for _, annotated := range d.Servers {
+				if annotated.URL == server {
+					s = annotated
+				}
+			}
+			servers = append(servers, s)
 		}
+		for _, annotated := range d.Servers {
+			if !common.Contains(allServers, annotated.URL) {
+				servers = append(servers, annotated)
+			}
+		}
+		d.Servers = servers
 	}
 
 	// If there is only 1 server, we can safely remove all path level servers
@@ -203,10 +249,37 @@ func (g *OpenAPIGenerator) BuildDocument(arguments *args.Arguments) []*plugin.Ge
 		d.Components.Schemas.AdditionalProperties = pairs
 	}
 
-	bytes, err := d.YAMLValue("Generated with " + consts.PluginNameThriftHttpSwagger + "\n" + consts.InfoURL + consts.PluginNameThriftHttpSwagger)
+	rawInfo := d.ToRawInfo()
+	if g.filter.Active() {
+		common.PruneSchemas(rawInfo)
+	}
+	var examples map[string]*common.OperationExamples
+	if arguments.ExamplesFile != "" {
+		examples, err = common.LoadOperationExamples(arguments.ExamplesFile)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if err = common.AddExamples(rawInfo, examples, !arguments.DisableExamples); err != nil {
+		g.warnf(g.fileDesc.Filepath, common.DiagnosticInvalidExample, "%s", err)
+	}
+	if err = common.HoistComponents(rawInfo, arguments.HoistComponents); err != nil {
+		g.warnf(g.fileDesc.Filepath, common.DiagnosticConflictingComponent, "%s", err)
+	}
+	if arguments.Overlay != "" {
+		g.diagnostics = append(g.diagnostics, common.ApplyOverlay(rawInfo, arguments.Overlay)...)
+	}
+	if arguments.Lint {
+		g.lint(rawInfo, arguments.LintDisable)
+	}
+
+	if err = common.CheckDiagnostics(g.diagnostics, arguments.Strict, arguments.WarningsAsErrors); err != nil {
+		return nil, err
+	}
+
+	bytes, err := common.MarshalDocument(rawInfo, "Generated with "+consts.PluginNameThriftHttpSwagger+"\n"+consts.InfoURL+consts.PluginNameThriftHttpSwagger)
 	if err != nil {
-		logs.Errorf("Error converting to yaml: %s", err)
-		return nil
+		return nil, fmt.Errorf("error converting to yaml: %s", err)
 	}
 	outputDir := arguments.OutputDir
 	if outputDir == "" {
@@ -219,20 +292,20 @@ func (g *OpenAPIGenerator) BuildDocument(arguments *args.Arguments) []*plugin.Ge
 		Name:    &filePath,
 	})
 
-	return ret
+	return ret, nil
 }
 
 func (g *OpenAPIGenerator) getDocumentOption(obj interface{}) error {
 	serviceOrStruct, name := g.getDocumentAnnotationInWhichServiceOrStruct()
 	if serviceOrStruct == consts.DocumentOptionServiceType {
 		serviceDesc := g.fileDesc.GetServiceDescriptor(name)
-		err := utils.ParseServiceOption(serviceDesc, consts.OpenapiDocument, obj)
+		err := utils.ParseServiceOption(g.descs, serviceDesc, consts.OpenapiDocument, obj)
 		if err != nil {
 			return err
 		}
 	} else if serviceOrStruct == consts.DocumentOptionStructType {
 		structDesc := g.fileDesc.GetStructDescriptor(name)
-		err := utils.ParseStructOption(structDesc, consts.OpenapiDocument, obj)
+		err := utils.ParseStructOption(g.descs, structDesc, consts.OpenapiDocument, obj)
 		if err != nil {
 			return err
 		}
@@ -243,6 +316,9 @@ func (g *OpenAPIGenerator) getDocumentOption(obj interface{}) error {
 func (g *OpenAPIGenerator) addPathsToDocument(d *openapi.Document, services []*thrift_reflection.ServiceDescriptor) {
 	var err error
 	for _, s := range services {
+		if !g.visible(s.Annotations, g.sources.Location(s.Filepath, s.GetName(), "")) {
+			continue
+		}
 		annotationsCount := 0
 		for _, m := range s.GetMethods() {
 			var inputDesc, outputDesc, throwDesc *thrift_reflection.StructDescriptor
@@ -254,33 +330,22 @@ func (g *OpenAPIGenerator) addPathsToDocument(d *openapi.Document, services []*t
 
 			if len(m.Args) > 0 {
 				if len(m.Args) > 1 {
-					logs.Warnf("function '%s' has more than one argument, but only the first can be used in plugin now", m.GetName())
+					g.warnf(g.methodLocation(s, m), common.DiagnosticIgnoredArgument, "function '%s' has more than one argument, but only the first can be used in plugin now", m.GetName())
 				}
 				// TODO: support more argument types
-				if m.Args[0].GetType().IsStruct() {
-					inputDesc, err = m.Args[0].GetType().GetStructDescriptor()
-					if err != nil {
-						logs.Errorf("Error getting arguments descriptor: %s", err)
-					}
-				} else {
-					logs.Errorf("now only support struct type for input, but got %s", m.Args[0].GetType().GetName())
+				if inputDesc = g.descs.Struct(m.Args[0].GetType()); inputDesc == nil {
+					g.errorf(g.methodLocation(s, m), common.DiagnosticUnsupportedType, "now only support struct type for input, but got %s", m.Args[0].GetType().GetName())
 				}
 			}
 
 			// TODO: support more response types
-			if m.Response.IsStruct() {
-				outputDesc, err = m.Response.GetStructDescriptor()
-				if err != nil {
-					logs.Errorf("Error getting response descriptor: %s", err)
-				}
-			} else if m.Response.Name != "void" {
-				logs.Errorf("now only support struct type for output, but got %s", m.Response.Name)
+			if outputDesc = g.descs.Struct(m.Response); outputDesc == nil && m.Response.Name != "void" {
+				g.errorf(g.methodLocation(s, m), common.DiagnosticUnsupportedType, "now only support struct type for output, but got %s", m.Response.Name)
 			}
 
 			if len(m.ThrowExceptions) > 0 {
-				throwDesc, err = m.ThrowExceptions[0].GetType().GetExceptionDescriptor()
-				if err != nil {
-					logs.Errorf("Error getting exception descriptor: %s", err)
+				if throwDesc = g.descs.Exception(m.ThrowExceptions[0].GetType()); throwDesc == nil {
+					g.errorf(g.methodLocation(s, m), common.DiagnosticUnresolvedType, "exception %s is not found", m.ThrowExceptions[0].GetType().GetName())
 				}
 			}
 
@@ -294,29 +359,45 @@ func (g *OpenAPIGenerator) addPathsToDocument(d *openapi.Document, services []*t
 						host = domains[0]
 					}
 
+					newOp := &openapi.Operation{}
+					err = utils.ParseMethodOption(g.descs, m, consts.OpenapiOperation, &newOp)
+					if err != nil {
+						g.errorf(g.methodLocation(s, m), common.DiagnosticInvalidOption, "Error parsing method option: %s", err)
+					}
+
+					methodComment := common.ParseComment(m.Comments)
+					if methodComment.Internal || !g.filter.IncludesMethod(s.GetName(), m.GetName()) || !g.visible(m.Annotations, g.methodLocation(s, m)) {
+						continue
+					}
+					deprecated, reason := g.getDeprecation(m.Annotations, m.Comments)
+					if g.excludeDeprecated && (deprecated || newOp.Deprecated) {
+						continue
+					}
+
 					annotationsCount++
 					operationID := s.GetName() + "_" + m.GetName()
-					comment := g.filterCommentString(m.Comments)
 
-					op, path2 := g.buildOperation(d, methodName, comment, operationID, s.GetName(), path[0], host, inputDesc, outputDesc, throwDesc)
+					op, path2 := g.buildOperation(d, methodName, methodComment.Description, operationID, s.GetName(), path[0], host, inputDesc, outputDesc, throwDesc)
+					op.Summary = methodComment.Summary
+					op.ExternalDocs = externalDocs(methodComment)
 
-					newOp := &openapi.Operation{}
-					err = utils.ParseMethodOption(m, consts.OpenapiOperation, &newOp)
-					if err != nil {
-						logs.Errorf("Error parsing method option: %s", err)
-					}
 					err = common.MergeStructs(op, newOp)
 					if err != nil {
-						logs.Errorf("Error merging method option: %s", err)
+						g.errorf(g.methodLocation(s, m), common.DiagnosticInvalidOption, "Error merging method option: %s", err)
+					}
+					if deprecated {
+						op.Deprecated = true
+						op.SpecificationExtension = append(op.SpecificationExtension, deprecatedReasonExtension(reason)...)
 					}
 
-					g.addOperationToDocument(d, op, path2, methodName)
+					g.addOperationToDocument(d, op, path2, methodName, g.methodLocation(s, m))
+					g.addUndocumentedExceptions(m, methodName, path2)
 				}
 			}
 		}
 		if annotationsCount > 0 {
-			comment := g.filterCommentString(s.Comments)
-			d.Tags = append(d.Tags, &openapi.Tag{Name: s.GetName(), Description: comment})
+			serviceComment := common.ParseComment(s.Comments)
+			d.Tags = append(d.Tags, &openapi.Tag{Name: s.GetName(), Description: serviceComment.Text, ExternalDocs: externalDocs(serviceComment)})
 		}
 	}
 }
@@ -341,19 +422,24 @@ func (g *OpenAPIGenerator) buildOperation(
 		var fieldSchema *openapi.SchemaOrReference
 		required := false
 
+		fieldComment := common.ParseComment(v.Comments)
+		if fieldComment.Internal || g.base.Skip(v) || !g.visibleField(inputDesc, v) {
+			continue
+		}
+
 		extOrNil := v.Annotations[consts.ApiQuery]
 		if len(extOrNil) > 0 {
 			if ext := v.Annotations[consts.ApiQuery][0]; ext != "" {
 				paramIn = consts.ParameterInQuery
 				paramName = ext
-				paramDesc = g.filterCommentString(v.Comments)
+				paramDesc = fieldComment.Text
 				fieldSchema = g.schemaOrReferenceForField(v.Type)
 				extPropertyOrNil := v.Annotations[consts.OpenapiProperty]
 				if len(extPropertyOrNil) > 0 {
 					newFieldSchema := &openapi.Schema{}
-					err := utils.ParseFieldOption(v, consts.OpenapiProperty, &newFieldSchema)
+					err := utils.ParseFieldOption(g.descs, v, consts.OpenapiProperty, &newFieldSchema)
 					if err != nil {
-						logs.Errorf("Error parsing field option: %s", err)
+						g.errorf(g.sources.Location(inputDesc.Filepath, inputDesc.GetName(), v.GetName()), common.DiagnosticInvalidOption, "Error parsing field option: %s", err)
 					}
 					common.MergeStructs(fieldSchema.Schema, newFieldSchema)
 				}
@@ -364,14 +450,14 @@ func (g *OpenAPIGenerator) buildOperation(
 			if ext := v.Annotations[consts.ApiPath][0]; ext != "" {
 				paramIn = consts.ParameterInPath
 				paramName = ext
-				paramDesc = g.filterCommentString(v.Comments)
+				paramDesc = fieldComment.Text
 				fieldSchema = g.schemaOrReferenceForField(v.Type)
 				extPropertyOrNil := v.Annotations[consts.OpenapiProperty]
 				if len(extPropertyOrNil) > 0 {
 					newFieldSchema := &openapi.Schema{}
-					err := utils.ParseFieldOption(v, consts.OpenapiProperty, &newFieldSchema)
+					err := utils.ParseFieldOption(g.descs, v, consts.OpenapiProperty, &newFieldSchema)
 					if err != nil {
-						logs.Errorf("Error parsing field option: %s", err)
+						g.errorf(g.sources.Location(inputDesc.Filepath, inputDesc.GetName(), v.GetName()), common.DiagnosticInvalidOption, "Error parsing field option: %s", err)
 					}
 					common.MergeStructs(fieldSchema.Schema, newFieldSchema)
 				}
@@ -383,14 +469,14 @@ func (g *OpenAPIGenerator) buildOperation(
 			if ext := v.Annotations[consts.ApiCookie][0]; ext != "" {
 				paramIn = consts.ParameterInCookie
 				paramName = ext
-				paramDesc = g.filterCommentString(v.Comments)
+				paramDesc = fieldComment.Text
 				fieldSchema = g.schemaOrReferenceForField(v.Type)
 				extPropertyOrNil := v.Annotations[consts.OpenapiProperty]
 				if len(extPropertyOrNil) > 0 {
 					newFieldSchema := &openapi.Schema{}
-					err := utils.ParseFieldOption(v, consts.OpenapiProperty, &newFieldSchema)
+					err := utils.ParseFieldOption(g.descs, v, consts.OpenapiProperty, &newFieldSchema)
 					if err != nil {
-						logs.Errorf("Error parsing field option: %s", err)
+						g.errorf(g.sources.Location(inputDesc.Filepath, inputDesc.GetName(), v.GetName()), common.DiagnosticInvalidOption, "Error parsing field option: %s", err)
 					}
 					common.MergeStructs(fieldSchema.Schema, newFieldSchema)
 				}
@@ -401,14 +487,14 @@ func (g *OpenAPIGenerator) buildOperation(
 			if ext := v.Annotations[consts.ApiHeader][0]; ext != "" {
 				paramIn = consts.ParameterInHeader
 				paramName = ext
-				paramDesc = g.filterCommentString(v.Comments)
+				paramDesc = fieldComment.Text
 				fieldSchema = g.schemaOrReferenceForField(v.Type)
 				extPropertyOrNil := v.Annotations[consts.OpenapiProperty]
 				if len(extPropertyOrNil) > 0 {
 					newFieldSchema := &openapi.Schema{}
-					err := utils.ParseFieldOption(v, consts.OpenapiProperty, &newFieldSchema)
+					err := utils.ParseFieldOption(g.descs, v, consts.OpenapiProperty, &newFieldSchema)
 					if err != nil {
-						logs.Errorf("Error parsing field option: %s", err)
+						g.errorf(g.sources.Location(inputDesc.Filepath, inputDesc.GetName(), v.GetName()), common.DiagnosticInvalidOption, "Error parsing field option: %s", err)
 					}
 					common.MergeStructs(fieldSchema.Schema, newFieldSchema)
 				}
@@ -421,15 +507,22 @@ func (g *OpenAPIGenerator) buildOperation(
 			Description: paramDesc,
 			Required:    required,
 			Schema:      fieldSchema,
+			Example:     example(fieldComment),
 		}
 
 		var extParameter *openapi.Parameter
-		err := utils.ParseFieldOption(v, consts.OpenapiParameter, &extParameter)
+		err := utils.ParseFieldOption(g.descs, v, consts.OpenapiParameter, &extParameter)
 		if err != nil {
-			logs.Errorf("Error parsing field option: %s", err)
+			g.errorf(g.sources.Location(inputDesc.Filepath, inputDesc.GetName(), v.GetName()), common.DiagnosticInvalidOption, "Error parsing field option: %s", err)
 		}
 		common.MergeStructs(parameter, extParameter)
 
+		if deprecated, reason := g.getDeprecation(v.Annotations, v.Comments); deprecated {
+			parameter.Deprecated = true
+			parameter.SpecificationExtension = append(parameter.SpecificationExtension, deprecatedReasonExtension(reason)...)
+		}
+		parameter.SpecificationExtension = append(parameter.SpecificationExtension, componentExtension(v.Annotations)...)
+
 		// Append the parameter to the parameters array if it was set
 		if paramName != "" && paramIn != "" {
 			parameters = append(parameters, &openapi.ParameterOrReference{
@@ -522,7 +615,7 @@ func (g *OpenAPIGenerator) buildOperation(
 			if len(additionalProperties) > 0 {
 				RequestBody = &openapi.RequestBodyOrReference{
 					RequestBody: &openapi.RequestBody{
-						Description: g.filterCommentString(inputDesc.Comments),
+						Description: common.ParseComment(inputDesc.Comments).Text,
 						Content: &openapi.MediaTypes{
 							AdditionalProperties: additionalProperties,
 						},
@@ -579,7 +672,7 @@ func (g *OpenAPIGenerator) buildOperation(
 
 func (g *OpenAPIGenerator) processResponse(d *openapi.Document, desc *thrift_reflection.StructDescriptor, statusCode string) *openapi.NamedResponseOrReference {
 	name, header, content := g.getResponseForStruct(d, desc, statusCode)
-	description := g.filterCommentString(desc.Comments)
+	description := common.ParseComment(desc.Comments).Text
 
 	if description == "" {
 		if statusCode == consts.StatusOK {
@@ -641,12 +734,19 @@ func (g *OpenAPIGenerator) getResponseForStruct(d *openapi.Document, desc *thrif
 		if len(field.Annotations[consts.ApiHeader]) < 1 {
 			continue
 		}
-		if ext := field.Annotations[consts.ApiHeader][0]; ext != "" {
+		fieldComment := common.ParseComment(field.Comments)
+		if ext := field.Annotations[consts.ApiHeader][0]; ext != "" && !fieldComment.Internal && !g.base.Skip(field) && g.visibleField(desc, field) {
 			headerName := ext
 			header := &openapi.Header{
-				Description: g.filterCommentString(field.Comments),
+				Description: fieldComment.Text,
 				Schema:      g.schemaOrReferenceForField(field.Type),
+				Example:     example(fieldComment),
+			}
+			if deprecated, reason := g.getDeprecation(field.Annotations, field.Comments); deprecated {
+				header.Deprecated = true
+				header.SpecificationExtension = deprecatedReasonExtension(reason)
 			}
+			header.SpecificationExtension = append(header.SpecificationExtension, componentExtension(field.Annotations)...)
 			headers.AdditionalProperties = append(headers.AdditionalProperties, &openapi.NamedHeaderOrReference{
 				Name: headerName,
 				Value: &openapi.HeaderOrReference{
@@ -661,19 +761,20 @@ func (g *OpenAPIGenerator) getResponseForStruct(d *openapi.Document, desc *thrif
 	rawBodySchema := g.getSchemaByOption(desc, consts.ApiRawBody)
 	var additionalProperties []*openapi.NamedMediaType
 
+	var bodyRef string
 	if len(bodySchema.Properties.AdditionalProperties) > 0 {
 		refSchema := &openapi.NamedSchemaOrReference{
 			Name:  desc.GetName() + consts.ComponentSchemaSuffixBody,
 			Value: &openapi.SchemaOrReference{Schema: bodySchema},
 		}
-		ref := consts.ComponentSchemaPrefix + desc.GetName() + consts.ComponentSchemaSuffixBody
+		bodyRef = consts.ComponentSchemaPrefix + desc.GetName() + consts.ComponentSchemaSuffixBody
 		g.addSchemaToDocument(d, refSchema)
+	}
+	if schema := g.envelopeSchema(d, desc, bodyRef); schema != nil {
 		additionalProperties = append(additionalProperties, &openapi.NamedMediaType{
 			Name: consts.ContentTypeJSON,
 			Value: &openapi.MediaType{
-				Schema: &openapi.SchemaOrReference{
-					Reference: &openapi.Reference{Xref: ref},
-				},
+				Schema: schema,
 			},
 		})
 	}
@@ -703,15 +804,17 @@ func (g *OpenAPIGenerator) getResponseForStruct(d *openapi.Document, desc *thrif
 }
 
 func (g *OpenAPIGenerator) getSchemaByOption(inputDesc *thrift_reflection.StructDescriptor, option string) *openapi.Schema {
+	// The schema is added to the components by the callers, with a name suffixed by the option.
+	schemaName := inputDesc.GetName() + optionSchemaSuffixes[option]
 	definitionProperties := &openapi.Properties{
 		AdditionalProperties: make([]*openapi.NamedSchemaOrReference, 0),
 	}
 
 	var allRequired []string
 	var extSchema *openapi.Schema
-	err := utils.ParseStructOption(inputDesc, consts.OpenapiSchema, &extSchema)
+	err := utils.ParseStructOption(g.descs, inputDesc, consts.OpenapiSchema, &extSchema)
 	if err != nil {
-		logs.Errorf("Error parsing struct option: %s", err)
+		g.errorf(g.sources.Location(inputDesc.Filepath, inputDesc.GetName(), ""), common.DiagnosticInvalidOption, "Error parsing struct option: %s", err)
 	}
 	if extSchema != nil {
 		if extSchema.Required != nil {
@@ -722,6 +825,11 @@ func (g *OpenAPIGenerator) getSchemaByOption(inputDesc *thrift_reflection.Struct
 	var required []string
 	for _, field := range inputDesc.GetFields() {
 		if field.Annotations[option] != nil {
+			fieldComment := common.ParseComment(field.Comments)
+			if fieldComment.Internal || g.base.Skip(field) || !g.visibleField(inputDesc, field) {
+				continue
+			}
+
 			extName := field.GetName()
 			if field.Annotations[option] != nil && field.Annotations[option][0] != "" {
 				extName = field.Annotations[option][0]
@@ -732,7 +840,7 @@ func (g *OpenAPIGenerator) getSchemaByOption(inputDesc *thrift_reflection.Struct
 			}
 
 			// Get the field description from the comments.
-			description := g.filterCommentString(field.Comments)
+			description := fieldComment.Text
 			fieldSchema := g.schemaOrReferenceForField(field.Type)
 			if fieldSchema == nil {
 				continue
@@ -740,17 +848,23 @@ func (g *OpenAPIGenerator) getSchemaByOption(inputDesc *thrift_reflection.Struct
 
 			if fieldSchema.IsSetSchema() {
 				fieldSchema.Schema.Description = description
+				fieldSchema.Schema.Example = example(fieldComment)
+				fieldSchema.Schema.ExternalDocs = externalDocs(fieldComment)
 				newFieldSchema := &openapi.Schema{}
-				err := utils.ParseFieldOption(field, consts.OpenapiProperty, &newFieldSchema)
+				err := utils.ParseFieldOption(g.descs, field, consts.OpenapiProperty, &newFieldSchema)
 				if err != nil {
-					logs.Errorf("Error parsing field option: %s", err)
+					g.errorf(g.sources.Location(inputDesc.Filepath, inputDesc.GetName(), field.GetName()), common.DiagnosticInvalidOption, "Error parsing field option: %s", err)
 				}
 				err = common.MergeStructs(fieldSchema.Schema, newFieldSchema)
 				if err != nil {
-					logs.Errorf("Error merging field option: %s", err)
+					g.errorf(g.sources.Location(inputDesc.Filepath, inputDesc.GetName(), field.GetName()), common.DiagnosticInvalidOption, "Error merging field option: %s", err)
 				}
 			}
 
+			if deprecated, reason := g.getDeprecation(field.Annotations, field.Comments); deprecated {
+				fieldSchema = deprecateSchema(fieldSchema, reason)
+			}
+
 			definitionProperties.AdditionalProperties = append(
 				definitionProperties.AdditionalProperties,
 				&openapi.NamedSchemaOrReference{
@@ -758,8 +872,10 @@ func (g *OpenAPIGenerator) getSchemaByOption(inputDesc *thrift_reflection.Struct
 					Value: fieldSchema,
 				},
 			)
+			g.lintLocations[common.SchemaPointer(schemaName, extName)] = g.sources.Location(inputDesc.Filepath, inputDesc.GetName(), field.GetName())
 		}
 	}
+	g.lintLocations[common.SchemaPointer(schemaName)] = g.sources.Location(inputDesc.Filepath, inputDesc.GetName(), "")
 
 	schema := &openapi.Schema{
 		Type:       consts.SchemaObjectType,
@@ -769,54 +885,17 @@ func (g *OpenAPIGenerator) getSchemaByOption(inputDesc *thrift_reflection.Struct
 	if extSchema != nil {
 		err := common.MergeStructs(schema, extSchema)
 		if err != nil {
-			logs.Errorf("Error merging struct option: %s", err)
+			g.errorf(g.sources.Location(inputDesc.Filepath, inputDesc.GetName(), ""), common.DiagnosticInvalidOption, "Error merging struct option: %s", err)
 		}
 	}
 
-	schema.Required = required
-	return schema
-}
-
-// filterCommentString removes linter rules from comments.
-func (g *OpenAPIGenerator) filterCommentString(str string) string {
-	var comments []string
-	matches := regexp.MustCompile(consts.CommentPatternRegexp).FindAllStringSubmatch(str, -1)
-
-	for _, match := range matches {
-		if match[1] != "" {
-			// Handle one-line comments
-			comments = append(comments, strings.TrimSpace(match[1]))
-		} else if match[2] != "" {
-			// Handle multiline comments
-			multiLineComment := match[2]
-			lines := strings.Split(multiLineComment, "\n")
-
-			// Find the minimum indentation level (excluding empty lines)
-			minIndent := -1
-			for _, line := range lines {
-				trimmedLine := strings.TrimSpace(line)
-				if trimmedLine != "" {
-					lineIndent := len(line) - len(strings.TrimLeft(line, " "))
-					if minIndent == -1 || lineIndent < minIndent {
-						minIndent = lineIndent
-					}
-				}
-			}
-
-			// Remove the minimum indentation and any leading '*' from each line
-			for i, line := range lines {
-				if minIndent > 0 && len(line) >= minIndent {
-					line = line[minIndent:]
-				}
-				lines[i] = strings.TrimPrefix(line, "*")
-			}
-
-			// Remove leading and trailing empty lines from the comment block
-			comments = append(comments, strings.TrimSpace(strings.Join(lines, "\n")))
-		}
+	if deprecated, reason := g.getDeprecation(inputDesc.Annotations, inputDesc.Comments); deprecated {
+		schema.Deprecated = true
+		schema.SpecificationExtension = append(schema.SpecificationExtension, deprecatedReasonExtension(reason)...)
 	}
 
-	return strings.Join(comments, "\n")
+	schema.Required = required
+	return schema
 }
 
 func (g *OpenAPIGenerator) addSchemasForStructsToDocument(d *openapi.Document, structs []*thrift_reflection.StructDescriptor) {
@@ -825,11 +904,10 @@ func (g *OpenAPIGenerator) addSchemasForStructsToDocument(d *openapi.Document, s
 		for _, f := range s.GetFields() {
 			fieldType := f.GetType()
 			if fieldType == nil {
-				logs.Errorf("Warning: field type is nil for field: %s\n", f.GetName())
+				g.warnf(g.sources.Location(s.Filepath, s.GetName(), f.GetName()), common.DiagnosticUnresolvedType, "field type is nil for field %s", f.GetName())
 				continue
 			}
-			if fieldType.IsStruct() {
-				structDesc, _ := fieldType.GetStructDescriptor()
+			if structDesc := g.descs.Struct(fieldType); structDesc != nil {
 				sls = append(sls, structDesc)
 			}
 		}
@@ -846,7 +924,7 @@ func (g *OpenAPIGenerator) addSchemasForStructsToDocument(d *openapi.Document, s
 		}
 
 		// Get the description from the comments.
-		messageDescription := g.filterCommentString(s.Comments)
+		messageComment := common.ParseComment(s.Comments)
 
 		// Build an array holding the fields of the message.
 		definitionProperties := &openapi.Properties{
@@ -855,25 +933,34 @@ func (g *OpenAPIGenerator) addSchemasForStructsToDocument(d *openapi.Document, s
 
 		for _, field := range s.Fields {
 			// Get the field description from the comments.
-			description := g.filterCommentString(field.Comments)
+			fieldComment := common.ParseComment(field.Comments)
+			if fieldComment.Internal || g.base.Skip(field) || !g.visibleField(s, field) {
+				continue
+			}
 			fieldSchema := g.schemaOrReferenceForField(field.Type)
 			if fieldSchema == nil {
 				continue
 			}
 
 			if fieldSchema.IsSetSchema() {
-				fieldSchema.Schema.Description = description
+				fieldSchema.Schema.Description = fieldComment.Text
+				fieldSchema.Schema.Example = example(fieldComment)
+				fieldSchema.Schema.ExternalDocs = externalDocs(fieldComment)
 				newFieldSchema := &openapi.Schema{}
-				err := utils.ParseFieldOption(field, consts.OpenapiProperty, &newFieldSchema)
+				err := utils.ParseFieldOption(g.descs, field, consts.OpenapiProperty, &newFieldSchema)
 				if err != nil {
-					logs.Errorf("Error parsing field option: %s", err)
+					g.errorf(g.sources.Location(s.Filepath, s.GetName(), field.GetName()), common.DiagnosticInvalidOption, "Error parsing field option: %s", err)
 				}
 				err = common.MergeStructs(fieldSchema.Schema, newFieldSchema)
 				if err != nil {
-					logs.Errorf("Error merging field option: %s", err)
+					g.errorf(g.sources.Location(s.Filepath, s.GetName(), field.GetName()), common.DiagnosticInvalidOption, "Error merging field option: %s", err)
 				}
 			}
 
+			if deprecated, reason := g.getDeprecation(field.Annotations, field.Comments); deprecated {
+				fieldSchema = deprecateSchema(fieldSchema, reason)
+			}
+
 			extName := field.GetName()
 			options := []string{consts.ApiHeader, consts.ApiBody, consts.ApiForm, consts.ApiRawBody}
 			for _, option := range options {
@@ -889,27 +976,36 @@ func (g *OpenAPIGenerator) addSchemasForStructsToDocument(d *openapi.Document, s
 					Value: fieldSchema,
 				},
 			)
+			g.lintLocations[common.SchemaPointer(schemaName, extName)] = g.sources.Location(s.Filepath, s.GetName(), field.GetName())
 		}
 
 		schema := &openapi.Schema{
-			Type:        consts.SchemaObjectType,
-			Description: messageDescription,
-			Properties:  definitionProperties,
+			Type:         consts.SchemaObjectType,
+			Description:  messageComment.Text,
+			Properties:   definitionProperties,
+			Example:      example(messageComment),
+			ExternalDocs: externalDocs(messageComment),
 		}
 
 		var extSchema *openapi.Schema
-		err := utils.ParseStructOption(s, consts.OpenapiSchema, &extSchema)
+		err := utils.ParseStructOption(g.descs, s, consts.OpenapiSchema, &extSchema)
 		if err != nil {
-			logs.Errorf("Error parsing struct option: %s", err)
+			g.errorf(g.sources.Location(s.Filepath, s.GetName(), ""), common.DiagnosticInvalidOption, "Error parsing struct option: %s", err)
 		}
 		if extSchema != nil {
 			err = common.MergeStructs(schema, extSchema)
 			if err != nil {
-				logs.Errorf("Error merging struct option: %s", err)
+				g.errorf(g.sources.Location(s.Filepath, s.GetName(), ""), common.DiagnosticInvalidOption, "Error merging struct option: %s", err)
 			}
 		}
 
+		if deprecated, reason := g.getDeprecation(s.Annotations, s.Comments); deprecated {
+			schema.Deprecated = true
+			schema.SpecificationExtension = append(schema.SpecificationExtension, deprecatedReasonExtension(reason)...)
+		}
+
 		// Add the schema to the components.schema list.
+		g.lintLocations[common.SchemaPointer(schemaName)] = g.sources.Location(s.Filepath, s.GetName(), "")
 		g.addSchemaToDocument(d, &openapi.NamedSchemaOrReference{
 			Name: schemaName,
 			Value: &openapi.SchemaOrReference{
@@ -928,7 +1024,63 @@ func (g *OpenAPIGenerator) addSchemaToDocument(d *openapi.Document, schema *open
 	d.Components.Schemas.AdditionalProperties = append(d.Components.Schemas.AdditionalProperties, schema)
 }
 
-func (g *OpenAPIGenerator) addOperationToDocument(d *openapi.Document, op *openapi.Operation, path, methodName string) {
+// methodLocation describes where a method is declared in the IDL, used in diagnostics.
+func (g *OpenAPIGenerator) methodLocation(s *thrift_reflection.ServiceDescriptor, m *thrift_reflection.MethodDescriptor) string {
+	return fmt.Sprintf("%s (%s.%s)", g.sources.Location(s.Filepath, s.GetName(), m.GetName()), s.GetName(), m.GetName())
+}
+
+// addUndocumentedExceptions reports the exceptions of a method after the first one, which are not documented.
+func (g *OpenAPIGenerator) addUndocumentedExceptions(m *thrift_reflection.MethodDescriptor, methodName, path string) {
+	if len(m.ThrowExceptions) < 2 {
+		return
+	}
+	for _, e := range m.ThrowExceptions[1:] {
+		g.lintIssues = append(g.lintIssues, &common.LintIssue{
+			Rule:    common.LintDocumentedException,
+			Pointer: common.OperationPointer(methodName, path),
+			Message: fmt.Sprintf("exception %s of %s is not documented, only the first exception is", e.GetType().GetName(), m.GetName()),
+		})
+	}
+}
+
+// lint reports the issues of the IDL and of the document as warnings, at the IDL locations of their elements.
+func (g *OpenAPIGenerator) lint(document *yaml.Node, disabled []string) {
+	for _, issue := range append(g.lintIssues, common.LintDocument(document, disabled)...) {
+		if !common.Contains(disabled, issue.Rule) {
+			g.warnf(g.lintLocations.Lookup(issue.Pointer, g.fileDesc.Filepath), issue.Rule, "%s", issue.Message)
+		}
+	}
+}
+
+// warnf reports a problem that leaves the document usable.
+func (g *OpenAPIGenerator) warnf(location, code, format string, a ...interface{}) {
+	g.diagnostics = append(g.diagnostics, &common.Diagnostic{Severity: common.SeverityWarning, Code: code, Location: location, Message: fmt.Sprintf(format, a...)})
+}
+
+// errorf reports a problem that leaves an element out of the document, or documents it partially.
+func (g *OpenAPIGenerator) errorf(location, code, format string, a ...interface{}) {
+	g.diagnostics = append(g.diagnostics, &common.Diagnostic{Severity: common.SeverityError, Code: code, Location: location, Message: fmt.Sprintf(format, a...)})
+}
+
+// Diagnostics returns the problems found while building the document.
+func (g *OpenAPIGenerator) Diagnostics() []*common.Diagnostic {
+	return g.diagnostics
+}
+
+// addOperationToDocument adds an operation to the specified path/method.
+// If another method already declared the same route, the operation is dropped and the conflict is reported.
+func (g *OpenAPIGenerator) addOperationToDocument(d *openapi.Document, op *openapi.Operation, path, methodName, location string) {
+	key := common.OperationKey(methodName, path)
+	if existing, ok := g.operationLocations[key]; ok {
+		g.errorf(location, common.DiagnosticConflictingOperation, "duplicate route %s %s, already declared by %s, only the first one is documented", methodName, path, existing)
+		return
+	}
+	g.operationLocations[key] = location
+	g.lintLocations[common.OperationPointer(methodName, path)] = location
+	if _, ok := g.lintLocations[common.PathPointer(path)]; !ok {
+		g.lintLocations[common.PathPointer(path)] = location
+	}
+
 	var selectedPathItem *openapi.NamedPathItem
 	for _, namedPathItem := range d.Paths.Path {
 		if namedPathItem.Name == path {
@@ -972,13 +1124,10 @@ func (g *OpenAPIGenerator) schemaReferenceForMessage(message *thrift_reflection.
 func (g *OpenAPIGenerator) schemaOrReferenceForField(fieldType *thrift_reflection.TypeDescriptor) *openapi.SchemaOrReference {
 	var kindSchema *openapi.SchemaOrReference
 
+	structDesc, unionDesc := g.descs.Struct(fieldType), g.descs.Union(fieldType)
+	typedefDesc, enumDesc := g.descs.Typedef(fieldType), g.descs.Enum(fieldType)
 	switch {
-	case fieldType.IsStruct():
-		structDesc, err := fieldType.GetStructDescriptor()
-		if err != nil {
-			logs.Errorf("Error getting struct descriptor: %s", err)
-			return nil
-		}
+	case structDesc != nil:
 		ref := g.schemaReferenceForMessage(structDesc)
 		kindSchema = &openapi.SchemaOrReference{
 			Reference: &openapi.Reference{Xref: ref},
@@ -1006,34 +1155,29 @@ func (g *OpenAPIGenerator) schemaOrReferenceForField(fieldType *thrift_reflectio
 			},
 		}
 
-	case fieldType.IsTypedef():
-		typedefDesc, err := fieldType.GetTypedefDescriptor()
-		if err != nil {
-			logs.Errorf("Error getting typedef descriptor: %s", err)
-			return nil
-		}
+	case typedefDesc != nil:
 		kindSchema = g.schemaOrReferenceForField(typedefDesc.Type)
 
-	case fieldType.IsEnum():
-		enumDesc, err := fieldType.GetEnumDescriptor()
-		if err != nil {
-			logs.Errorf("Error getting enum descriptor: %s", err)
-			return nil
-		}
+	case enumDesc != nil:
 		kindSchema = &openapi.SchemaOrReference{Schema: &openapi.Schema{}}
 		kindSchema.Schema.Type = "string"
 		kindSchema.Schema.Format = "enum"
 		kindSchema.Schema.Enum = make([]*openapi.Any, 0, len(enumDesc.GetValues()))
+		var deprecatedValues []string
 		for _, v := range enumDesc.GetValues() {
 			kindSchema.Schema.Enum = append(kindSchema.Schema.Enum, &openapi.Any{Yaml: v.GetName()})
+			if deprecated, _ := g.getDeprecation(v.Annotations, v.Comments); deprecated {
+				deprecatedValues = append(deprecatedValues, v.GetName())
+			}
 		}
-
-	case fieldType.IsUnion():
-		unionDesc, err := fieldType.GetUnionDescriptor()
-		if err != nil {
-			logs.Errorf("Error getting union descriptor: %s", err)
-			return nil
+		if len(deprecatedValues) > 0 {
+			kindSchema.Schema.SpecificationExtension = append(kindSchema.Schema.SpecificationExtension, &openapi.NamedAny{
+				Name:  consts.ExtensionDeprecatedEnumValues,
+				Value: &openapi.Any{Yaml: common.YAMLValue(deprecatedValues)},
+			})
 		}
+
+	case unionDesc != nil:
 		kindSchema = &openapi.SchemaOrReference{Schema: &openapi.Schema{}}
 		kindSchema.Schema.OneOf = make([]*openapi.SchemaOrReference, 0, len(unionDesc.GetFields()))
 		for _, f := range unionDesc.GetFields() {
@@ -1041,8 +1185,11 @@ func (g *OpenAPIGenerator) schemaOrReferenceForField(fieldType *thrift_reflectio
 			kindSchema.Schema.OneOf = append(kindSchema.Schema.OneOf, fieldSchema)
 		}
 
-	case fieldType.IsException():
-		logs.Errorf("Error: exception type not supported: %s for field", fieldType.GetName())
+	case g.descs.Exception(fieldType) != nil:
+		g.errorf(g.fileDesc.Filepath, common.DiagnosticUnsupportedType, "exception type %s is not supported for fields", fieldType.GetName())
+
+	case !fieldType.IsBasic():
+		g.errorf(g.fileDesc.Filepath, common.DiagnosticUnresolvedType, "type %s is not found", fieldType.GetName())
 
 	default:
 		kindSchema = &openapi.SchemaOrReference{Schema: &openapi.Schema{}}
@@ -1078,6 +1225,100 @@ func (g *OpenAPIGenerator) schemaOrReferenceForField(fieldType *thrift_reflectio
 	return kindSchema
 }
 
+// getDeprecation reports whether an IDL element is deprecated by the `api.deprecated`/`deprecated` annotation
+// or by its comments, and returns the reason of the deprecation.
+func (g *OpenAPIGenerator) getDeprecation(annotations map[string][]string, comments string) (bool, string) {
+	for _, key := range []string{consts.ApiDeprecated, consts.Deprecated} {
+		if values, ok := annotations[key]; ok {
+			deprecated, reason := common.ParseDeprecation(values)
+			if deprecated && reason == "" {
+				reason = common.ParseComment(comments).DeprecatedReason
+			}
+			return deprecated, reason
+		}
+	}
+	comment := common.ParseComment(comments)
+	return comment.Deprecated, comment.DeprecatedReason
+}
+
+// visible reports whether an element is documented according to its `openapi.visibility` annotation,
+// and reports the invalid visibilities.
+func (g *OpenAPIGenerator) visible(annotations map[string][]string, location string) bool {
+	var visibility string
+	if values := annotations[consts.OpenapiVisibility]; len(values) > 0 {
+		visibility = values[0]
+	}
+	visible, err := g.filter.Visible(visibility)
+	if err != nil {
+		g.errorf(location, common.DiagnosticInvalidOption, "%s", err)
+	}
+	return visible
+}
+
+// visibleField reports whether a field of a struct is documented according to its visibility.
+// A field is read by the parameters, the bodies and the schemas, so its visibility is validated the first time only.
+func (g *OpenAPIGenerator) visibleField(desc *thrift_reflection.StructDescriptor, field *thrift_reflection.FieldDescriptor) bool {
+	if visible, ok := g.fieldVisibilities[field]; ok {
+		return visible
+	}
+	visible := g.visible(field.Annotations, g.sources.Location(desc.Filepath, desc.GetName(), field.GetName()))
+	g.fieldVisibilities[field] = visible
+	return visible
+}
+
+// externalDocs returns the externalDocs of the first `@see` tag of a comment.
+func externalDocs(comment *common.Comment) *openapi.ExternalDocs {
+	if len(comment.See) == 0 {
+		return nil
+	}
+	return &openapi.ExternalDocs{URL: comment.See[0].URL, Description: comment.See[0].Description}
+}
+
+// example returns the value of the first `@example` tag of a comment.
+func example(comment *common.Comment) *openapi.Any {
+	if len(comment.Examples) == 0 {
+		return nil
+	}
+	return &openapi.Any{Yaml: comment.Examples[0]}
+}
+
+// deprecatedReasonExtension returns the x-deprecated-reason extension, or nothing when no reason is given.
+func deprecatedReasonExtension(reason string) []*openapi.NamedAny {
+	if reason == "" {
+		return nil
+	}
+	return []*openapi.NamedAny{{
+		Name:  consts.ExtensionDeprecatedReason,
+		Value: &openapi.Any{Yaml: common.YAMLValue(reason)},
+	}}
+}
+
+// componentExtension returns the x-component extension naming the component of a parameter or header
+// after the `openapi.component` annotation, or nothing when it is not annotated.
+func componentExtension(annotations map[string][]string) []*openapi.NamedAny {
+	values := annotations[consts.OpenapiComponent]
+	if len(values) == 0 || values[0] == "" {
+		return nil
+	}
+	return []*openapi.NamedAny{{
+		Name:  consts.ExtensionComponent,
+		Value: &openapi.Any{Yaml: common.YAMLValue(values[0])},
+	}}
+}
+
+// deprecateSchema marks a property as deprecated.
+// References are wrapped in allOf, because siblings of a $ref are ignored.
+func deprecateSchema(schema *openapi.SchemaOrReference, reason string) *openapi.SchemaOrReference {
+	if !schema.IsSetSchema() {
+		schema = &openapi.SchemaOrReference{
+			Schema: &openapi.Schema{AllOf: []*openapi.SchemaOrReference{schema}},
+		}
+	}
+	schema.Schema.Deprecated = true
+	schema.Schema.SpecificationExtension = append(schema.Schema.SpecificationExtension, deprecatedReasonExtension(reason)...)
+	return schema
+}
+
 var HttpMethodAnnotations = map[string]string{
 	consts.ApiGet:     "GET",
 	consts.ApiPost:    "POST",
@@ -1088,3 +1329,10 @@ var HttpMethodAnnotations = map[string]string{
 	consts.ApiHEAD:    "HEAD",
 	consts.ApiAny:     "ANY",
 }
+
+// optionSchemaSuffixes are the suffixes of the names of the schemas made of the fields with an option.
+var optionSchemaSuffixes = map[string]string{
+	consts.ApiBody:    consts.ComponentSchemaSuffixBody,
+	consts.ApiForm:    consts.ComponentSchemaSuffixForm,
+	consts.ApiRawBody: consts.ComponentSchemaSuffixRawBody,
+}
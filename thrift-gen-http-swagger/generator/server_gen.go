@@ -55,7 +55,7 @@ func NewServerGenerator(ast *parser.Thrift, args *args.Arguments) (*ServerGenera
 func (g *ServerGenerator) Generate() ([]*plugin.Generated, error) {
 	filePath := filepath.Join(g.OutputDir, consts.DefaultOutputSwaggerFile)
 
-	tmpl, err := template.New("server").Delims("{{", "}}").Parse(consts.CodeGenerationCommentThriftHttp + "\n" + tpl.ServerTemplateHttp)
+	tmpl, err := template.New("server").Delims("{{", "}}").Parse(consts.BuildConstraintSwagger + "\n\n" + consts.CodeGenerationCommentThriftHttp + "\n" + tpl.ServerTemplateHttp)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse template: %v", err)
 	}
@@ -66,8 +66,12 @@ func (g *ServerGenerator) Generate() ([]*plugin.Generated, error) {
 		return nil, fmt.Errorf("failed to execute template: %v", err)
 	}
 
+	noopFilePath := filepath.Join(g.OutputDir, consts.DefaultOutputNoopFile)
 	return []*plugin.Generated{{
 		Content: buf.String(),
 		Name:    &filePath,
+	}, {
+		Content: consts.BuildConstraintNoop + "\n\n" + consts.CodeGenerationCommentThriftHttp + "\n" + tpl.NoopTemplateHttp,
+		Name:    &noopFilePath,
 	}}, nil
 }
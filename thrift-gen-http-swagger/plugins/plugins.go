@@ -18,11 +18,13 @@ package plugins
 
 import (
 	"errors"
+	"fmt"
 	"io"
 	"os"
 
 	"github.com/cloudwego/hertz/cmd/hz/util/logs"
 	"github.com/cloudwego/thriftgo/plugin"
+	common "github.com/hertz-contrib/swagger-generate/common/utils"
 	"github.com/hertz-contrib/swagger-generate/thrift-gen-http-swagger/args"
 	"github.com/hertz-contrib/swagger-generate/thrift-gen-http-swagger/generator"
 )
@@ -49,38 +51,44 @@ func Run() int {
 	return 0
 }
 
-func handleRequest(req *plugin.Request) (err error) {
+func handleRequest(req *plugin.Request) error {
+	res, err := Generate(req)
+	if err != nil {
+		return err
+	}
+	return handleResponse(res)
+}
+
+// Generate generates the OpenAPI document and the swagger server of a thriftgo request.
+func Generate(req *plugin.Request) (*plugin.Response, error) {
 	if req == nil {
-		return errors.New("request is nil")
+		return nil, errors.New("request is nil")
 	}
 
 	args := new(args.Arguments)
 	if err := args.Unpack(req.PluginParameters); err != nil {
-		return err
+		return nil, err
 	}
 
-	ast := req.GetAST()
+	contents, diagnostics, err := generator.Generate(req.GetAST(), args)
 
-	og := generator.NewOpenAPIGenerator(ast)
-	openapiContent := og.BuildDocument(args)
-
-	sg, err := generator.NewServerGenerator(ast, args)
-	if err != nil {
-		return err
+	// thriftgo prints the warnings of the response, which is the only way to report the diagnostics.
+	warnings := make([]string, 0, len(diagnostics))
+	for _, d := range diagnostics {
+		if d.Severity == common.SeverityError {
+			warnings = append(warnings, fmt.Sprintf("%s: %s", d.Severity, d))
+		} else {
+			warnings = append(warnings, d.String())
+		}
 	}
-	serverContent, err := sg.Generate()
 	if err != nil {
-		return err
-	}
-
-	res := &plugin.Response{
-		Contents: append(openapiContent, serverContent...),
+		// The error is returned in the response too, so that thriftgo prints the diagnostics before it.
+		return plugin.BuildErrorResponse(err.Error(), warnings...), nil
 	}
-	if err := handleResponse(res); err != nil {
-		return err
-	}
-
-	return err
+	return &plugin.Response{
+		Contents: contents,
+		Warnings: warnings,
+	}, nil
 }
 
 func handleResponse(res *plugin.Response) error {
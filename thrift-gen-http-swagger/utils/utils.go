@@ -17,99 +17,24 @@
 package utils
 
 import (
-	"encoding/json"
-	"errors"
-
-	"github.com/cloudwego/thriftgo/extension/thrift_option"
 	"github.com/cloudwego/thriftgo/thrift_reflection"
+	common "github.com/hertz-contrib/swagger-generate/common/utils"
 )
 
-func ParseStructOption(descriptor *thrift_reflection.StructDescriptor, optionName string, obj interface{}) error {
-	opt, err := thrift_option.ParseStructOption(descriptor, optionName)
-	if errors.Is(err, thrift_option.ErrKeyNotMatch) ||
-		errors.Is(err, thrift_option.ErrNotIncluded) ||
-		errors.Is(err, thrift_option.ErrNotExistOption) {
-		return nil
-	}
-	if err != nil {
-		return err
-	}
-	mapVal := opt.GetValue()
-	mapValMap := mapVal.(map[string]interface{})
-	jsonData, err := json.Marshal(mapValMap)
-	if err != nil {
-		return err
-	}
-	if err = json.Unmarshal(jsonData, obj); err != nil {
-		return err
-	}
-	return err
+func ParseStructOption(descs *common.Descriptors, descriptor *thrift_reflection.StructDescriptor, optionName string, obj interface{}) error {
+	return descs.ParseOption(descriptor, "_StructOptions", optionName, obj)
 }
 
-func ParseServiceOption(descriptor *thrift_reflection.ServiceDescriptor, optionName string, obj interface{}) error {
-	opt, err := thrift_option.ParseServiceOption(descriptor, optionName)
-	if errors.Is(err, thrift_option.ErrKeyNotMatch) ||
-		errors.Is(err, thrift_option.ErrNotIncluded) ||
-		errors.Is(err, thrift_option.ErrNotExistOption) {
-		return nil
-	}
-	if err != nil {
-		return err
-	}
-	mapVal := opt.GetValue()
-	mapValMap := mapVal.(map[string]interface{})
-	jsonData, err := json.Marshal(mapValMap)
-	if err != nil {
-		return err
-	}
-	if err = json.Unmarshal(jsonData, obj); err != nil {
-		return err
-	}
-	return err
+func ParseServiceOption(descs *common.Descriptors, descriptor *thrift_reflection.ServiceDescriptor, optionName string, obj interface{}) error {
+	return descs.ParseOption(descriptor, "_ServiceOptions", optionName, obj)
 }
 
-func ParseMethodOption(descriptor *thrift_reflection.MethodDescriptor, optionName string, obj interface{}) error {
-	opt, err := thrift_option.ParseMethodOption(descriptor, optionName)
-	if errors.Is(err, thrift_option.ErrKeyNotMatch) ||
-		errors.Is(err, thrift_option.ErrNotIncluded) ||
-		errors.Is(err, thrift_option.ErrNotExistOption) {
-		return nil
-	}
-	if err != nil {
-		return err
-	}
-	mapVal := opt.GetValue()
-	mapValMap := mapVal.(map[string]interface{})
-	jsonData, err := json.Marshal(mapValMap)
-	if err != nil {
-		return err
-	}
-	if err = json.Unmarshal(jsonData, obj); err != nil {
-		return err
-	}
-	return err
+func ParseMethodOption(descs *common.Descriptors, descriptor *thrift_reflection.MethodDescriptor, optionName string, obj interface{}) error {
+	return descs.ParseOption(descriptor, "_MethodOptions", optionName, obj)
 }
 
-func ParseFieldOption(descriptor *thrift_reflection.FieldDescriptor, optionName string, obj interface{}) error {
-	opt, err := thrift_option.ParseFieldOption(descriptor, optionName)
-	if errors.Is(err, thrift_option.ErrKeyNotMatch) ||
-		errors.Is(err, thrift_option.ErrNotIncluded) ||
-		errors.Is(err, thrift_option.ErrNotExistOption) {
-		return nil
-	}
-	if err != nil {
-		return err
-	}
-	mapVal := opt.GetValue()
-	mapValMap := mapVal.(map[string]interface{})
-	jsonData, err := json.Marshal(mapValMap)
-	if err != nil {
-		return err
-	}
-	if err = json.Unmarshal(jsonData, obj); err != nil {
-		return err
-	}
-	return err
+func ParseFieldOption(descs *common.Descriptors, descriptor *thrift_reflection.FieldDescriptor, optionName string, obj interface{}) error {
+	return descs.ParseOption(descriptor, "_FieldOptions", optionName, obj)
 }
 
 func GetAnnotations(input map[string][]string, targets map[string]string) map[string][]string {
@@ -22,10 +22,41 @@ import (
 	"github.com/hertz-contrib/swagger-generate/common/utils"
 )
 
+// Arguments are the options of the plugin, passed like `lint=true,lint_disable=kebab-case-path` by the
+// snake_case names of the fields; the names of the fields, like `Lint=true`, are deprecated aliases.
 type Arguments struct {
-	OutputDir string
-	HertzAddr string
-	KitexAddr string
+	OutputDir         string
+	HertzAddr         string
+	KitexAddr         string
+	IdlName           string   // IdlName names the files and the routes of the IDL, to generate several IDLs into one package.
+	Transport         string   // Transport is the transport protocol of the proxy: ttheader, ttheader_framed, framed or buffered.
+	RPCTimeout        string   // RPCTimeout is the timeout of the calls of the proxy, as a duration like 3s.
+	ConnectTimeout    string   // ConnectTimeout is the timeout of the connections of the proxy, as a duration like 500ms.
+	MaxRetries        int      // MaxRetries is the maximum number of retries of the failed calls of the proxy.
+	Codec             string   // Codec is the payload codec of the calls of the proxy: json or binary.
+	Strict            bool     // Strict fails the generation when errors are found, like conflicting operations or unsupported types.
+	WarningsAsErrors  bool     // WarningsAsErrors reports the warnings as errors, and fails the generation like Strict.
+	ServicePrefix     bool     // ServicePrefix documents methods as /{Service}/{Method} instead of /{Method}.
+	ExcludeDeprecated bool     // ExcludeDeprecated drops deprecated operations from the document.
+	ExamplesFile      string   // ExamplesFile is a YAML file of examples by operation ID that replace the synthesized ones.
+	DisableExamples   bool     // DisableExamples stops synthesizing examples from the schemas.
+	HoistComponents   bool     // HoistComponents moves the parameters, headers and responses repeated in several operations to the components.
+	Overlay           string   // Overlay is an OpenAPI Overlay or merge patch YAML file applied to the document.
+	Lint              bool     // Lint reports the issues of the linter on the document.
+	LintDisable       []string // LintDisable are the lint rules to skip, separated by `;`.
+	Include           []string // Include are the globs of the `Service.Method` names of the methods to document, separated by `;`.
+	Exclude           []string // Exclude are the globs of the `Service.Method` names of the methods to leave out, separated by `;`.
+	MetaKeys          []string // MetaKeys are the metainfo keys documented as X-Meta-* request headers, separated by `;`.
+	PersistentKeys    []string // PersistentKeys are the metainfo keys documented as X-Meta-Persist-* request headers, separated by `;`.
+	BackwardKeys      []string // BackwardKeys are the metainfo keys documented as X-Meta-Backward-* response headers, separated by `;`.
+	Visibility        string   // Visibility documents only the public elements (public), or all of them (internal).
+	BaseStruct        string   // BaseStruct is the struct of the Kitex Base field of the requests, Base by default.
+	BaseRespStruct    string   // BaseRespStruct is the struct of the Kitex BaseResp field of the responses, BaseResp by default.
+	BaseFieldID       int      // BaseFieldID is the ID of the Base and BaseResp fields, 255 by default.
+	BaseMode          string   // BaseMode documents the Base fields as they are (keep), not at all (hide) or as headers (header).
+	BaseRespMode      string   // BaseRespMode documents the BaseResp fields as they are (keep), not at all (hide) or as a shared envelope (envelope).
+
+	deprecated []string
 }
 
 func (a *Arguments) Unpack(args []string) error {
@@ -33,5 +64,11 @@ func (a *Arguments) Unpack(args []string) error {
 	if err != nil {
 		return fmt.Errorf("unpack argument failed: %s", err)
 	}
+	a.deprecated = utils.DeprecatedArgs(args)
 	return nil
 }
+
+// Deprecated returns the options spelled by the deprecated names of the fields, e.g. `Lint`.
+func (a *Arguments) Deprecated() []string {
+	return a.deprecated
+}
@@ -61,7 +61,7 @@ struct HelloResp {
 
 // HelloService1描述
 service HelloService1 {
-    HelloResp QueryMethod(1: QueryReq req) ()
+    HelloResp QueryMethod(1: QueryReq req) (api.safe = "true")
 
     HelloResp PathMethod(1: PathReq req) ()
 
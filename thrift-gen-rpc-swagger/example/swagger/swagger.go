@@ -1,3 +1,5 @@
+//go:build !noswagger
+
 /*
  * Copyright 2024 CloudWeGo Authors
  *
@@ -19,271 +21,113 @@ package swagger
 
 import (
 	"context"
-	_ "embed"
-	"encoding/json"
-	"errors"
-	"fmt"
-	"net"
-	"net/http"
 	"os"
-	"path/filepath"
-	"regexp"
-	"strings"
 
-	"github.com/bytedance/gopkg/cloud/metainfo"
-	"github.com/cloudwego/hertz/pkg/app"
-	"github.com/cloudwego/hertz/pkg/app/server"
-	"github.com/cloudwego/hertz/pkg/common/hlog"
 	"github.com/cloudwego/hertz/pkg/network"
-	"github.com/cloudwego/hertz/pkg/route"
-	"github.com/cloudwego/kitex/client"
-	"github.com/cloudwego/kitex/client/genericclient"
-	"github.com/cloudwego/kitex/pkg/endpoint"
-	"github.com/cloudwego/kitex/pkg/generic"
-	"github.com/cloudwego/kitex/pkg/klog"
 	"github.com/cloudwego/kitex/pkg/remote"
-	"github.com/cloudwego/kitex/pkg/remote/trans/detection"
-	"github.com/cloudwego/kitex/pkg/remote/trans/netpoll"
-	"github.com/cloudwego/kitex/pkg/remote/trans/nphttp2"
-	"github.com/cloudwego/kitex/pkg/transmeta"
-	"github.com/cloudwego/kitex/transport"
-	"github.com/hertz-contrib/cors"
-	"github.com/hertz-contrib/swagger"
-	swaggerFiles "github.com/swaggo/files"
-)
-
-var (
-	//go:embed openapi.yaml
-	openapiYAML []byte
-	hertzEngine *route.Engine
-	httpReg     = regexp.MustCompile("^(?:GET |POST|PUT|DELE|HEAD|OPTI|CONN|TRAC|PATC)$")
+	swaggerruntime "github.com/hertz-contrib/swagger-generate/swagger-runtime"
+	"github.com/hertz-contrib/swagger-generate/swagger-runtime/kitexproxy"
 )
 
 const (
-	kitexAddr = "127.0.0.1:8888"
-	idlFile   = "hello.thrift"
+	kitexAddr             = "127.0.0.1:8888"
+	defaultTransport      = "ttheader"
+	defaultRPCTimeout     = ""
+	defaultConnectTimeout = ""
+	defaultMaxRetries     = 0
+	defaultCodec          = "json"
 )
 
-type MixTransHandlerFactory struct {
-	OriginFactory remote.ServerTransHandlerFactory
+// ProxyOptions are the options of the proxy: the options of the plugin, overridden by the SWAGGER_TRANSPORT,
+// SWAGGER_RPC_TIMEOUT, SWAGGER_CONNECT_TIMEOUT, SWAGGER_MAX_RETRIES, SWAGGER_CODEC, SWAGGER_MAX_BODY_SIZE,
+// SWAGGER_READ_ONLY, SWAGGER_ADDR environment variables.
+// They are changed before the Kitex server starts.
+var ProxyOptions = kitexproxy.LoadOptions(kitexproxy.Defaults{
+	Transport:      defaultTransport,
+	RPCTimeout:     defaultRPCTimeout,
+	ConnectTimeout: defaultConnectTimeout,
+	MaxRetries:     defaultMaxRetries,
+	Codec:          defaultCodec,
+})
+
+// proxy is the swagger server of the package, serving the IDLs registered by the idl.go files.
+var proxy = &kitexproxy.Server{
+	Addr:     kitexAddr,
+	Protocol: kitexproxy.Thrift,
+	Options:  &ProxyOptions,
+	Access:   &Access,
+	Swagger:  &Swagger,
+	IdlDir:   &IdlDir,
 }
 
-type transHandler struct {
-	remote.ServerTransHandler
-}
+// Options are the options of the proxy and of its generic clients.
+type Options = kitexproxy.Options
 
-func (t *transHandler) SetInvokeHandleFunc(inkHdlFunc endpoint.Endpoint) {
-	t.ServerTransHandler.(remote.InvokeHandleFuncSetter).SetInvokeHandleFunc(inkHdlFunc)
-}
-
-func (m MixTransHandlerFactory) NewTransHandler(opt *remote.ServerOption) (remote.ServerTransHandler, error) {
+// ProtocolDetector reports whether a connection of the Kitex server carries HTTP requests served by the swagger
+// server, peeking at its first bytes without reading them.
+type ProtocolDetector = swaggerruntime.ProtocolDetector
 
-	if hertzEngine == nil {
-		StartServer()
-	}
+// IdlDir reads the IDL files from this directory, by the paths they are embedded with, instead of the files
+// embedded in the idl.go files, to try the edits of the IDL without regenerating. The files missing from it
+// stay embedded. It is read from the SWAGGER_IDL_DIR environment variable by default.
+var IdlDir = os.Getenv("SWAGGER_IDL_DIR")
 
-	var kitexOrigin remote.ServerTransHandler
-	var err error
-
-	if m.OriginFactory != nil {
-		kitexOrigin, err = m.OriginFactory.NewTransHandler(opt)
-	} else {
-		kitexOrigin, err = detection.NewSvrTransHandlerFactory(netpoll.NewSvrTransHandlerFactory(), nphttp2.NewSvrTransHandlerFactory()).NewTransHandler(opt)
-	}
-	if err != nil {
-		return nil, err
-	}
-	return &transHandler{ServerTransHandler: kitexOrigin}, nil
+// MixTransHandlerFactory creates the transport handlers of the Kitex server, which serve the HTTP requests
+// of its connections with the swagger server, and the other requests with the handlers of OriginFactory,
+// or of Kitex if nil. The swagger server is started with them, unless it is disabled by Access.
+type MixTransHandlerFactory struct {
+	OriginFactory remote.ServerTransHandlerFactory
 }
 
-func (t *transHandler) OnRead(ctx context.Context, conn net.Conn) error {
-	c, ok := conn.(network.Conn)
-	if ok {
-		pre, _ := c.Peek(4)
-		if httpReg.Match(pre) {
-			klog.Info("using Hertz to process request")
-			err := hertzEngine.Serve(ctx, c)
-			if err != nil {
-				err = errors.New(fmt.Sprintf("HERTZ: %s", err.Error()))
-			}
-			return err
-		}
-	}
-
-	return t.ServerTransHandler.OnRead(ctx, conn)
+func (m MixTransHandlerFactory) NewTransHandler(opt *remote.ServerOption) (remote.ServerTransHandler, error) {
+	return proxy.NewTransHandler(m.OriginFactory, opt)
 }
 
+// StartServer starts the swagger server once, listening on ProxyOptions.Addr if set, and serving the HTTP
+// requests of the connections of the Kitex server otherwise. It is called when the Kitex server starts.
 func StartServer() {
-	h := server.Default()
-	h.Use(cors.Default())
-
-	cli := initializeGenericClient()
-	setupSwaggerRoutes(h)
-	setupProxyRoutes(h, cli)
-
-	hlog.Info("Swagger UI is available at: http://" + kitexAddr + "/swagger/index.html")
-	err := h.Engine.Init()
-	if err != nil {
-		panic(err)
-	}
-
-	hertzEngine = h.Engine
+	proxy.Start()
 }
 
-func findThriftFile(fileName string) (string, error) {
-	workingDir, err := os.Getwd()
-	if err != nil {
-		return "", err
-	}
-
-	foundPath := ""
-	relativePath := fileName
-
-	err = filepath.Walk(workingDir, func(path string, info os.FileInfo, err error) error {
-		if err != nil {
-			return err
-		}
-
-		if !info.IsDir() {
-			relative, err := filepath.Rel(workingDir, path)
-			if err != nil {
-				return err
-			}
-
-			if relative == relativePath {
-				foundPath = path
-				return filepath.SkipDir
-			}
-		}
-		return nil
-	})
-
-	if err == nil && foundPath != "" {
-		return foundPath, nil
-	}
-
-	parentDir := filepath.Dir(workingDir)
-	for parentDir != "/" && parentDir != "." && parentDir != workingDir {
-		filePath := filepath.Join(parentDir, fileName)
-		if _, err := os.Stat(filePath); err == nil {
-			return filePath, nil
-		}
-		workingDir = parentDir
-		parentDir = filepath.Dir(parentDir)
-	}
-
-	return "", errors.New("thrift file not found: " + fileName)
+// StopServer stops the swagger server and closes the generic clients of the proxy once its calls in flight
+// are done, the new ones being rejected. It is called when the Kitex server shuts down, and the swagger server
+// is not started again.
+func StopServer(ctx context.Context) error {
+	return proxy.Stop(ctx)
 }
 
-func initializeGenericClient() genericclient.Client {
-	thriftFile, err := findThriftFile(idlFile)
-	if err != nil {
-		hlog.Fatal("Failed to locate Thrift file:", err)
-	}
-
-	p, err := generic.NewThriftFileProviderWithDynamicGo(thriftFile)
-	if err != nil {
-		hlog.Fatal("Failed to create ThriftFileProvider:", err)
-	}
-
-	g, err := generic.JSONThriftGeneric(p)
-	if err != nil {
-		hlog.Fatal("Failed to create HTTPThriftGeneric:", err)
-	}
-	var opts []client.Option
-	opts = append(opts, client.WithTransportProtocol(transport.TTHeader))
-	opts = append(opts, client.WithMetaHandler(transmeta.ClientTTHeaderHandler))
-	opts = append(opts, client.WithHostPorts(kitexAddr))
-	cli, err := genericclient.NewClient("swagger", g, opts...)
-	if err != nil {
-		hlog.Fatal("Failed to create generic client:", err)
-	}
-
-	return cli
+// DetectHTTP1 detects the HTTP/1.x requests by their method followed by a space, the Detector of ProxyOptions
+// by default.
+func DetectHTTP1(conn network.Conn) bool {
+	return swaggerruntime.DetectHTTP1(conn)
 }
 
-func setupSwaggerRoutes(h *server.Hertz) {
-	h.GET("swagger/*any", swagger.WrapHandler(swaggerFiles.Handler, swagger.URL("/openapi.yaml")))
-
-	h.GET("/openapi.yaml", func(c context.Context, ctx *app.RequestContext) {
-		ctx.Header("Content-Type", "application/x-yaml")
-		ctx.Write(openapiYAML)
-	})
-}
-
-func setupProxyRoutes(h *server.Hertz, cli genericclient.Client) {
-	h.Any("/*ServiceMethod", func(c context.Context, ctx *app.RequestContext) {
-		serviceMethod := ctx.Param("ServiceMethod")
-		if serviceMethod == "" {
-			handleError(ctx, "ServiceMethod not provided", http.StatusBadRequest)
-			return
-		}
-
-		bodyBytes := ctx.Request.Body()
-
-		queryMap := formatQueryParams(ctx)
-
-		for k, v := range queryMap {
-			if strings.HasPrefix(k, "p_") {
-				c = metainfo.WithPersistentValue(c, k, v)
-			} else {
-				c = metainfo.WithValue(c, k, v)
-			}
-		}
+// AccessOptions control the access to the swagger endpoints, and to the proxy of the RPC servers.
+type AccessOptions = swaggerruntime.AccessOptions
 
-		c = metainfo.WithBackwardValues(c)
+// Access are the access options of the swagger endpoints, from the SWAGGER_ENABLED, SWAGGER_USERNAME,
+// SWAGGER_PASSWORD, SWAGGER_TOKEN, SWAGGER_ALLOWED_IPS and SWAGGER_CORS_ORIGINS environment variables,
+// the lists separated by commas. They are changed before the endpoints are bound.
+var Access = swaggerruntime.LoadAccessOptions()
 
-		jReq := string(bodyBytes)
+// UI is a UI of the documents served by the swagger endpoints.
+type UI = swaggerruntime.UI
 
-		jRsp, err := cli.GenericCall(c, serviceMethod, jReq)
-		if err != nil {
-			hlog.Errorf("GenericCall error: %v", err)
-			ctx.JSON(500, map[string]interface{}{
-				"error": err.Error(),
-			})
-			return
-		}
-
-		result := make(map[string]interface{})
-		if err := json.Unmarshal([]byte(jRsp.(string)), &result); err != nil {
-			hlog.Errorf("Failed to unmarshal response body: %v", err)
-			ctx.JSON(500, map[string]interface{}{
-				"error": "Failed to unmarshal response body",
-			})
-			return
-		}
-
-		m := metainfo.RecvAllBackwardValues(c)
-
-		for key, value := range m {
-			result[key] = value
-		}
-
-		respBody, err := json.Marshal(result)
-		if err != nil {
-			hlog.Errorf("Failed to marshal response body: %v", err)
-			ctx.JSON(500, map[string]interface{}{
-				"error": "Failed to marshal response body",
-			})
-			return
-		}
-
-		ctx.Data(http.StatusOK, "application/json", respBody)
+const (
+	SwaggerUI = swaggerruntime.SwaggerUI
+	Redoc     = swaggerruntime.Redoc
+	RapiDoc   = swaggerruntime.RapiDoc
+	Scalar    = swaggerruntime.Scalar
+)
 
-	})
-}
+// Document is an OpenAPI document served by the swagger endpoints besides the generated ones, e.g. the document
+// of another package, listed by its name in the document selector of the UI.
+type Document = swaggerruntime.Document
 
-func formatQueryParams(ctx *app.RequestContext) map[string]string {
-	var QueryParams = make(map[string]string)
-	ctx.Request.URI().QueryArgs().VisitAll(func(key, value []byte) {
-		QueryParams[string(key)] = string(value)
-	})
-	return QueryParams
-}
+// SwaggerOptions are the options of the swagger endpoints.
+type SwaggerOptions = swaggerruntime.SwaggerOptions
 
-func handleError(ctx *app.RequestContext, errMsg string, statusCode int) {
-	hlog.Errorf("Error: %s", errMsg)
-	ctx.JSON(statusCode, map[string]interface{}{
-		"error": errMsg,
-	})
-}
+// Swagger are the options of the swagger endpoints, from the SWAGGER_PREFIX, SWAGGER_UI, SWAGGER_REWRITE_SERVERS,
+// SWAGGER_BASE_PATH, SWAGGER_TRUSTED_PROXIES and SWAGGER_CDN environment variables, the lists separated by commas.
+// They are changed before the endpoints are bound.
+var Swagger = swaggerruntime.LoadSwaggerOptions()
@@ -0,0 +1,109 @@
+/*
+ * Copyright 2024 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package generator
+
+import (
+	"github.com/cloudwego/thriftgo/thrift_reflection"
+	"github.com/hertz-contrib/swagger-generate/common/consts"
+	common "github.com/hertz-contrib/swagger-generate/common/utils"
+	openapi "github.com/hertz-contrib/swagger-generate/idl/thrift"
+)
+
+// requestBase is the Base field of the requests of a method, with the thrift types of the scalar fields of the Base.
+type requestBase struct {
+	field string
+	keys  map[string]string
+}
+
+// recordRequestBase records the Base of the requests of a method, filled by the proxy, if they have one.
+func (g *OpenAPIGenerator) recordRequestBase(service, method string, desc *thrift_reflection.StructDescriptor) {
+	field, scalars := g.base.BaseFields(desc)
+	if field == nil {
+		return
+	}
+	base := &requestBase{field: field.GetName(), keys: make(map[string]string, len(scalars))}
+	for _, f := range scalars {
+		base.keys[f.GetName()] = f.GetType().GetName()
+	}
+	g.requestBases[service+"."+method] = base
+}
+
+// baseParameters returns the header parameters of the scalar fields of the Base of a request in the header mode.
+func (g *OpenAPIGenerator) baseParameters(desc *thrift_reflection.StructDescriptor) []*openapi.ParameterOrReference {
+	if g.base.BaseMode() != consts.BaseModeHeader {
+		return nil
+	}
+	_, scalars := g.base.BaseFields(desc)
+
+	var parameters []*openapi.ParameterOrReference
+	for _, f := range scalars {
+		parameters = append(parameters, &openapi.ParameterOrReference{
+			Parameter: &openapi.Parameter{
+				Name:        f.GetName(),
+				In:          consts.ParameterInHeader,
+				Description: common.ParseComment(f.Comments).Text,
+				Schema:      g.schemaOrReferenceForField(f.Type),
+			},
+		})
+	}
+	return parameters
+}
+
+// envelopeSchema returns the schema of the content of a response with a BaseResp field in the envelope mode:
+// the envelope shared by the responses, combined with the schema of the response without its BaseResp if ref is set.
+// Otherwise it returns the reference to the schema of the response, or nil if ref is empty.
+func (g *OpenAPIGenerator) envelopeSchema(d *openapi.Document, desc *thrift_reflection.StructDescriptor, ref string) *openapi.SchemaOrReference {
+	var schema *openapi.SchemaOrReference
+	if ref != "" {
+		schema = &openapi.SchemaOrReference{Reference: &openapi.Reference{Xref: ref}}
+	}
+	if g.base.BaseRespMode() != consts.BaseRespModeEnvelope {
+		return schema
+	}
+	for _, field := range desc.GetFields() {
+		if !g.base.IsBaseResp(field) {
+			continue
+		}
+		name := g.base.BaseRespStruct() + consts.ComponentSchemaSuffixEnvelope
+		g.addSchemaToDocument(d, &openapi.NamedSchemaOrReference{
+			Name: name,
+			Value: &openapi.SchemaOrReference{Schema: &openapi.Schema{
+				Type:        consts.SchemaObjectType,
+				Description: consts.BaseRespEnvelopeDesc,
+				Properties: &openapi.Properties{AdditionalProperties: []*openapi.NamedSchemaOrReference{{
+					Name:  field.GetName(),
+					Value: g.schemaOrReferenceForField(field.Type),
+				}}},
+			}},
+		})
+		envelope := &openapi.SchemaOrReference{Reference: &openapi.Reference{Xref: consts.ComponentSchemaPrefix + name}}
+		if schema == nil {
+			return envelope
+		}
+		return &openapi.SchemaOrReference{Schema: &openapi.Schema{AllOf: []*openapi.SchemaOrReference{envelope, schema}}}
+	}
+	return schema
+}
+
+// RequestBase returns the name of the Base field of the requests of a method and the thrift types of its scalar
+// fields, filled by the proxy of the swagger server, or nothing if its requests have no Base.
+func (g *OpenAPIGenerator) RequestBase(service, method string) (string, map[string]string) {
+	if base, ok := g.requestBases[service+"."+method]; ok {
+		return base.field, base.keys
+	}
+	return "", nil
+}
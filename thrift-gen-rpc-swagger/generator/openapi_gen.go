@@ -34,12 +34,13 @@
 package generator
 
 import (
+	"fmt"
 	"path/filepath"
 	"regexp"
 	"sort"
+	"strconv"
 	"strings"
 
-	"github.com/cloudwego/hertz/cmd/hz/util/logs"
 	"github.com/cloudwego/thriftgo/parser"
 	"github.com/cloudwego/thriftgo/plugin"
 	"github.com/cloudwego/thriftgo/thrift_reflection"
@@ -48,27 +49,48 @@ import (
 	openapi "github.com/hertz-contrib/swagger-generate/idl/thrift"
 	"github.com/hertz-contrib/swagger-generate/thrift-gen-rpc-swagger/args"
 	"github.com/hertz-contrib/swagger-generate/thrift-gen-rpc-swagger/utils"
+	"gopkg.in/yaml.v3"
 )
 
 type OpenAPIGenerator struct {
-	fileDesc         *thrift_reflection.FileDescriptor
-	ast              *parser.Thrift
-	generatedSchemas []string
-	requiredSchemas  []string
-	requiredTypeDesc []*thrift_reflection.StructDescriptor
+	fileDesc           *thrift_reflection.FileDescriptor
+	descs              *common.Descriptors
+	ast                *parser.Thrift
+	servicePrefix      bool
+	generatedSchemas   []string
+	requiredSchemas    []string
+	requiredTypeDesc   []*thrift_reflection.StructDescriptor
+	operationLocations map[string]string // IDL locations of the operations already added, keyed by route.
+	sources            *common.ThriftSources
+	lintLocations      common.LintLocations // IDL locations of the elements of the document, keyed by JSON pointer.
+	lintIssues         []*common.LintIssue  // Issues found in the IDL, reported with the issues of the document.
+	diagnostics        []*common.Diagnostic
+	excludeDeprecated  bool
+	base               *common.BaseConventions
+	filter             *common.Filter
+	metaKeys           *common.MetaKeys
+	requestBases       map[string]*requestBase                     // Base of the requests of the methods, keyed by Service.Method.
+	fieldVisibilities  map[*thrift_reflection.FieldDescriptor]bool // Visibilities of the fields already validated.
+	document           *yaml.Node                                  // Final document, from which the routes of the proxy are built.
 }
 
 // NewOpenAPIGenerator creates a new generator for a protoc plugin invocation.
 func NewOpenAPIGenerator(ast *parser.Thrift) *OpenAPIGenerator {
-	_, fileDesc := thrift_reflection.RegisterAST(ast)
+	descs, fileDesc := common.NewDescriptors(ast)
 	return &OpenAPIGenerator{
-		fileDesc:         fileDesc,
-		ast:              ast,
-		generatedSchemas: make([]string, 0),
+		fileDesc:           fileDesc,
+		descs:              descs,
+		ast:                ast,
+		generatedSchemas:   make([]string, 0),
+		operationLocations: make(map[string]string),
+		requestBases:       make(map[string]*requestBase),
+		sources:            common.NewThriftSources(),
+		lintLocations:      make(common.LintLocations),
+		fieldVisibilities:  make(map[*thrift_reflection.FieldDescriptor]bool),
 	}
 }
 
-func (g *OpenAPIGenerator) BuildDocument(arguments *args.Arguments) []*plugin.Generated {
+func (g *OpenAPIGenerator) BuildDocument(arguments *args.Arguments) ([]*plugin.Generated, error) {
 	d := &openapi.Document{}
 
 	version := consts.OpenAPIVersion
@@ -88,17 +110,38 @@ func (g *OpenAPIGenerator) BuildDocument(arguments *args.Arguments) []*plugin.Ge
 	var extDocument *openapi.Document
 	err := g.getDocumentOption(&extDocument)
 	if err != nil {
-		logs.Errorf("Error getting document option: %s", err)
-		return nil
+		return nil, fmt.Errorf("error getting document option: %s", err)
 	}
 	if extDocument != nil {
+		// The default info is only a placeholder, the info of the annotation replaces it
+		if extDocument.Info != nil {
+			d.Info = &openapi.Info{}
+		}
 		err := common.MergeStructs(d, extDocument)
 		if err != nil {
-			logs.Errorf("Error merging document option: %s", err)
-			return nil
+			return nil, fmt.Errorf("error merging document option: %s", err)
 		}
 	}
 
+	g.servicePrefix = arguments.ServicePrefix
+	if err = common.CheckIdlName(arguments.IdlName); err != nil {
+		return nil, err
+	}
+	if err = common.CheckLintRules(arguments.LintDisable); err != nil {
+		return nil, err
+	}
+
+	if g.base, err = common.NewBaseConventions(g.descs, arguments.BaseStruct, arguments.BaseRespStruct, arguments.BaseFieldID, arguments.BaseMode, arguments.BaseRespMode); err != nil {
+		return nil, err
+	}
+	if g.filter, err = common.NewFilter(arguments.Include, arguments.Exclude, arguments.Visibility); err != nil {
+		return nil, err
+	}
+	if g.metaKeys, err = common.NewMetaKeys(arguments.MetaKeys, arguments.PersistentKeys, arguments.BackwardKeys); err != nil {
+		return nil, err
+	}
+
+	g.excludeDeprecated = arguments.ExcludeDeprecated
 	g.addPathsToDocument(d, g.fileDesc.GetServices())
 
 	for len(g.requiredSchemas) > 0 {
@@ -138,12 +181,24 @@ func (g *OpenAPIGenerator) BuildDocument(arguments *args.Arguments) []*plugin.Ge
 		}
 	}
 
-	// Set all servers on API level
+	// Set all servers on API level, merged with the servers of the document annotation
 	if len(allServers) > 0 {
-		d.Servers = []*openapi.Server{}
+		servers := []*openapi.Server{}
 		for _, server := range allServers {
-			d.Servers = append(d.Servers, &openapi.Server{URL: server})
+			s := &openapi.Server{URL: server}
+			for _, annotated := range d.Servers {
+				if annotated.URL == server {
+					s = annotated
+				}
+			}
+			servers = append(servers, s)
 		}
+		for _, annotated := range d.Servers {
+			if !common.Contains(allServers, annotated.URL) {
+				servers = append(servers, annotated)
+			}
+		}
+		d.Servers = servers
 	}
 
 	// If there is only 1 server, we can safely remove all path level servers
@@ -154,7 +209,7 @@ func (g *OpenAPIGenerator) BuildDocument(arguments *args.Arguments) []*plugin.Ge
 	}
 
 	// If there are no servers, add a default one
-	if len(allServers) == 0 {
+	if len(d.Servers) == 0 {
 		d.Servers = []*openapi.Server{
 			{URL: consts.DefaultServerURL},
 		}
@@ -184,36 +239,65 @@ func (g *OpenAPIGenerator) BuildDocument(arguments *args.Arguments) []*plugin.Ge
 		d.Components.Schemas.AdditionalProperties = pairs
 	}
 
-	bytes, err := d.YAMLValue("Generated with " + consts.PluginNameThriftRpcSwagger + "\n" + consts.InfoURL + consts.PluginNameThriftRpcSwagger)
+	rawInfo := d.ToRawInfo()
+	if g.filter.Active() {
+		common.PruneSchemas(rawInfo)
+	}
+	var examples map[string]*common.OperationExamples
+	if arguments.ExamplesFile != "" {
+		examples, err = common.LoadOperationExamples(arguments.ExamplesFile)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if err = common.AddExamples(rawInfo, examples, !arguments.DisableExamples); err != nil {
+		g.warnf(g.fileDesc.Filepath, common.DiagnosticInvalidExample, "%s", err)
+	}
+	if err = common.HoistComponents(rawInfo, arguments.HoistComponents); err != nil {
+		g.warnf(g.fileDesc.Filepath, common.DiagnosticConflictingComponent, "%s", err)
+	}
+	if arguments.Overlay != "" {
+		g.diagnostics = append(g.diagnostics, common.ApplyOverlay(rawInfo, arguments.Overlay)...)
+	}
+	if arguments.Lint {
+		g.lint(rawInfo, arguments.LintDisable)
+	}
+
+	if err = common.CheckDiagnostics(g.diagnostics, arguments.Strict, arguments.WarningsAsErrors); err != nil {
+		return nil, err
+	}
+	g.document = rawInfo
+
+	bytes, err := common.MarshalDocument(rawInfo, "Generated with "+consts.PluginNameThriftRpcSwagger+"\n"+consts.InfoURL+consts.PluginNameThriftRpcSwagger)
 	if err != nil {
-		logs.Errorf("Error converting to yaml: %s", err)
-		return nil
+		return nil, fmt.Errorf("error converting to yaml: %s", err)
 	}
 	outputDir := arguments.OutputDir
 	if outputDir == "" {
 		outputDir = consts.DefaultOutputDir
 	}
-	filePath := filepath.Join(outputDir, consts.DefaultOutputYamlFile)
+	documentFile, _ := common.IdlOutputFiles(arguments.IdlName)
+	filePath := filepath.Join(outputDir, documentFile)
 	var ret []*plugin.Generated
 	ret = append(ret, &plugin.Generated{
 		Content: string(bytes),
 		Name:    &filePath,
 	})
 
-	return ret
+	return ret, nil
 }
 
 func (g *OpenAPIGenerator) getDocumentOption(obj interface{}) error {
 	serviceOrStruct, name := g.getDocumentAnnotationInWhichServiceOrStruct()
 	if serviceOrStruct == consts.DocumentOptionServiceType {
 		serviceDesc := g.fileDesc.GetServiceDescriptor(name)
-		err := utils.ParseServiceOption(serviceDesc, consts.OpenapiDocument, obj)
+		err := utils.ParseServiceOption(g.descs, serviceDesc, consts.OpenapiDocument, obj)
 		if err != nil {
 			return err
 		}
 	} else if serviceOrStruct == consts.DocumentOptionStructType {
 		structDesc := g.fileDesc.GetStructDescriptor(name)
-		err := utils.ParseStructOption(structDesc, consts.OpenapiDocument, obj)
+		err := utils.ParseStructOption(g.descs, structDesc, consts.OpenapiDocument, obj)
 		if err != nil {
 			return err
 		}
@@ -224,39 +308,31 @@ func (g *OpenAPIGenerator) getDocumentOption(obj interface{}) error {
 func (g *OpenAPIGenerator) addPathsToDocument(d *openapi.Document, services []*thrift_reflection.ServiceDescriptor) {
 	var err error
 	for _, s := range services {
+		if !g.visible(s.Annotations, g.sources.Location(s.Filepath, s.GetName(), "")) {
+			continue
+		}
 		annotationsCount := 0
 		for _, m := range s.GetMethods() {
 			var inputDesc, outputDesc, throwDesc *thrift_reflection.StructDescriptor
 
 			if len(m.Args) > 0 {
 				if len(m.Args) > 1 {
-					logs.Warnf("function '%s' has more than one argument, but only the first can be used in plugin now", m.GetName())
+					g.warnf(g.methodLocation(s, m), common.DiagnosticIgnoredArgument, "function '%s' has more than one argument, but only the first can be used in plugin now", m.GetName())
 				}
 				// TODO: support more argument types
-				if m.Args[0].GetType().IsStruct() {
-					inputDesc, err = m.Args[0].GetType().GetStructDescriptor()
-					if err != nil {
-						logs.Errorf("Error getting arguments descriptor: %s", err)
-					}
-				} else {
-					logs.Errorf("now only support struct type for input, but got %s", m.Args[0].GetType().GetName())
+				if inputDesc = g.descs.Struct(m.Args[0].GetType()); inputDesc == nil {
+					g.errorf(g.methodLocation(s, m), common.DiagnosticUnsupportedType, "now only support struct type for input, but got %s", m.Args[0].GetType().GetName())
 				}
 			}
 
 			// TODO: support more response types
-			if m.Response.IsStruct() {
-				outputDesc, err = m.Response.GetStructDescriptor()
-				if err != nil {
-					logs.Errorf("Error getting response descriptor: %s", err)
-				}
-			} else if m.Response.Name != "void" {
-				logs.Errorf("now only support struct type for output, but got %s", m.Response.Name)
+			if outputDesc = g.descs.Struct(m.Response); outputDesc == nil && m.Response.Name != "void" {
+				g.errorf(g.methodLocation(s, m), common.DiagnosticUnsupportedType, "now only support struct type for output, but got %s", m.Response.Name)
 			}
 
 			if len(m.ThrowExceptions) > 0 {
-				throwDesc, err = m.ThrowExceptions[0].GetType().GetExceptionDescriptor()
-				if err != nil {
-					logs.Errorf("Error getting exception descriptor: %s", err)
+				if throwDesc = g.descs.Exception(m.ThrowExceptions[0].GetType()); throwDesc == nil {
+					g.errorf(g.methodLocation(s, m), common.DiagnosticUnresolvedType, "exception %s is not found", m.ThrowExceptions[0].GetType().GetName())
 				}
 			}
 			var host string
@@ -267,28 +343,53 @@ func (g *OpenAPIGenerator) addPathsToDocument(d *openapi.Document, services []*t
 				host = domains[0]
 			}
 
+			newOp := &openapi.Operation{}
+			err = utils.ParseMethodOption(g.descs, m, consts.OpenapiOperation, &newOp)
+			if err != nil {
+				g.errorf(g.methodLocation(s, m), common.DiagnosticInvalidOption, "Error parsing method option: %s", err)
+			}
+
+			methodComment := common.ParseComment(m.Comments)
+			if methodComment.Internal || !g.filter.IncludesMethod(s.GetName(), m.GetName()) || !g.visible(m.Annotations, g.methodLocation(s, m)) {
+				continue
+			}
+			deprecated, reason := g.getDeprecation(m.Annotations, m.Comments)
+			if g.excludeDeprecated && (deprecated || newOp.Deprecated) {
+				continue
+			}
+
 			annotationsCount++
 			operationID := s.GetName() + "_" + m.GetName()
 			path := "/" + m.GetName()
-			comment := g.filterCommentString(m.Comments)
-
-			op, path2 := g.buildOperation(d, comment, operationID, s.GetName(), path, host, inputDesc, outputDesc, throwDesc)
+			if g.servicePrefix {
+				path = "/" + s.GetName() + path
+			}
 
-			newOp := &openapi.Operation{}
-			err = utils.ParseMethodOption(m, consts.OpenapiOperation, &newOp)
-			if err != nil {
-				logs.Errorf("Error parsing method option: %s", err)
+			if inputDesc != nil {
+				g.recordRequestBase(s.GetName(), m.GetName(), inputDesc)
 			}
+			op, path2 := g.buildOperation(d, methodComment.Description, operationID, s.GetName(), path, host, inputDesc, outputDesc, throwDesc)
+			op.Summary = methodComment.Summary
+			op.ExternalDocs = externalDocs(methodComment)
+
 			err = common.MergeStructs(op, newOp)
 			if err != nil {
-				logs.Errorf("Error merging method option: %s", err)
+				g.errorf(g.methodLocation(s, m), common.DiagnosticInvalidOption, "Error merging method option: %s", err)
+			}
+			if deprecated {
+				op.Deprecated = true
+				op.SpecificationExtension = append(op.SpecificationExtension, deprecatedReasonExtension(reason)...)
+			}
+			if g.safe(m.Annotations, g.methodLocation(s, m)) {
+				op.SpecificationExtension = append(op.SpecificationExtension, safeExtension())
 			}
 
-			g.addOperationToDocument(d, op, path2)
+			g.addOperationToDocument(d, op, path2, g.methodLocation(s, m))
+			g.addUndocumentedExceptions(m, consts.HttpMethodPost, path2)
 		}
 		if annotationsCount > 0 {
-			comment := g.filterCommentString(s.Comments)
-			d.Tags = append(d.Tags, &openapi.Tag{Name: s.GetName(), Description: comment})
+			serviceComment := common.ParseComment(s.Comments)
+			d.Tags = append(d.Tags, &openapi.Tag{Name: s.GetName(), Description: serviceComment.Text, ExternalDocs: externalDocs(serviceComment)})
 		}
 	}
 }
@@ -307,21 +408,20 @@ func (g *OpenAPIGenerator) buildOperation(
 	// Parameters array to hold all parameter objects
 	var parameters []*openapi.ParameterOrReference
 
-	fieldSchema := &openapi.SchemaOrReference{
-		Schema: &openapi.Schema{
-			Type: consts.SchemaObjectType,
-		},
+	for _, header := range g.metaKeys.RequestHeaders() {
+		parameters = append(parameters, &openapi.ParameterOrReference{
+			Parameter: &openapi.Parameter{
+				Name:        header.Name,
+				In:          consts.ParameterInHeader,
+				Description: header.Description,
+				Schema:      &openapi.SchemaOrReference{Schema: &openapi.Schema{Type: "string"}},
+			},
+		})
 	}
-	parameter := &openapi.Parameter{
-		Name:        consts.ParameterNameTTHeader,
-		In:          consts.ParameterInQuery,
-		Description: consts.ParameterDescription,
-		Required:    false,
-		Schema:      fieldSchema,
+
+	if inputDesc != nil {
+		parameters = append(parameters, g.baseParameters(inputDesc)...)
 	}
-	parameters = append(parameters, &openapi.ParameterOrReference{
-		Parameter: parameter,
-	})
 
 	var RequestBody *openapi.RequestBodyOrReference
 
@@ -352,7 +452,7 @@ func (g *OpenAPIGenerator) buildOperation(
 		if len(additionalProperties) > 0 {
 			RequestBody = &openapi.RequestBodyOrReference{
 				RequestBody: &openapi.RequestBody{
-					Description: g.filterCommentString(inputDesc.Comments),
+					Description: common.ParseComment(inputDesc.Comments).Text,
 					Content: &openapi.MediaTypes{
 						AdditionalProperties: additionalProperties,
 					},
@@ -371,7 +471,7 @@ func (g *OpenAPIGenerator) buildOperation(
 
 	if outputDesc != nil {
 		name, content := g.getResponseForStruct(d, outputDesc)
-		desc = g.filterCommentString(outputDesc.Comments)
+		desc = common.ParseComment(outputDesc.Comments).Text
 
 		if desc == "" {
 			desc = consts.DefaultResponseDesc
@@ -389,6 +489,7 @@ func (g *OpenAPIGenerator) buildOperation(
 						Value: &openapi.ResponseOrReference{
 							Response: &openapi.Response{
 								Description: desc,
+								Headers:     g.metaResponseHeaders(),
 								Content:     contentOrEmpty,
 							},
 						},
@@ -400,7 +501,7 @@ func (g *OpenAPIGenerator) buildOperation(
 
 	if throwDesc != nil {
 		exceptionName, exceptionContent := g.getExceptionForStruct(d, throwDesc)
-		exceptionDesc = g.filterCommentString(throwDesc.Comments)
+		exceptionDesc = common.ParseComment(throwDesc.Comments).Text
 
 		if exceptionDesc == "" {
 			exceptionDesc = consts.DefaultExceptionDesc
@@ -475,21 +576,22 @@ func (g *OpenAPIGenerator) getDocumentAnnotationInWhichServiceOrStruct() (string
 func (g *OpenAPIGenerator) getResponseForStruct(d *openapi.Document, desc *thrift_reflection.StructDescriptor) (string, *openapi.MediaTypes) {
 	bodySchema := g.getSchemaByOption(desc)
 
-	var additionalProperties []*openapi.NamedMediaType
-
+	var ref string
 	if len(bodySchema.Properties.AdditionalProperties) > 0 {
 		refSchema := &openapi.NamedSchemaOrReference{
 			Name:  desc.GetName(),
 			Value: &openapi.SchemaOrReference{Schema: bodySchema},
 		}
-		ref := consts.ComponentSchemaPrefix + desc.GetName()
+		ref = consts.ComponentSchemaPrefix + desc.GetName()
 		g.addSchemaToDocument(d, refSchema)
+	}
+
+	var additionalProperties []*openapi.NamedMediaType
+	if schema := g.envelopeSchema(d, desc, ref); schema != nil {
 		additionalProperties = append(additionalProperties, &openapi.NamedMediaType{
 			Name: consts.ContentTypeJSON,
 			Value: &openapi.MediaType{
-				Schema: &openapi.SchemaOrReference{
-					Reference: &openapi.Reference{Xref: ref},
-				},
+				Schema: schema,
 			},
 		})
 	}
@@ -537,9 +639,9 @@ func (g *OpenAPIGenerator) getSchemaByOption(inputDesc *thrift_reflection.Struct
 
 	var allRequired []string
 	var extSchema *openapi.Schema
-	err := utils.ParseStructOption(inputDesc, consts.OpenapiSchema, &extSchema)
+	err := utils.ParseStructOption(g.descs, inputDesc, consts.OpenapiSchema, &extSchema)
 	if err != nil {
-		logs.Errorf("Error parsing struct option: %s", err)
+		g.errorf(g.sources.Location(inputDesc.Filepath, inputDesc.GetName(), ""), common.DiagnosticInvalidOption, "Error parsing struct option: %s", err)
 	}
 	if extSchema != nil {
 		if extSchema.Required != nil {
@@ -549,6 +651,11 @@ func (g *OpenAPIGenerator) getSchemaByOption(inputDesc *thrift_reflection.Struct
 
 	var required []string
 	for _, field := range inputDesc.GetFields() {
+		fieldComment := common.ParseComment(field.Comments)
+		if fieldComment.Internal || g.base.Skip(field) || !g.visibleField(inputDesc, field) {
+			continue
+		}
+
 		extName := field.GetName()
 
 		if common.Contains(allRequired, extName) {
@@ -556,7 +663,7 @@ func (g *OpenAPIGenerator) getSchemaByOption(inputDesc *thrift_reflection.Struct
 		}
 
 		// Get the field description from the comments.
-		description := g.filterCommentString(field.Comments)
+		description := fieldComment.Text
 		fieldSchema := g.schemaOrReferenceForField(field.Type)
 		if fieldSchema == nil {
 			continue
@@ -564,17 +671,23 @@ func (g *OpenAPIGenerator) getSchemaByOption(inputDesc *thrift_reflection.Struct
 
 		if fieldSchema.IsSetSchema() {
 			fieldSchema.Schema.Description = description
+			fieldSchema.Schema.Example = example(fieldComment)
+			fieldSchema.Schema.ExternalDocs = externalDocs(fieldComment)
 			newFieldSchema := &openapi.Schema{}
-			err := utils.ParseFieldOption(field, consts.OpenapiProperty, &newFieldSchema)
+			err := utils.ParseFieldOption(g.descs, field, consts.OpenapiProperty, &newFieldSchema)
 			if err != nil {
-				logs.Errorf("Error parsing field option: %s", err)
+				g.errorf(g.sources.Location(inputDesc.Filepath, inputDesc.GetName(), field.GetName()), common.DiagnosticInvalidOption, "Error parsing field option: %s", err)
 			}
 			err = common.MergeStructs(fieldSchema.Schema, newFieldSchema)
 			if err != nil {
-				logs.Errorf("Error merging field option: %s", err)
+				g.errorf(g.sources.Location(inputDesc.Filepath, inputDesc.GetName(), field.GetName()), common.DiagnosticInvalidOption, "Error merging field option: %s", err)
 			}
 		}
 
+		if deprecated, reason := g.getDeprecation(field.Annotations, field.Comments); deprecated {
+			fieldSchema = deprecateSchema(fieldSchema, reason)
+		}
+
 		definitionProperties.AdditionalProperties = append(
 			definitionProperties.AdditionalProperties,
 			&openapi.NamedSchemaOrReference{
@@ -582,7 +695,9 @@ func (g *OpenAPIGenerator) getSchemaByOption(inputDesc *thrift_reflection.Struct
 				Value: fieldSchema,
 			},
 		)
+		g.lintLocations[common.SchemaPointer(inputDesc.GetName(), extName)] = g.sources.Location(inputDesc.Filepath, inputDesc.GetName(), field.GetName())
 	}
+	g.lintLocations[common.SchemaPointer(inputDesc.GetName())] = g.sources.Location(inputDesc.Filepath, inputDesc.GetName(), "")
 
 	schema := &openapi.Schema{
 		Type:       consts.SchemaObjectType,
@@ -592,54 +707,17 @@ func (g *OpenAPIGenerator) getSchemaByOption(inputDesc *thrift_reflection.Struct
 	if extSchema != nil {
 		err := common.MergeStructs(schema, extSchema)
 		if err != nil {
-			logs.Errorf("Error merging struct option: %s", err)
+			g.errorf(g.sources.Location(inputDesc.Filepath, inputDesc.GetName(), ""), common.DiagnosticInvalidOption, "Error merging struct option: %s", err)
 		}
 	}
 
-	schema.Required = required
-	return schema
-}
-
-// filterCommentString removes linter rules from comments.
-func (g *OpenAPIGenerator) filterCommentString(str string) string {
-	var comments []string
-	matches := regexp.MustCompile(consts.CommentPatternRegexp).FindAllStringSubmatch(str, -1)
-
-	for _, match := range matches {
-		if match[1] != "" {
-			// Handle one-line comments
-			comments = append(comments, strings.TrimSpace(match[1]))
-		} else if match[2] != "" {
-			// Handle multiline comments
-			multiLineComment := match[2]
-			lines := strings.Split(multiLineComment, "\n")
-
-			// Find the minimum indentation level (excluding empty lines)
-			minIndent := -1
-			for _, line := range lines {
-				trimmedLine := strings.TrimSpace(line)
-				if trimmedLine != "" {
-					lineIndent := len(line) - len(strings.TrimLeft(line, " "))
-					if minIndent == -1 || lineIndent < minIndent {
-						minIndent = lineIndent
-					}
-				}
-			}
-
-			// Remove the minimum indentation and any leading '*' from each line
-			for i, line := range lines {
-				if minIndent > 0 && len(line) >= minIndent {
-					line = line[minIndent:]
-				}
-				lines[i] = strings.TrimPrefix(line, "*")
-			}
-
-			// Remove leading and trailing empty lines from the comment block
-			comments = append(comments, strings.TrimSpace(strings.Join(lines, "\n")))
-		}
+	if deprecated, reason := g.getDeprecation(inputDesc.Annotations, inputDesc.Comments); deprecated {
+		schema.Deprecated = true
+		schema.SpecificationExtension = append(schema.SpecificationExtension, deprecatedReasonExtension(reason)...)
 	}
 
-	return strings.Join(comments, "\n")
+	schema.Required = required
+	return schema
 }
 
 func (g *OpenAPIGenerator) addSchemasForStructsToDocument(d *openapi.Document, structs []*thrift_reflection.StructDescriptor) {
@@ -648,11 +726,10 @@ func (g *OpenAPIGenerator) addSchemasForStructsToDocument(d *openapi.Document, s
 		for _, f := range s.GetFields() {
 			fieldType := f.GetType()
 			if fieldType == nil {
-				logs.Errorf("Warning: field type is nil for field: %s\n", f.GetName())
+				g.warnf(g.sources.Location(s.Filepath, s.GetName(), f.GetName()), common.DiagnosticUnresolvedType, "field type is nil for field %s", f.GetName())
 				continue
 			}
-			if fieldType.IsStruct() {
-				structDesc, _ := fieldType.GetStructDescriptor()
+			if structDesc := g.descs.Struct(fieldType); structDesc != nil {
 				sls = append(sls, structDesc)
 			}
 		}
@@ -669,7 +746,7 @@ func (g *OpenAPIGenerator) addSchemasForStructsToDocument(d *openapi.Document, s
 		}
 
 		// Get the description from the comments.
-		messageDescription := g.filterCommentString(s.Comments)
+		messageComment := common.ParseComment(s.Comments)
 
 		// Build an array holding the fields of the message.
 		definitionProperties := &openapi.Properties{
@@ -678,25 +755,34 @@ func (g *OpenAPIGenerator) addSchemasForStructsToDocument(d *openapi.Document, s
 
 		for _, field := range s.Fields {
 			// Get the field description from the comments.
-			description := g.filterCommentString(field.Comments)
+			fieldComment := common.ParseComment(field.Comments)
+			if fieldComment.Internal || g.base.Skip(field) || !g.visibleField(s, field) {
+				continue
+			}
 			fieldSchema := g.schemaOrReferenceForField(field.Type)
 			if fieldSchema == nil {
 				continue
 			}
 
 			if fieldSchema.IsSetSchema() {
-				fieldSchema.Schema.Description = description
+				fieldSchema.Schema.Description = fieldComment.Text
+				fieldSchema.Schema.Example = example(fieldComment)
+				fieldSchema.Schema.ExternalDocs = externalDocs(fieldComment)
 				newFieldSchema := &openapi.Schema{}
-				err := utils.ParseFieldOption(field, consts.OpenapiProperty, &newFieldSchema)
+				err := utils.ParseFieldOption(g.descs, field, consts.OpenapiProperty, &newFieldSchema)
 				if err != nil {
-					logs.Errorf("Error parsing field option: %s", err)
+					g.errorf(g.sources.Location(s.Filepath, s.GetName(), field.GetName()), common.DiagnosticInvalidOption, "Error parsing field option: %s", err)
 				}
 				err = common.MergeStructs(fieldSchema.Schema, newFieldSchema)
 				if err != nil {
-					logs.Errorf("Error merging field option: %s", err)
+					g.errorf(g.sources.Location(s.Filepath, s.GetName(), field.GetName()), common.DiagnosticInvalidOption, "Error merging field option: %s", err)
 				}
 			}
 
+			if deprecated, reason := g.getDeprecation(field.Annotations, field.Comments); deprecated {
+				fieldSchema = deprecateSchema(fieldSchema, reason)
+			}
+
 			fName := field.GetName()
 
 			definitionProperties.AdditionalProperties = append(
@@ -706,27 +792,36 @@ func (g *OpenAPIGenerator) addSchemasForStructsToDocument(d *openapi.Document, s
 					Value: fieldSchema,
 				},
 			)
+			g.lintLocations[common.SchemaPointer(schemaName, fName)] = g.sources.Location(s.Filepath, s.GetName(), field.GetName())
 		}
 
 		schema := &openapi.Schema{
-			Type:        consts.SchemaObjectType,
-			Description: messageDescription,
-			Properties:  definitionProperties,
+			Type:         consts.SchemaObjectType,
+			Description:  messageComment.Text,
+			Properties:   definitionProperties,
+			Example:      example(messageComment),
+			ExternalDocs: externalDocs(messageComment),
 		}
 
 		var extSchema *openapi.Schema
-		err := utils.ParseStructOption(s, consts.OpenapiSchema, &extSchema)
+		err := utils.ParseStructOption(g.descs, s, consts.OpenapiSchema, &extSchema)
 		if err != nil {
-			logs.Errorf("Error parsing struct option: %s", err)
+			g.errorf(g.sources.Location(s.Filepath, s.GetName(), ""), common.DiagnosticInvalidOption, "Error parsing struct option: %s", err)
 		}
 		if extSchema != nil {
 			err = common.MergeStructs(schema, extSchema)
 			if err != nil {
-				logs.Errorf("Error merging struct option: %s", err)
+				g.errorf(g.sources.Location(s.Filepath, s.GetName(), ""), common.DiagnosticInvalidOption, "Error merging struct option: %s", err)
 			}
 		}
 
+		if deprecated, reason := g.getDeprecation(s.Annotations, s.Comments); deprecated {
+			schema.Deprecated = true
+			schema.SpecificationExtension = append(schema.SpecificationExtension, deprecatedReasonExtension(reason)...)
+		}
+
 		// Add the schema to the components.schema list.
+		g.lintLocations[common.SchemaPointer(schemaName)] = g.sources.Location(s.Filepath, s.GetName(), "")
 		g.addSchemaToDocument(d, &openapi.NamedSchemaOrReference{
 			Name: schemaName,
 			Value: &openapi.SchemaOrReference{
@@ -745,7 +840,72 @@ func (g *OpenAPIGenerator) addSchemaToDocument(d *openapi.Document, schema *open
 	d.Components.Schemas.AdditionalProperties = append(d.Components.Schemas.AdditionalProperties, schema)
 }
 
-func (g *OpenAPIGenerator) addOperationToDocument(d *openapi.Document, op *openapi.Operation, path string) {
+// methodLocation describes where a method is declared in the IDL, used in diagnostics.
+func (g *OpenAPIGenerator) methodLocation(s *thrift_reflection.ServiceDescriptor, m *thrift_reflection.MethodDescriptor) string {
+	return fmt.Sprintf("%s (%s.%s)", g.sources.Location(s.Filepath, s.GetName(), m.GetName()), s.GetName(), m.GetName())
+}
+
+// addUndocumentedExceptions reports the exceptions of a method after the first one, which are not documented.
+func (g *OpenAPIGenerator) addUndocumentedExceptions(m *thrift_reflection.MethodDescriptor, methodName, path string) {
+	if len(m.ThrowExceptions) < 2 {
+		return
+	}
+	for _, e := range m.ThrowExceptions[1:] {
+		g.lintIssues = append(g.lintIssues, &common.LintIssue{
+			Rule:    common.LintDocumentedException,
+			Pointer: common.OperationPointer(methodName, path),
+			Message: fmt.Sprintf("exception %s of %s is not documented, only the first exception is", e.GetType().GetName(), m.GetName()),
+		})
+	}
+}
+
+// lint reports the issues of the IDL and of the document as warnings, at the IDL locations of their elements.
+func (g *OpenAPIGenerator) lint(document *yaml.Node, disabled []string) {
+	for _, issue := range append(g.lintIssues, common.LintDocument(document, disabled)...) {
+		if !common.Contains(disabled, issue.Rule) {
+			g.warnf(g.lintLocations.Lookup(issue.Pointer, g.fileDesc.Filepath), issue.Rule, "%s", issue.Message)
+		}
+	}
+}
+
+// warnf reports a problem that leaves the document usable.
+func (g *OpenAPIGenerator) warnf(location, code, format string, a ...interface{}) {
+	g.diagnostics = append(g.diagnostics, &common.Diagnostic{Severity: common.SeverityWarning, Code: code, Location: location, Message: fmt.Sprintf(format, a...)})
+}
+
+// errorf reports a problem that leaves an element out of the document, or documents it partially.
+func (g *OpenAPIGenerator) errorf(location, code, format string, a ...interface{}) {
+	g.diagnostics = append(g.diagnostics, &common.Diagnostic{Severity: common.SeverityError, Code: code, Location: location, Message: fmt.Sprintf(format, a...)})
+}
+
+// Diagnostics returns the problems found while building the document.
+func (g *OpenAPIGenerator) Diagnostics() []*common.Diagnostic {
+	return g.diagnostics
+}
+
+// Document returns the document built by the generator, or nil if it was not built.
+func (g *OpenAPIGenerator) Document() *yaml.Node {
+	return g.document
+}
+
+// addOperationToDocument adds an operation to the specified path.
+// If another method already declared the same route, the operation is dropped and the conflict is reported.
+func (g *OpenAPIGenerator) addOperationToDocument(d *openapi.Document, op *openapi.Operation, path, location string) {
+	key := common.OperationKey(consts.HttpMethodPost, path)
+	if existing, ok := g.operationLocations[key]; ok {
+		message := fmt.Sprintf("duplicate route %s %s, already declared by %s, only the first one is documented", consts.HttpMethodPost, path, existing)
+		if !g.servicePrefix {
+			message += ", consider enabling ServicePrefix"
+		}
+		g.errorf(location, common.DiagnosticConflictingOperation, "%s", message)
+		return
+	}
+	g.operationLocations[key] = location
+	g.lintLocations[common.OperationPointer(consts.HttpMethodPost, path)] = location
+	if _, ok := g.lintLocations[common.PathPointer(path)]; !ok {
+		g.lintLocations[common.PathPointer(path)] = location
+	}
+
 	var selectedPathItem *openapi.NamedPathItem
 	for _, namedPathItem := range d.Paths.Path {
 		if namedPathItem.Name == path {
@@ -774,13 +934,10 @@ func (g *OpenAPIGenerator) schemaReferenceForMessage(message *thrift_reflection.
 func (g *OpenAPIGenerator) schemaOrReferenceForField(fieldType *thrift_reflection.TypeDescriptor) *openapi.SchemaOrReference {
 	var kindSchema *openapi.SchemaOrReference
 
+	structDesc, unionDesc := g.descs.Struct(fieldType), g.descs.Union(fieldType)
+	typedefDesc, enumDesc := g.descs.Typedef(fieldType), g.descs.Enum(fieldType)
 	switch {
-	case fieldType.IsStruct():
-		structDesc, err := fieldType.GetStructDescriptor()
-		if err != nil {
-			logs.Errorf("Error getting struct descriptor: %s", err)
-			return nil
-		}
+	case structDesc != nil:
 		ref := g.schemaReferenceForMessage(structDesc)
 		kindSchema = &openapi.SchemaOrReference{
 			Reference: &openapi.Reference{Xref: ref},
@@ -807,34 +964,29 @@ func (g *OpenAPIGenerator) schemaOrReferenceForField(fieldType *thrift_reflectio
 				},
 			},
 		}
-	case fieldType.IsTypedef():
-		typedefDesc, err := fieldType.GetTypedefDescriptor()
-		if err != nil {
-			logs.Errorf("Error getting typedef descriptor: %s", err)
-			return nil
-		}
+	case typedefDesc != nil:
 		kindSchema = g.schemaOrReferenceForField(typedefDesc.Type)
 
-	case fieldType.IsEnum():
-		enumDesc, err := fieldType.GetEnumDescriptor()
-		if err != nil {
-			logs.Errorf("Error getting enum descriptor: %s", err)
-			return nil
-		}
+	case enumDesc != nil:
 		kindSchema = &openapi.SchemaOrReference{Schema: &openapi.Schema{}}
 		kindSchema.Schema.Type = "string"
 		kindSchema.Schema.Format = "enum"
 		kindSchema.Schema.Enum = make([]*openapi.Any, 0, len(enumDesc.GetValues()))
+		var deprecatedValues []string
 		for _, v := range enumDesc.GetValues() {
 			kindSchema.Schema.Enum = append(kindSchema.Schema.Enum, &openapi.Any{Yaml: v.GetName()})
+			if deprecated, _ := g.getDeprecation(v.Annotations, v.Comments); deprecated {
+				deprecatedValues = append(deprecatedValues, v.GetName())
+			}
 		}
-
-	case fieldType.IsUnion():
-		unionDesc, err := fieldType.GetUnionDescriptor()
-		if err != nil {
-			logs.Errorf("Error getting union descriptor: %s", err)
-			return nil
+		if len(deprecatedValues) > 0 {
+			kindSchema.Schema.SpecificationExtension = append(kindSchema.Schema.SpecificationExtension, &openapi.NamedAny{
+				Name:  consts.ExtensionDeprecatedEnumValues,
+				Value: &openapi.Any{Yaml: common.YAMLValue(deprecatedValues)},
+			})
 		}
+
+	case unionDesc != nil:
 		kindSchema = &openapi.SchemaOrReference{Schema: &openapi.Schema{}}
 		kindSchema.Schema.OneOf = make([]*openapi.SchemaOrReference, 0, len(unionDesc.GetFields()))
 		for _, f := range unionDesc.GetFields() {
@@ -842,8 +994,11 @@ func (g *OpenAPIGenerator) schemaOrReferenceForField(fieldType *thrift_reflectio
 			kindSchema.Schema.OneOf = append(kindSchema.Schema.OneOf, fieldSchema)
 		}
 
-	case fieldType.IsException():
-		logs.Errorf("Error: exception type not supported: %s for field", fieldType.GetName())
+	case g.descs.Exception(fieldType) != nil:
+		g.errorf(g.fileDesc.Filepath, common.DiagnosticUnsupportedType, "exception type %s is not supported for fields", fieldType.GetName())
+
+	case !fieldType.IsBasic():
+		g.errorf(g.fileDesc.Filepath, common.DiagnosticUnresolvedType, "type %s is not found", fieldType.GetName())
 
 	default:
 		kindSchema = &openapi.SchemaOrReference{Schema: &openapi.Schema{}}
@@ -878,3 +1033,124 @@ func (g *OpenAPIGenerator) schemaOrReferenceForField(fieldType *thrift_reflectio
 
 	return kindSchema
 }
+
+// getDeprecation reports whether an IDL element is deprecated by the `api.deprecated`/`deprecated` annotation
+// or by its comments, and returns the reason of the deprecation.
+func (g *OpenAPIGenerator) getDeprecation(annotations map[string][]string, comments string) (bool, string) {
+	for _, key := range []string{consts.ApiDeprecated, consts.Deprecated} {
+		if values, ok := annotations[key]; ok {
+			deprecated, reason := common.ParseDeprecation(values)
+			if deprecated && reason == "" {
+				reason = common.ParseComment(comments).DeprecatedReason
+			}
+			return deprecated, reason
+		}
+	}
+	comment := common.ParseComment(comments)
+	return comment.Deprecated, comment.DeprecatedReason
+}
+
+// safe reports whether a method has no side effects according to its `api.safe` annotation,
+// and reports the invalid values.
+func (g *OpenAPIGenerator) safe(annotations map[string][]string, location string) bool {
+	values := annotations[consts.ApiSafe]
+	if len(values) == 0 {
+		return false
+	}
+	safe, err := strconv.ParseBool(values[0])
+	if err != nil {
+		g.errorf(location, common.DiagnosticInvalidOption, "invalid %s annotation %q, expected true or false", consts.ApiSafe, values[0])
+	}
+	return safe
+}
+
+// visible reports whether an element is documented according to its `openapi.visibility` annotation,
+// and reports the invalid visibilities.
+func (g *OpenAPIGenerator) visible(annotations map[string][]string, location string) bool {
+	var visibility string
+	if values := annotations[consts.OpenapiVisibility]; len(values) > 0 {
+		visibility = values[0]
+	}
+	visible, err := g.filter.Visible(visibility)
+	if err != nil {
+		g.errorf(location, common.DiagnosticInvalidOption, "%s", err)
+	}
+	return visible
+}
+
+// visibleField reports whether a field of a struct is documented according to its visibility.
+// A field is read by the bodies and the schemas, so its visibility is validated the first time only.
+func (g *OpenAPIGenerator) visibleField(desc *thrift_reflection.StructDescriptor, field *thrift_reflection.FieldDescriptor) bool {
+	if visible, ok := g.fieldVisibilities[field]; ok {
+		return visible
+	}
+	visible := g.visible(field.Annotations, g.sources.Location(desc.Filepath, desc.GetName(), field.GetName()))
+	g.fieldVisibilities[field] = visible
+	return visible
+}
+
+// externalDocs returns the externalDocs of the first `@see` tag of a comment.
+func externalDocs(comment *common.Comment) *openapi.ExternalDocs {
+	if len(comment.See) == 0 {
+		return nil
+	}
+	return &openapi.ExternalDocs{URL: comment.See[0].URL, Description: comment.See[0].Description}
+}
+
+// example returns the value of the first `@example` tag of a comment.
+func example(comment *common.Comment) *openapi.Any {
+	if len(comment.Examples) == 0 {
+		return nil
+	}
+	return &openapi.Any{Yaml: comment.Examples[0]}
+}
+
+// deprecatedReasonExtension returns the x-deprecated-reason extension, or nothing when no reason is given.
+func deprecatedReasonExtension(reason string) []*openapi.NamedAny {
+	if reason == "" {
+		return nil
+	}
+	return []*openapi.NamedAny{{
+		Name:  consts.ExtensionDeprecatedReason,
+		Value: &openapi.Any{Yaml: common.YAMLValue(reason)},
+	}}
+}
+
+// safeExtension returns the x-safe extension, which allows an operation in the read-only mode of the proxy.
+func safeExtension() *openapi.NamedAny {
+	return &openapi.NamedAny{
+		Name:  consts.ExtensionSafe,
+		Value: &openapi.Any{Yaml: common.YAMLValue(true)},
+	}
+}
+
+// deprecateSchema marks a property as deprecated.
+// References are wrapped in allOf, because siblings of a $ref are ignored.
+func deprecateSchema(schema *openapi.SchemaOrReference, reason string) *openapi.SchemaOrReference {
+	if !schema.IsSetSchema() {
+		schema = &openapi.SchemaOrReference{
+			Schema: &openapi.Schema{AllOf: []*openapi.SchemaOrReference{schema}},
+		}
+	}
+	schema.Schema.Deprecated = true
+	schema.Schema.SpecificationExtension = append(schema.Schema.SpecificationExtension, deprecatedReasonExtension(reason)...)
+	return schema
+}
+
+// metaResponseHeaders returns the headers of the backward metainfo of the responses, or nil if there are none.
+func (g *OpenAPIGenerator) metaResponseHeaders() *openapi.HeadersOrReferences {
+	var headers []*openapi.NamedHeaderOrReference
+	for _, header := range g.metaKeys.ResponseHeaders() {
+		headers = append(headers, &openapi.NamedHeaderOrReference{
+			Name: header.Name,
+			Value: &openapi.HeaderOrReference{Header: &openapi.Header{
+				Description: header.Description,
+				Schema:      &openapi.SchemaOrReference{Schema: &openapi.Schema{Type: "string"}},
+			}},
+		})
+	}
+	if len(headers) == 0 {
+		return nil
+	}
+	return &openapi.HeadersOrReferences{AdditionalProperties: headers}
+}
@@ -0,0 +1,59 @@
+/*
+ * Copyright 2024 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package generator
+
+import (
+	"github.com/cloudwego/thriftgo/parser"
+	"github.com/cloudwego/thriftgo/plugin"
+	common "github.com/hertz-contrib/swagger-generate/common/utils"
+	"github.com/hertz-contrib/swagger-generate/thrift-gen-rpc-swagger/args"
+)
+
+// Generate generates the OpenAPI document and the swagger server of a thrift AST,
+// and returns the problems found in the IDL.
+func Generate(ast *parser.Thrift, args *args.Arguments) ([]*plugin.Generated, []*common.Diagnostic, error) {
+	contents, diagnostics, err := generate(ast, args)
+	// The deprecated options are reported apart from the document, so that they never fail the generation.
+	return contents, append(common.DeprecatedArgDiagnostics(args.Deprecated()), diagnostics...), err
+}
+
+func generate(ast *parser.Thrift, args *args.Arguments) ([]*plugin.Generated, []*common.Diagnostic, error) {
+	og := NewOpenAPIGenerator(ast)
+	openapiContent, err := og.BuildDocument(args)
+	if err != nil {
+		return nil, og.Diagnostics(), err
+	}
+
+	sg, err := NewServerGenerator(ast, args)
+	if err != nil {
+		return nil, og.Diagnostics(), err
+	}
+	for _, s := range sg.Idl.Services {
+		for _, m := range s.Methods {
+			m.BaseField, m.BaseKeys = og.RequestBase(s.Name, m.Name)
+		}
+	}
+	if err = sg.Idl.ApplyDocuments(og.Document()); err != nil {
+		return nil, og.Diagnostics(), err
+	}
+	serverContent, err := sg.Generate()
+	if err != nil {
+		return nil, og.Diagnostics(), err
+	}
+
+	return append(openapiContent, serverContent...), og.Diagnostics(), nil
+}
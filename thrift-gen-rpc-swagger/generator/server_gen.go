@@ -35,9 +35,10 @@ import (
 )
 
 type ServerGenerator struct {
-	IdlPath   string
+	utils.ClientOptions
 	KitexAddr string
 	OutputDir string
+	Idl       *utils.Idl // Idl is the IDL registered into the server, with its services and files.
 }
 
 func NewServerGenerator(ast *parser.Thrift, args *args.Arguments) (*ServerGenerator, error) {
@@ -63,57 +64,138 @@ func NewServerGenerator(ast *parser.Thrift, args *args.Arguments) (*ServerGenera
 		return nil, err
 	}
 
+	clientOptions := utils.ClientOptions{
+		Transport:      args.Transport,
+		RPCTimeout:     args.RPCTimeout,
+		ConnectTimeout: args.ConnectTimeout,
+		MaxRetries:     args.MaxRetries,
+		Codec:          args.Codec,
+	}
+	transports := []string{consts.TransportTTHeader, consts.TransportTTHeaderFramed, consts.TransportFramed, consts.TransportBuffered}
+	if err := clientOptions.Check(transports, []string{consts.CodecJSON, consts.CodecBinary}); err != nil {
+		return nil, err
+	}
+
+	idlFiles, err := readIdlFiles(ast)
+	if err != nil {
+		return nil, err
+	}
+
+	idl := &utils.Idl{
+		Name:          args.IdlName,
+		ServicePrefix: args.ServicePrefix,
+		Files:         idlFiles,
+	}
+	for _, svc := range ast.Services {
+		service := &utils.IdlService{Name: svc.Name, File: idlPath}
+		for _, f := range svc.Functions {
+			service.Methods = append(service.Methods, &utils.IdlMethod{Name: f.Name})
+		}
+		idl.Services = append(idl.Services, service)
+	}
+
 	return &ServerGenerator{
-		IdlPath:   idlPath,
-		KitexAddr: kitexAddr,
-		OutputDir: outputDir,
+		ClientOptions: clientOptions,
+		KitexAddr:     kitexAddr,
+		OutputDir:     outputDir,
+		Idl:           idl,
 	}, nil
 }
 
 func (g *ServerGenerator) Generate() ([]*plugin.Generated, error) {
+	idlContent, err := utils.EmbedIdl(consts.CodeGenerationCommentThriftRpc, g.Idl)
+	if err != nil {
+		return nil, err
+	}
+	_, idlFileName := utils.IdlOutputFiles(g.Idl.Name)
+	idlFilePath := filepath.Join(g.OutputDir, idlFileName)
+	idlFile := &plugin.Generated{
+		Content: string(idlContent),
+		Name:    &idlFilePath,
+	}
+
 	filePath := filepath.Join(g.OutputDir, consts.DefaultOutputSwaggerFile)
 
+	var content string
 	if utils.FileExists(filePath) {
-		updatedContent, err := updateVariables(filePath, g.KitexAddr, g.IdlPath)
+		if content, err = updateVariables(filePath, g.KitexAddr, g.ClientOptions); err != nil {
+			return nil, err
+		}
+	} else {
+		tmpl, err := template.New("server").Delims("{{", "}}").Parse(consts.BuildConstraintSwagger + "\n\n" + consts.CodeGenerationCommentThriftRpc + "\n" + tpl.ServerTemplateRpc)
 		if err != nil {
 			return nil, err
 		}
-		return []*plugin.Generated{{
-			Content: updatedContent,
-			Name:    &filePath,
-		}}, nil
-	}
 
-	tmpl, err := template.New("server").Delims("{{", "}}").Parse(consts.CodeGenerationCommentThriftRpc + "\n" + tpl.ServerTemplateRpc)
-	if err != nil {
-		return nil, err
+		var buf bytes.Buffer
+		err = tmpl.Execute(&buf, g)
+		if err != nil {
+			return nil, err
+		}
+		content = buf.String()
 	}
 
-	var buf bytes.Buffer
-	err = tmpl.Execute(&buf, g)
-	if err != nil {
-		return nil, err
+	generated := []*plugin.Generated{{
+		Content: content,
+		Name:    &filePath,
+	}, idlFile}
+	// The swagger files generated before the build constraint was introduced have no no-op file
+	if strings.Contains(content, consts.BuildConstraintSwagger) {
+		noopFilePath := filepath.Join(g.OutputDir, consts.DefaultOutputNoopFile)
+		generated = append(generated, &plugin.Generated{
+			Content: consts.BuildConstraintNoop + "\n\n" + consts.CodeGenerationCommentThriftRpc + "\n" + tpl.NoopTemplateRpc,
+			Name:    &noopFilePath,
+		})
 	}
+	return generated, nil
+}
 
-	return []*plugin.Generated{{
-		Content: buf.String(),
-		Name:    &filePath,
-	}}, nil
+// readIdlFiles returns the contents of a thrift file and of its includes, keyed by the path of the file
+// joined to the paths of the includes, the way the generic client of the server resolves them.
+func readIdlFiles(ast *parser.Thrift) (map[string]string, error) {
+	files := make(map[string]string)
+	var read func(path string, tree *parser.Thrift) error
+	read = func(path string, tree *parser.Thrift) error {
+		if _, ok := files[path]; ok {
+			return nil
+		}
+		content, err := ioutil.ReadFile(tree.Filename)
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %w", tree.Filename, err)
+		}
+		files[path] = string(content)
+		for _, include := range tree.Includes {
+			if include.Reference == nil {
+				continue
+			}
+			includePath := include.Path
+			if !filepath.IsAbs(includePath) {
+				includePath = filepath.Join(filepath.Dir(path), includePath)
+			}
+			if err = read(includePath, include.Reference); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	return files, read(ast.Filename, ast)
 }
 
-func updateVariables(filePath, newKitexAddr, newIdlPath string) (string, error) {
+func updateVariables(filePath, newKitexAddr string, clientOptions utils.ClientOptions) (string, error) {
 	content, err := ioutil.ReadFile(filePath)
 	if err != nil {
 		return "", err
 	}
 
-	kitexAddrPattern := regexp.MustCompile(`kitexAddr\s*=\s*"(.*?)"`)
-	idlPathPattern := regexp.MustCompile(`idlFile\s*=\s*"(.*?)"`)
+	// Files generated before swagger-runtime was used declare the proxy themselves, and do not compile with idl.go.
+	if !bytes.Contains(content, []byte("kitexproxy.Server")) {
+		return "", fmt.Errorf("%s does not support the generated IDL files, remove it to regenerate", filePath)
+	}
 
+	kitexAddrPattern := regexp.MustCompile(`kitexAddr\s*=\s*"(.*?)"`)
 	updatedContent := kitexAddrPattern.ReplaceAllString(string(content), fmt.Sprintf(`kitexAddr = "%s"`, newKitexAddr))
-	updatedContent = idlPathPattern.ReplaceAllString(updatedContent, fmt.Sprintf(`idlFile = "%s"`, newIdlPath))
 
-	return updatedContent, nil
+	return utils.UpdateConsts(filePath, updatedContent, clientOptions.Consts())
 }
 
 func validateAddress(addr string) error {
@@ -11,5 +11,8 @@ require (
 	github.com/hertz-contrib/cors v0.1.0
 	github.com/hertz-contrib/swagger v0.1.0
 	github.com/hertz-contrib/swagger-generate v0.0.0-20240921161005-987932fb30c5
-	github.com/swaggo/files v1.0.1
+	github.com/hertz-contrib/swagger-generate/swagger-runtime v0.1.0
+	github.com/hertz-contrib/swagger-generate/ui-assets v0.1.0 // indirect
+	github.com/swaggo/files v1.0.1 // indirect
+	gopkg.in/yaml.v3 v3.0.1
 )
@@ -0,0 +1,37 @@
+/*
+ * Copyright 2024 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package uiassets embeds the scripts of the UIs the generated swagger servers offer besides Swagger UI,
+// so that they are served by the servers instead of being loaded from a CDN.
+package uiassets
+
+import (
+	"embed"
+	"io/fs"
+)
+
+//go:embed dist
+var dist embed.FS
+
+// FS contains redoc.standalone.js, rapidoc-min.js and scalar.standalone.js, as downloaded by fetch.sh.
+var FS fs.FS
+
+func init() {
+	var err error
+	if FS, err = fs.Sub(dist, "dist"); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,20 @@
+#!/usr/bin/env bash
+
+# Downloads the scripts embedded by the package into dist, and records their checksums in dist/SHA256SUMS.
+# Run it before tagging the module, or to upgrade the scripts within their major versions, and commit dist.
+
+set -o errexit
+set -o nounset
+set -o pipefail
+
+cd "$(dirname "${BASH_SOURCE[0]}")/dist"
+
+fetch() {
+	curl --fail --silent --show-error --location --output "$1" "$2"
+}
+
+fetch redoc.standalone.js https://cdn.jsdelivr.net/npm/redoc@2/bundles/redoc.standalone.js
+fetch rapidoc-min.js https://cdn.jsdelivr.net/npm/rapidoc@9/dist/rapidoc-min.js
+fetch scalar.standalone.js https://cdn.jsdelivr.net/npm/@scalar/api-reference@1/dist/browser/standalone.js
+
+sha256sum redoc.standalone.js rapidoc-min.js scalar.standalone.js >SHA256SUMS
@@ -0,0 +1,3 @@
+module github.com/hertz-contrib/swagger-generate/ui-assets
+
+go 1.16